@@ -0,0 +1,90 @@
+//go:build !coverage
+
+// Command check-rosters compares team documents with users, which hold team membership,
+// and prints the drift found as JSON. With -repair legacy members arrays are folded into users
+// and teams referenced only by users get a document. It uses the same environment as the server.
+//
+//	MONGO_URI=mongodb://localhost:27017 go run ./cmd/check-rosters -repair
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"assignment-service/internal/config"
+	"assignment-service/internal/service"
+	"assignment-service/internal/storage"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "fix the drift found, only reported by default")
+	flag.Parse()
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("failed to initialize logger: %v", err)
+	}
+	//nolint:errcheck
+	defer logger.Sync()
+
+	cfg := config.MustLoad(logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// the memory backend would start empty and lose the changes on exit
+	if cfg.StorageBackend == config.StorageMemory {
+		logger.Fatal("persistent STORAGE_BACKEND required", zap.String("backend", cfg.StorageBackend))
+	}
+
+	store, err := storage.Open(ctx, cfg, logger)
+	if err != nil {
+		logger.Fatal("failed to open storage", zap.Error(err))
+	}
+	//nolint:errcheck
+	defer store.Close(context.Background())
+
+	teamService := service.NewTeamService(
+		store.Teams,
+		store.Users,
+		nil, // roster checks do not reassign reviews
+		store.UnitOfWork,
+		logger,
+	)
+
+	drifts, err := teamService.CheckRosters(ctx, *repair)
+	if err != nil {
+		logger.Fatal("failed to check rosters", zap.Error(err))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(drifts); err != nil {
+		logger.Fatal("failed to write drift", zap.Error(err))
+	}
+
+	counts := make(map[string]int)
+	unrepaired := 0
+	for _, drift := range drifts {
+		counts[drift.Kind]++
+		if !drift.Repaired {
+			unrepaired++
+		}
+	}
+	logger.Info("rosters checked",
+		zap.Bool("repair", *repair),
+		zap.Int("drift", len(drifts)),
+		zap.Int("unrepaired", unrepaired),
+		zap.Any("by_kind", counts))
+
+	if unrepaired > 0 {
+		os.Exit(1)
+	}
+}
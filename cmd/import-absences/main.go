@@ -0,0 +1,100 @@
+//go:build !coverage
+
+// Command import-absences reads an iCalendar (.ics) file and creates out-of-office periods
+// for attendees matched to users by email. It uses the same environment as the server.
+//
+//	MONGO_URI=mongodb://localhost:27017 go run ./cmd/import-absences -file vacations.ics -handoff
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"assignment-service/internal/config"
+	"assignment-service/internal/domain"
+	"assignment-service/internal/service"
+	"assignment-service/internal/storage"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	path := flag.String("file", "", "path to the .ics file, - reads stdin")
+	handoff := flag.Bool("handoff", false, "hand off open reviews when an imported absence starts")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("failed to initialize logger: %v", err)
+	}
+	//nolint:errcheck
+	defer logger.Sync()
+
+	cfg := config.MustLoad(logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var calendar io.Reader = os.Stdin
+	if *path != "-" {
+		file, err := os.Open(*path)
+		if err != nil {
+			logger.Fatal("failed to open calendar", zap.Error(err))
+		}
+		//nolint:errcheck
+		defer file.Close()
+		calendar = file
+	}
+
+	// the memory backend would start empty and lose the changes on exit
+	if cfg.StorageBackend == config.StorageMemory {
+		logger.Fatal("persistent STORAGE_BACKEND required", zap.String("backend", cfg.StorageBackend))
+	}
+
+	store, err := storage.Open(ctx, cfg, logger)
+	if err != nil {
+		logger.Fatal("failed to open storage", zap.Error(err))
+	}
+	//nolint:errcheck
+	defer store.Close(context.Background())
+
+	userService := service.NewUserService(store.Users, logger)
+
+	results, err := userService.ImportAbsences(ctx, calendar, *handoff)
+	if err != nil {
+		logger.Fatal("failed to import absences", zap.Error(err))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(results); err != nil {
+		logger.Fatal("failed to write results", zap.Error(err))
+	}
+
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[result.Outcome]++
+	}
+	logger.Info("absences imported",
+		zap.Int("created", counts[domain.AbsenceImportCreated]),
+		zap.Int("updated", counts[domain.AbsenceImportUpdated]),
+		zap.Int("unchanged", counts[domain.AbsenceImportUnchanged]),
+		zap.Int("removed", counts[domain.AbsenceImportRemoved]),
+		zap.Int("skipped", counts[domain.AbsenceImportSkipped]),
+		zap.Int("failed", counts[domain.AbsenceImportFailed]))
+
+	if counts[domain.AbsenceImportFailed] > 0 {
+		os.Exit(1)
+	}
+}
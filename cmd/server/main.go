@@ -12,7 +12,7 @@ import (
 
 	"assignment-service/internal/config"
 	httphandler "assignment-service/internal/http"
-	"assignment-service/internal/repository/mongodb"
+	"assignment-service/internal/storage"
 
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -37,20 +37,23 @@ func main() {
 
 	g, ctx := errgroup.WithContext(ctx)
 
-	// Database
-	logger.Info("connecting to MongoDB...")
-	mongoClient, err := mongodb.NewClient(ctx, cfg.MongoURI, cfg.MongoDB, cfg.MongoConnectTimeout, logger)
+	// Storage
+	logger.Info("opening storage...", zap.String("backend", cfg.StorageBackend))
+	store, err := storage.Open(ctx, cfg, logger)
 	if err != nil {
-		logger.Fatal("failed to connect to MongoDB", zap.Error(err))
+		logger.Fatal("failed to open storage", zap.Error(err))
 	}
-	logger.Info("successfully connected to MongoDB")
+	logger.Info("storage opened", zap.String("backend", cfg.StorageBackend))
 
 	// Server
-	router := httphandler.SetupRouter(mongoClient, logger)
+	app, err := httphandler.Setup(store.Repositories, cfg, logger)
+	if err != nil {
+		logger.Fatal("failed to setup router", zap.Error(err))
+	}
 
 	srv := &http.Server{
 		Addr:         ":" + cfg.ServerPort,
-		Handler:      router,
+		Handler:      app.Router,
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.WriteTimeout,
 		IdleTimeout:  cfg.IdleTimeout,
@@ -67,6 +70,20 @@ func main() {
 		return nil
 	})
 
+	// Background jobs, closed jobsDone tells that they no longer use the storage
+	jobsDone := make(chan struct{})
+	if app.AbsenceHandoff != nil {
+		g.Go(func() error {
+			defer close(jobsDone)
+			logger.Info("starting absence handoff job", zap.Duration("interval", cfg.AbsenceHandoffInterval))
+			app.AbsenceHandoff.Run(ctx)
+			logger.Info("absence handoff job stopped")
+			return nil
+		})
+	} else {
+		close(jobsDone)
+	}
+
 	// Graceful Shutdown
 	g.Go(func() error {
 		<-ctx.Done()
@@ -83,12 +100,19 @@ func main() {
 			logger.Info("HTTP server stopped gracefully")
 		}
 
-		// 2. Database
-		logger.Info("closing MongoDB connection...")
-		if err := mongoClient.Close(context.Background()); err != nil {
-			logger.Error("error closing MongoDB connection", zap.Error(err))
+		// 2. Background jobs
+		select {
+		case <-jobsDone:
+		case <-shutdownCtx.Done():
+			logger.Error("background jobs did not stop in time")
+		}
+
+		// 3. Storage
+		logger.Info("closing storage...")
+		if err := store.Close(context.Background()); err != nil {
+			logger.Error("error closing storage", zap.Error(err))
 		} else {
-			logger.Info("MongoDB connection closed successfully")
+			logger.Info("storage closed successfully")
 		}
 
 		return nil
@@ -12,6 +12,16 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// Storage backends
+const (
+	StorageMongoDB  = "mongodb"
+	StoragePostgres = "postgres"
+	// StorageSQLite keeps data in a local file, the service needs no database server
+	StorageSQLite = "sqlite"
+	// StorageMemory keeps data in process memory until exit, for tests and local runs
+	StorageMemory = "memory"
+)
+
 type Config struct {
 	// server
 	ServerPort              string        `env:"SERVER_PORT" envDefault:"8080"`
@@ -21,9 +31,25 @@ type Config struct {
 	GracefulShutdownTimeout time.Duration `env:"GRACEFUL_SHUTDOWN_TIMEOUT" envDefault:"30s"`
 
 	// db
-	MongoURI            string        `env:"MONGO_URI" envRequired:"true"`
-	MongoDB             string        `env:"MONGO_DB" envDefault:"assignment_service"`
-	MongoConnectTimeout time.Duration `env:"MONGO_CONNECT_TIMEOUT" envDefault:"10s"`
+	StorageBackend       string        `env:"STORAGE_BACKEND" envDefault:"mongodb"` // mongodb, postgres, sqlite or memory
+	MongoURI             string        `env:"MONGO_URI"`                            // required by the mongodb backend
+	MongoDB              string        `env:"MONGO_DB" envDefault:"assignment_service"`
+	MongoConnectTimeout  time.Duration `env:"MONGO_CONNECT_TIMEOUT" envDefault:"10s"`
+	MongoAllowStandalone bool          `env:"MONGO_ALLOW_STANDALONE" envDefault:"false"` // standalone server has no transactions, writes are not atomic
+
+	PostgresURI            string        `env:"POSTGRES_URI"` // required by the postgres backend
+	PostgresConnectTimeout time.Duration `env:"POSTGRES_CONNECT_TIMEOUT" envDefault:"10s"`
+
+	SQLitePath string `env:"SQLITE_PATH" envDefault:"assignment_service.db"` // created on first start
+
+	// reviewers
+	ReviewerStrategy       string            `env:"REVIEWER_STRATEGY" envDefault:"random"`
+	TeamReviewerStrategies map[string]string `env:"TEAM_REVIEWER_STRATEGIES"`            // team_a:round_robin,team_b:random
+	OverflowTeam           string            `env:"OVERFLOW_TEAM"`                       // lends reviewers when the author's team is at capacity
+	ReassignTimeLimit      time.Duration     `env:"REASSIGN_TIME_LIMIT" envDefault:"8s"` // bulk reassignment stops in time to answer before WRITE_TIMEOUT
+
+	// absences
+	AbsenceHandoffInterval time.Duration `env:"ABSENCE_HANDOFF_INTERVAL" envDefault:"1m"` // 0 disables the background handoff
 }
 
 func Load() (*Config, error) {
@@ -72,14 +98,54 @@ func validateConfig(c *Config) error {
 	}
 
 	// db
-	if strings.TrimSpace(c.MongoDB) == "" {
-		return fmt.Errorf("MONGO_DB must not be empty")
+	switch c.StorageBackend {
+	case StorageMongoDB:
+		if strings.TrimSpace(c.MongoDB) == "" {
+			return fmt.Errorf("MONGO_DB must not be empty")
+		}
+		if c.MongoConnectTimeout < 5*time.Second {
+			return fmt.Errorf("MONGO_CONNECT_TIMEOUT must be >= 5s, got: %v", c.MongoConnectTimeout)
+		}
+	case StoragePostgres:
+		if c.PostgresConnectTimeout < 5*time.Second {
+			return fmt.Errorf("POSTGRES_CONNECT_TIMEOUT must be >= 5s, got: %v", c.PostgresConnectTimeout)
+		}
+	case StorageSQLite:
+		if strings.TrimSpace(c.SQLitePath) == "" {
+			return fmt.Errorf("SQLITE_PATH must not be empty")
+		}
+	case StorageMemory:
+	default:
+		return fmt.Errorf("STORAGE_BACKEND must be %s, %s, %s or %s, got: %s",
+			StorageMongoDB, StoragePostgres, StorageSQLite, StorageMemory, c.StorageBackend)
 	}
-	if c.MongoConnectTimeout < 5*time.Second {
-		return fmt.Errorf("MONGO_CONNECT_TIMEOUT must be >= 5s, got: %v", c.MongoConnectTimeout)
+
+	// reviewers
+	if strings.TrimSpace(c.ReviewerStrategy) == "" {
+		return fmt.Errorf("REVIEWER_STRATEGY must not be empty")
+	}
+	for team, strategy := range c.TeamReviewerStrategies {
+		if strings.TrimSpace(team) == "" || strings.TrimSpace(strategy) == "" {
+			return fmt.Errorf("TEAM_REVIEWER_STRATEGIES must contain non-empty team:strategy pairs, got: %q:%q", team, strategy)
+		}
+	}
+	if c.ReassignTimeLimit <= 0 || c.ReassignTimeLimit >= c.WriteTimeout {
+		return fmt.Errorf("REASSIGN_TIME_LIMIT must be > 0 and < WRITE_TIMEOUT (%v), got: %v", c.WriteTimeout, c.ReassignTimeLimit)
 	}
 
-	return validateMongoURI(c.MongoURI)
+	// absences
+	if c.AbsenceHandoffInterval != 0 && c.AbsenceHandoffInterval < time.Second {
+		return fmt.Errorf("ABSENCE_HANDOFF_INTERVAL must be 0 or >= 1s, got: %v", c.AbsenceHandoffInterval)
+	}
+
+	switch c.StorageBackend {
+	case StorageMongoDB:
+		return validateMongoURI(c.MongoURI)
+	case StoragePostgres:
+		return validatePostgresURI(c.PostgresURI)
+	default:
+		return nil
+	}
 }
 
 func validateMongoURI(uri string) error {
@@ -107,8 +173,26 @@ func validateMongoURI(uri string) error {
 	return nil
 }
 
-// MongoDB connection string may contain password data, so it must be hidden during logging
-func maskMongoURI(uri string) string {
+func validatePostgresURI(uri string) error {
+	if uri == "" {
+		return fmt.Errorf("POSTGRES_URI is required")
+	}
+	if !strings.HasPrefix(uri, "postgres://") && !strings.HasPrefix(uri, "postgresql://") {
+		return fmt.Errorf("POSTGRES_URI must start with postgres:// or postgresql://, got: %s", maskURI(uri))
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("POSTGRES_URI is invalid URL: %w", err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("POSTGRES_URI must contain a host")
+	}
+	return nil
+}
+
+// Connection strings may contain password data, so it must be hidden during logging
+func maskURI(uri string) string {
 	if uri == "" {
 		return "<empty>"
 	}
@@ -128,8 +212,18 @@ func (c *Config) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddDuration("write_timeout", c.WriteTimeout)
 	enc.AddDuration("idle_timeout", c.IdleTimeout)
 	enc.AddDuration("graceful_shutdown_timeout", c.GracefulShutdownTimeout)
-	enc.AddString("mongo_uri", maskMongoURI(c.MongoURI))
+	enc.AddString("storage_backend", c.StorageBackend)
+	enc.AddString("mongo_uri", maskURI(c.MongoURI))
 	enc.AddString("mongo_db", c.MongoDB)
 	enc.AddDuration("mongo_connect_timeout", c.MongoConnectTimeout)
+	enc.AddBool("mongo_allow_standalone", c.MongoAllowStandalone)
+	enc.AddString("postgres_uri", maskURI(c.PostgresURI))
+	enc.AddDuration("postgres_connect_timeout", c.PostgresConnectTimeout)
+	enc.AddString("sqlite_path", c.SQLitePath)
+	enc.AddString("reviewer_strategy", c.ReviewerStrategy)
+	enc.AddInt("team_reviewer_strategies", len(c.TeamReviewerStrategies))
+	enc.AddString("overflow_team", c.OverflowTeam)
+	enc.AddDuration("reassign_time_limit", c.ReassignTimeLimit)
+	enc.AddDuration("absence_handoff_interval", c.AbsenceHandoffInterval)
 	return nil
 }
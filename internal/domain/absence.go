@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Absence is a period when the user is out of office and gets no reviews.
+// From is inclusive, To is exclusive.
+type Absence struct {
+	AbsenceID string    `bson:"absence_id" json:"absence_id"`
+	From      time.Time `bson:"from" json:"from"`
+	To        time.Time `bson:"to" json:"to"`
+	Reason    string    `bson:"reason,omitempty" json:"reason,omitempty"`
+
+	// Handoff asks to reassign the user's open reviews when the absence starts
+	Handoff     bool       `bson:"handoff" json:"handoff"`
+	HandedOffAt *time.Time `bson:"handed_off_at,omitempty" json:"handed_off_at,omitempty"`
+}
+
+func (a *Absence) Validate() error {
+	if a.From.IsZero() || a.To.IsZero() || !a.From.Before(a.To) {
+		return ErrInvalidAbsence
+	}
+	return nil
+}
+
+// Covers reports whether t falls into the absence
+func (a *Absence) Covers(t time.Time) bool {
+	return !t.Before(a.From) && t.Before(a.To)
+}
+
+// HandoffDue reports whether open reviews have to be handed off at t
+func (a *Absence) HandoffDue(t time.Time) bool {
+	return a.Handoff && a.HandedOffAt == nil && a.Covers(t)
+}
+
+// IsAbsent reports whether one of the user's absences covers t
+func (u *User) IsAbsent(t time.Time) bool {
+	for i := range u.Absences {
+		if u.Absences[i].Covers(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Outcomes of importing a calendar event for one attendee
+const (
+	AbsenceImportCreated   = "created"
+	AbsenceImportUpdated   = "updated"
+	AbsenceImportUnchanged = "unchanged"
+	AbsenceImportRemoved   = "removed"
+	AbsenceImportSkipped   = "skipped"
+	AbsenceImportFailed    = "failed"
+)
+
+// AbsenceImportResult reports what happened to a calendar event for one of its attendees
+type AbsenceImportResult struct {
+	EventUID  string `json:"event_uid"`
+	Summary   string `json:"summary,omitempty"`
+	Email     string `json:"email,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	AbsenceID string `json:"absence_id,omitempty"`
+	Outcome   string `json:"outcome"`
+	Error     string `json:"error,omitempty"`
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAbsence_Validate(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		absence Absence
+		wantErr bool
+	}{
+		{"valid", Absence{From: from, To: from.Add(24 * time.Hour)}, false},
+		{"missing from", Absence{To: from}, true},
+		{"missing to", Absence{From: from}, true},
+		{"empty range", Absence{From: from, To: from}, true},
+		{"reversed range", Absence{From: from, To: from.Add(-time.Hour)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.absence.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err != ErrInvalidAbsence {
+				t.Fatalf("expected ErrInvalidAbsence, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAbsence_Covers(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	a := Absence{From: from, To: from.Add(48 * time.Hour)}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{"before", from.Add(-time.Second), false},
+		{"start is inclusive", from, true},
+		{"inside", from.Add(24 * time.Hour), true},
+		{"end is exclusive", from.Add(48 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.Covers(tt.at); got != tt.expected {
+				t.Errorf("Covers(%v) = %v, want %v", tt.at, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAbsence_HandoffDue(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	at := from.Add(time.Hour)
+	handedOff := from
+
+	tests := []struct {
+		name     string
+		absence  Absence
+		expected bool
+	}{
+		{"handoff requested", Absence{From: from, To: from.Add(24 * time.Hour), Handoff: true}, true},
+		{"handoff not requested", Absence{From: from, To: from.Add(24 * time.Hour)}, false},
+		{"already handed off", Absence{From: from, To: from.Add(24 * time.Hour), Handoff: true, HandedOffAt: &handedOff}, false},
+		{"not started", Absence{From: at.Add(time.Hour), To: at.Add(2 * time.Hour), Handoff: true}, false},
+		{"finished", Absence{From: from.Add(-2 * time.Hour), To: from, Handoff: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.absence.HandoffDue(at); got != tt.expected {
+				t.Errorf("HandoffDue() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUser_IsAbsent(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	u := User{Absences: []Absence{
+		{From: from, To: from.Add(24 * time.Hour)},
+		{From: from.Add(72 * time.Hour), To: from.Add(96 * time.Hour)},
+	}}
+
+	if !u.IsAbsent(from.Add(time.Hour)) {
+		t.Error("expected user to be absent during the first absence")
+	}
+	if u.IsAbsent(from.Add(48 * time.Hour)) {
+		t.Error("expected user to be available between absences")
+	}
+	if !u.IsAbsent(from.Add(80 * time.Hour)) {
+		t.Error("expected user to be absent during the second absence")
+	}
+	if (&User{}).IsAbsent(from) {
+		t.Error("expected user without absences to be available")
+	}
+}
@@ -13,6 +13,23 @@ var (
 	ErrUserNotFound = errors.New("user not found")
 	ErrTeamNotFound = errors.New("team not found")
 	ErrPRNotFound   = errors.New("PR not found")
+
+	ErrInvalidReviewersCount = errors.New("reviewers count violates team policy")
+	ErrInvalidReviewState    = errors.New("review state must be APPROVED, CHANGES_REQUESTED or COMMENTED")
+	ErrNotEnoughApprovals    = errors.New("PR does not have enough approvals to be merged")
+
+	ErrPRClosed          = errors.New("PR is closed")
+	ErrPRDraft           = errors.New("PR is a draft")
+	ErrInvalidTransition = errors.New("PR status transition is not allowed")
+
+	ErrInvalidQuery = errors.New("invalid query parameters")
+
+	ErrInvalidAbsence  = errors.New("absence must have from before to")
+	ErrAbsenceNotFound = errors.New("absence not found")
+	ErrInvalidCalendar = errors.New("invalid iCalendar file")
+
+	ErrUserInAnotherTeam = errors.New("user is a member of another team")
+	ErrTeamNotEmpty      = errors.New("team still has members")
 )
 
 type ErrorCode string
@@ -26,6 +43,22 @@ const (
 	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
+
+	ErrorCodeInvalidReviewersCount ErrorCode = "INVALID_REVIEWERS_COUNT"
+	ErrorCodeInvalidReviewState    ErrorCode = "INVALID_REVIEW_STATE"
+	ErrorCodeNotEnoughApprovals    ErrorCode = "NOT_ENOUGH_APPROVALS"
+
+	ErrorCodePRClosed          ErrorCode = "PR_CLOSED"
+	ErrorCodePRDraft           ErrorCode = "PR_DRAFT"
+	ErrorCodeInvalidTransition ErrorCode = "INVALID_STATUS_TRANSITION"
+
+	ErrorCodeInvalidQuery ErrorCode = "INVALID_QUERY"
+
+	ErrorCodeInvalidAbsence  ErrorCode = "INVALID_ABSENCE"
+	ErrorCodeInvalidCalendar ErrorCode = "INVALID_CALENDAR"
+
+	ErrorCodeUserInAnotherTeam ErrorCode = "USER_IN_ANOTHER_TEAM"
+	ErrorCodeTeamNotEmpty      ErrorCode = "TEAM_NOT_EMPTY"
 )
 
 // domain error code -> API error code
@@ -41,7 +74,29 @@ func ToErrorCode(err error) ErrorCode {
 		return ErrorCodeNotAssigned
 	case ErrNoCandidate:
 		return ErrorCodeNoCandidate
-	case ErrNotFound, ErrUserNotFound, ErrTeamNotFound, ErrPRNotFound:
+	case ErrInvalidReviewersCount:
+		return ErrorCodeInvalidReviewersCount
+	case ErrInvalidReviewState:
+		return ErrorCodeInvalidReviewState
+	case ErrNotEnoughApprovals:
+		return ErrorCodeNotEnoughApprovals
+	case ErrPRClosed:
+		return ErrorCodePRClosed
+	case ErrPRDraft:
+		return ErrorCodePRDraft
+	case ErrInvalidTransition:
+		return ErrorCodeInvalidTransition
+	case ErrInvalidQuery:
+		return ErrorCodeInvalidQuery
+	case ErrInvalidAbsence:
+		return ErrorCodeInvalidAbsence
+	case ErrInvalidCalendar:
+		return ErrorCodeInvalidCalendar
+	case ErrUserInAnotherTeam:
+		return ErrorCodeUserInAnotherTeam
+	case ErrTeamNotEmpty:
+		return ErrorCodeTeamNotEmpty
+	case ErrNotFound, ErrUserNotFound, ErrTeamNotFound, ErrPRNotFound, ErrAbsenceNotFound:
 		return ErrorCodeNotFound
 	default:
 		return ErrorCodeNotFound
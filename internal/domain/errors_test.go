@@ -13,10 +13,22 @@ func TestToErrorCode(t *testing.T) {
 		{"pr merged", ErrPRMerged, ErrorCodePRMerged},
 		{"not assigned", ErrNotAssigned, ErrorCodeNotAssigned},
 		{"no candidate", ErrNoCandidate, ErrorCodeNoCandidate},
+		{"invalid reviewers count", ErrInvalidReviewersCount, ErrorCodeInvalidReviewersCount},
+		{"invalid review state", ErrInvalidReviewState, ErrorCodeInvalidReviewState},
+		{"not enough approvals", ErrNotEnoughApprovals, ErrorCodeNotEnoughApprovals},
+		{"pr closed", ErrPRClosed, ErrorCodePRClosed},
+		{"pr draft", ErrPRDraft, ErrorCodePRDraft},
+		{"invalid transition", ErrInvalidTransition, ErrorCodeInvalidTransition},
+		{"invalid query", ErrInvalidQuery, ErrorCodeInvalidQuery},
+		{"invalid absence", ErrInvalidAbsence, ErrorCodeInvalidAbsence},
+		{"invalid calendar", ErrInvalidCalendar, ErrorCodeInvalidCalendar},
+		{"user in another team", ErrUserInAnotherTeam, ErrorCodeUserInAnotherTeam},
+		{"team not empty", ErrTeamNotEmpty, ErrorCodeTeamNotEmpty},
 		{"not found generic", ErrNotFound, ErrorCodeNotFound},
 		{"user not found", ErrUserNotFound, ErrorCodeNotFound},
 		{"team not found", ErrTeamNotFound, ErrorCodeNotFound},
 		{"pr not found", ErrPRNotFound, ErrorCodeNotFound},
+		{"absence not found", ErrAbsenceNotFound, ErrorCodeNotFound},
 		{"unknown error maps to not found", errUnknown{}, ErrorCodeNotFound},
 	}
 
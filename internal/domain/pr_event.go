@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+type PREventType string
+
+const (
+	PREventCreated            PREventType = "created"
+	PREventReadyForReview     PREventType = "ready_for_review"
+	PREventReviewerAssigned   PREventType = "reviewer_assigned"
+	PREventReviewerUnassigned PREventType = "reviewer_unassigned"
+	PREventReviewerReplaced   PREventType = "reviewer_replaced"
+	PREventReviewSubmitted    PREventType = "review_submitted"
+	PREventMerged             PREventType = "merged"
+	PREventClosed             PREventType = "closed"
+	PREventReopened           PREventType = "reopened"
+)
+
+// Reasons of reviewer changes
+const (
+	PREventReasonAssignment     = "assignment"
+	PREventReasonManualReassign = "manual_reassign"
+	PREventReasonClosed         = "pr_closed"
+	PREventReasonDeactivated    = "reviewer_deactivated"
+	PREventReasonAbsent         = "reviewer_absent"
+	PREventReasonLeftTeam       = "reviewer_left_team"
+	PREventReasonMovedTeam      = "reviewer_moved_team"
+)
+
+// PREventReasonLegacyTeamReassignment is the reason of the reviewer_unassigned events the team reassignment wrote
+// before it replaced reviewers with reviewer_replaced events. Nothing writes it any more, the stored events still
+// count as reassignments in IsReassignment and in the ListReassignmentTimings queries of every repository.
+const PREventReasonLegacyTeamReassignment = "team_reassignment"
+
+// PREvent is an append-only record of a PR change
+type PREvent struct {
+	PullRequestID string      `bson:"pull_request_id" json:"pull_request_id"`
+	Type          PREventType `bson:"type" json:"type"`
+	At            time.Time   `bson:"at" json:"at"`
+
+	// reviewer the event is about and, for replacements, the previous reviewer
+	ReviewerID         string `bson:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+	ReplacedReviewerID string `bson:"replaced_reviewer_id,omitempty" json:"replaced_reviewer_id,omitempty"`
+	// team the reviewer was taken from
+	TeamName    string      `bson:"team_name,omitempty" json:"team_name,omitempty"`
+	Reason      string      `bson:"reason,omitempty" json:"reason,omitempty"`
+	ReviewState ReviewState `bson:"review_state,omitempty" json:"review_state,omitempty"`
+}
+
+// IsReassignment tells whether the event takes a reviewer off an open PR in favour of another one
+func (e *PREvent) IsReassignment() bool {
+	switch e.Type {
+	case PREventReviewerReplaced:
+		return true
+	case PREventReviewerUnassigned:
+		return e.Reason == PREventReasonLegacyTeamReassignment
+	default:
+		return false
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestPREvent_IsReassignment(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    PREvent
+		expected bool
+	}{
+		{"manual replacement", PREvent{Type: PREventReviewerReplaced, Reason: PREventReasonManualReassign}, true},
+		{"team reassignment", PREvent{Type: PREventReviewerUnassigned, Reason: PREventReasonLegacyTeamReassignment}, true},
+		{"released on close", PREvent{Type: PREventReviewerUnassigned, Reason: PREventReasonClosed}, false},
+		{"initial assignment", PREvent{Type: PREventReviewerAssigned, Reason: PREventReasonAssignment}, false},
+		{"merge", PREvent{Type: PREventMerged}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.IsReassignment(); got != tt.expected {
+				t.Errorf("IsReassignment() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
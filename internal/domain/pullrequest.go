@@ -1,12 +1,57 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type PRStatus string
 
 const (
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
+)
+
+// prTransitions lists allowed status changes, MERGED is final
+var prTransitions = map[PRStatus][]PRStatus{
+	PRStatusDraft:  {PRStatusOpen, PRStatusClosed},
+	PRStatusOpen:   {PRStatusMerged, PRStatusClosed},
+	PRStatusClosed: {PRStatusOpen},
+}
+
+// Valid reports whether the status is one of the known PR statuses
+func (s PRStatus) Valid() bool {
+	switch s {
+	case PRStatusDraft, PRStatusOpen, PRStatusMerged, PRStatusClosed:
+		return true
+	}
+	return false
+}
+
+func (s PRStatus) CanTransitionTo(to PRStatus) bool {
+	return slices.Contains(prTransitions[s], to)
+}
+
+// err explains why a PR in this status cannot be changed
+func (s PRStatus) err() error {
+	switch s {
+	case PRStatusMerged:
+		return ErrPRMerged
+	case PRStatusClosed:
+		return ErrPRClosed
+	case PRStatusDraft:
+		return ErrPRDraft
+	default:
+		return ErrInvalidTransition
+	}
+}
+
+// Fields PR lists can be sorted by
+const (
+	PRSortCreatedAt = "created_at"
+	PRSortID        = "pull_request_id"
 )
 
 type PullRequest struct {
@@ -17,15 +62,169 @@ type PullRequest struct {
 	AssignedReviewers []string   `bson:"assigned_reviewers" json:"assigned_reviewers"`
 	CreatedAt         *time.Time `bson:"created_at,omitempty" json:"createdAt,omitempty"`
 	MergedAt          *time.Time `bson:"merged_at,omitempty" json:"mergedAt,omitempty"`
+	ClosedAt          *time.Time `bson:"closed_at,omitempty" json:"closedAt,omitempty"`
+
+	// number of reviewers the PR should have, 0 for PRs created before the setting existed
+	RequiredReviewers int `bson:"required_reviewers,omitempty" json:"required_reviewers,omitempty"`
+	// fewer reviewers than required were assigned because candidates were missing or at capacity
+	UnderStaffed bool `bson:"under_staffed" json:"under_staffed"`
+	// fallback team of the author's team that lent reviewers
+	FallbackTeam string `bson:"fallback_team,omitempty" json:"fallback_team,omitempty"`
+	// team the reviewers were borrowed from when the author's team had no free reviewers
+	OverflowTeam string `bson:"overflow_team,omitempty" json:"overflow_team,omitempty"`
+
+	// review state of every assigned reviewer
+	Reviews []Review `bson:"reviews,omitempty" json:"reviews,omitempty"`
 }
 
 func (pr *PullRequest) IsMerged() bool {
 	return pr.Status == PRStatusMerged
 }
 
+// EnsureOpen returns the status specific error unless the PR is OPEN
+func (pr *PullRequest) EnsureOpen() error {
+	if pr.Status == PRStatusOpen {
+		return nil
+	}
+	return pr.Status.err()
+}
+
+// TransitionTo moves the PR to the status. Closing releases the reviewers.
+func (pr *PullRequest) TransitionTo(to PRStatus, now time.Time) error {
+	if !pr.Status.CanTransitionTo(to) {
+		if pr.Status == to || pr.Status == PRStatusOpen {
+			return ErrInvalidTransition
+		}
+		return pr.Status.err()
+	}
+
+	pr.Status = to
+	switch to {
+	case PRStatusMerged:
+		pr.MergedAt = &now
+	case PRStatusClosed:
+		pr.ClosedAt = &now
+		pr.AssignedReviewers = []string{}
+		pr.Reviews = nil
+		pr.UnderStaffed = false
+	case PRStatusOpen:
+		pr.ClosedAt = nil
+	}
+
+	return nil
+}
+
+// MarkReady moves a DRAFT PR to OPEN, reviewers are assigned by the caller
+func (pr *PullRequest) MarkReady(now time.Time) error {
+	if pr.Status != PRStatusDraft {
+		return pr.Status.err()
+	}
+	return pr.TransitionTo(PRStatusOpen, now)
+}
+
+// Reopen moves a CLOSED PR back to OPEN, reviewers are assigned by the caller
+func (pr *PullRequest) Reopen(now time.Time) error {
+	if pr.Status != PRStatusClosed {
+		return pr.Status.err()
+	}
+	return pr.TransitionTo(PRStatusOpen, now)
+}
+
+// SyncReviews keeps Reviews in line with AssignedReviewers:
+// new reviewers start as PENDING, reviews of removed reviewers are dropped
+func (pr *PullRequest) SyncReviews(now time.Time) {
+	reviews := make([]Review, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		if review := pr.ReviewOf(reviewerID); review != nil {
+			reviews = append(reviews, *review)
+			continue
+		}
+		reviews = append(reviews, Review{
+			ReviewerID: reviewerID,
+			State:      ReviewStatePending,
+			UpdatedAt:  &now,
+		})
+	}
+	pr.Reviews = reviews
+}
+
+func (pr *PullRequest) ReviewOf(reviewerID string) *Review {
+	for i := range pr.Reviews {
+		if pr.Reviews[i].ReviewerID == reviewerID {
+			return &pr.Reviews[i]
+		}
+	}
+	return nil
+}
+
+// SubmitReview records the reviewer's verdict. COMMENTED does not override an approval or a change request,
+// same as in most code hosting platforms.
+func (pr *PullRequest) SubmitReview(reviewerID string, state ReviewState, now time.Time) error {
+	if !state.IsSubmittable() {
+		return ErrInvalidReviewState
+	}
+	if err := pr.EnsureOpen(); err != nil {
+		return err
+	}
+	if !slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return ErrNotAssigned
+	}
+
+	pr.SyncReviews(now)
+	review := pr.ReviewOf(reviewerID)
+	if state != ReviewStateCommented || review.State == ReviewStatePending {
+		review.State = state
+	}
+	review.UpdatedAt = &now
+
+	return nil
+}
+
+// Approvals returns the number of assigned reviewers who approved the PR
+func (pr *PullRequest) Approvals() int {
+	approvals := 0
+	for _, review := range pr.Reviews {
+		if review.State == ReviewStateApproved && slices.Contains(pr.AssignedReviewers, review.ReviewerID) {
+			approvals++
+		}
+	}
+	return approvals
+}
+
+type ReviewState string
+
+const (
+	ReviewStatePending          ReviewState = "PENDING"
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewStateCommented        ReviewState = "COMMENTED"
+)
+
+// IsSubmittable reports whether a reviewer can submit the state, PENDING is set by the service only
+func (s ReviewState) IsSubmittable() bool {
+	switch s {
+	case ReviewStateApproved, ReviewStateChangesRequested, ReviewStateCommented:
+		return true
+	default:
+		return false
+	}
+}
+
+type Review struct {
+	ReviewerID string      `bson:"reviewer_id" json:"reviewer_id"`
+	State      ReviewState `bson:"state" json:"state"`
+	UpdatedAt  *time.Time  `bson:"updated_at,omitempty" json:"updatedAt,omitempty"`
+}
+
 type PullRequestShort struct {
-	PullRequestID   string   `json:"pull_request_id"`
-	PullRequestName string   `json:"pull_request_name"`
-	AuthorID        string   `json:"author_id"`
-	Status          PRStatus `json:"status"`
+	PullRequestID   string   `bson:"pull_request_id" json:"pull_request_id"`
+	PullRequestName string   `bson:"pull_request_name" json:"pull_request_name"`
+	AuthorID        string   `bson:"author_id" json:"author_id"`
+	Status          PRStatus `bson:"status" json:"status"`
+}
+
+// PRSearchHit is a PR found by text search, a higher Score is a better match
+type PRSearchHit struct {
+	PullRequestShort `bson:",inline"`
+	Score            float64 `bson:"score" json:"-"`
 }
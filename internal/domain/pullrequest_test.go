@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 )
 
 func TestPullRequest_IsMerged(t *testing.T) {
@@ -42,3 +43,212 @@ func TestPullRequest_IsMerged(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequest_SyncReviews(t *testing.T) {
+	now := time.Now()
+	pr := &PullRequest{
+		AssignedReviewers: []string{"user-2", "user-3"},
+		Reviews: []Review{
+			{ReviewerID: "user-2", State: ReviewStateApproved},
+			{ReviewerID: "user-4", State: ReviewStateApproved},
+		},
+	}
+
+	pr.SyncReviews(now)
+
+	if len(pr.Reviews) != 2 {
+		t.Fatalf("expected 2 reviews, got %d", len(pr.Reviews))
+	}
+	if got := pr.ReviewOf("user-2").State; got != ReviewStateApproved {
+		t.Fatalf("expected kept approval, got %v", got)
+	}
+	if got := pr.ReviewOf("user-3"); got.State != ReviewStatePending || !got.UpdatedAt.Equal(now) {
+		t.Fatalf("expected pending review for new reviewer, got %+v", got)
+	}
+	if pr.ReviewOf("user-4") != nil {
+		t.Fatalf("expected review of unassigned reviewer to be dropped")
+	}
+	if got := pr.Approvals(); got != 1 {
+		t.Fatalf("expected 1 approval, got %d", got)
+	}
+}
+
+func TestPullRequest_SubmitReview(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		status   PRStatus
+		submits  []ReviewState
+		expected ReviewState
+		err      error
+	}{
+		{"approve", PRStatusOpen, []ReviewState{ReviewStateApproved}, ReviewStateApproved, nil},
+		{"latest verdict wins", PRStatusOpen, []ReviewState{ReviewStateChangesRequested, ReviewStateApproved}, ReviewStateApproved, nil},
+		{"comment keeps approval", PRStatusOpen, []ReviewState{ReviewStateApproved, ReviewStateCommented}, ReviewStateApproved, nil},
+		{"comment on pending", PRStatusOpen, []ReviewState{ReviewStateCommented}, ReviewStateCommented, nil},
+		{"pending is not submittable", PRStatusOpen, []ReviewState{ReviewStatePending}, "", ErrInvalidReviewState},
+		{"unknown state", PRStatusOpen, []ReviewState{"LGTM"}, "", ErrInvalidReviewState},
+		{"merged PR", PRStatusMerged, []ReviewState{ReviewStateApproved}, "", ErrPRMerged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PullRequest{Status: tt.status, AssignedReviewers: []string{"user-2", "user-3"}}
+
+			var err error
+			for i, state := range tt.submits {
+				at := now
+				if i == len(tt.submits)-1 {
+					at = later
+				}
+				if err = pr.SubmitReview("user-2", state, at); err != nil {
+					break
+				}
+			}
+
+			if err != tt.err {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			review := pr.ReviewOf("user-2")
+			if review.State != tt.expected || !review.UpdatedAt.Equal(later) {
+				t.Fatalf("expected %v updated at %v, got %+v", tt.expected, later, review)
+			}
+		})
+	}
+
+	t.Run("reviewer not assigned", func(t *testing.T) {
+		pr := &PullRequest{Status: PRStatusOpen, AssignedReviewers: []string{"user-2"}}
+		if err := pr.SubmitReview("user-9", ReviewStateApproved, now); err != ErrNotAssigned {
+			t.Fatalf("expected %v, got %v", ErrNotAssigned, err)
+		}
+	})
+}
+
+func TestPullRequest_TransitionTo(t *testing.T) {
+	tests := []struct {
+		from PRStatus
+		to   PRStatus
+		err  error
+	}{
+		{PRStatusDraft, PRStatusOpen, nil},
+		{PRStatusDraft, PRStatusClosed, nil},
+		{PRStatusDraft, PRStatusMerged, ErrPRDraft},
+		{PRStatusOpen, PRStatusMerged, nil},
+		{PRStatusOpen, PRStatusClosed, nil},
+		{PRStatusOpen, PRStatusOpen, ErrInvalidTransition},
+		{PRStatusOpen, PRStatusDraft, ErrInvalidTransition},
+		{PRStatusClosed, PRStatusOpen, nil},
+		{PRStatusClosed, PRStatusClosed, ErrInvalidTransition},
+		{PRStatusClosed, PRStatusMerged, ErrPRClosed},
+		{PRStatusMerged, PRStatusOpen, ErrPRMerged},
+		{PRStatusMerged, PRStatusClosed, ErrPRMerged},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			pr := &PullRequest{Status: tt.from}
+
+			err := pr.TransitionTo(tt.to, time.Now())
+
+			if err != tt.err {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if err == nil && pr.Status != tt.to {
+				t.Fatalf("expected status %v, got %v", tt.to, pr.Status)
+			}
+			if err != nil && pr.Status != tt.from {
+				t.Fatalf("expected status to stay %v, got %v", tt.from, pr.Status)
+			}
+		})
+	}
+}
+
+func TestPullRequest_Close(t *testing.T) {
+	now := time.Now()
+	pr := &PullRequest{
+		Status:            PRStatusOpen,
+		AssignedReviewers: []string{"user-2"},
+		Reviews:           []Review{{ReviewerID: "user-2", State: ReviewStateApproved}},
+		UnderStaffed:      true,
+	}
+
+	if err := pr.TransitionTo(PRStatusClosed, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 0 || pr.Reviews != nil || pr.UnderStaffed {
+		t.Fatalf("expected reviewers to be released, got %+v", pr)
+	}
+	if pr.ClosedAt == nil || !pr.ClosedAt.Equal(now) {
+		t.Fatalf("expected closedAt to be set")
+	}
+
+	if err := pr.Reopen(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr.Status != PRStatusOpen || pr.ClosedAt != nil {
+		t.Fatalf("expected reopened PR, got %+v", pr)
+	}
+}
+
+func TestPullRequest_MarkReadyAndReopen(t *testing.T) {
+	tests := []struct {
+		name   string
+		status PRStatus
+		action func(*PullRequest, time.Time) error
+		err    error
+	}{
+		{"ready draft", PRStatusDraft, (*PullRequest).MarkReady, nil},
+		{"ready open", PRStatusOpen, (*PullRequest).MarkReady, ErrInvalidTransition},
+		{"ready closed", PRStatusClosed, (*PullRequest).MarkReady, ErrPRClosed},
+		{"reopen closed", PRStatusClosed, (*PullRequest).Reopen, nil},
+		{"reopen draft", PRStatusDraft, (*PullRequest).Reopen, ErrPRDraft},
+		{"reopen merged", PRStatusMerged, (*PullRequest).Reopen, ErrPRMerged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PullRequest{Status: tt.status}
+			if err := tt.action(pr, time.Now()); err != tt.err {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestPullRequest_EnsureOpen(t *testing.T) {
+	tests := map[PRStatus]error{
+		PRStatusOpen:   nil,
+		PRStatusDraft:  ErrPRDraft,
+		PRStatusClosed: ErrPRClosed,
+		PRStatusMerged: ErrPRMerged,
+	}
+
+	for status, expected := range tests {
+		pr := &PullRequest{Status: status}
+		if err := pr.EnsureOpen(); err != expected {
+			t.Fatalf("%v: expected %v, got %v", status, expected, err)
+		}
+	}
+}
+
+func TestPRStatus_Valid(t *testing.T) {
+	tests := map[PRStatus]bool{
+		PRStatusDraft:  true,
+		PRStatusOpen:   true,
+		PRStatusMerged: true,
+		PRStatusClosed: true,
+		"open":         false,
+		"":             false,
+	}
+
+	for status, expected := range tests {
+		if got := status.Valid(); got != expected {
+			t.Fatalf("%q: expected %v, got %v", status, expected, got)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+// Outcomes of taking a reviewer off an open PR
+const (
+	ReassignmentReplaced   = "replaced"
+	ReassignmentUnassigned = "unassigned"
+	ReassignmentFailed     = "failed"
+	ReassignmentSkipped    = "skipped"
+)
+
+// ReviewerReassignment reports what happened to one review slot of a reviewer taken off a PR
+type ReviewerReassignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	// NewReviewerID is set when the slot was replaced
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	// Outcome is replaced, unassigned (no candidate, the PR is left under-staffed), failed or skipped (time limit reached)
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+}
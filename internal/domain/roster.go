@@ -0,0 +1,26 @@
+package domain
+
+// Kinds of roster drift. Team membership is stored only in users' team_name,
+// teams created before that keep a members array that may disagree with users.
+const (
+	// RosterLegacyMembers: the team document still stores a members array
+	RosterLegacyMembers = "legacy_members"
+	// RosterMissingUser: a legacy member has no user document
+	RosterMissingUser = "missing_user"
+	// RosterTeamlessUser: a legacy member's user has no team
+	RosterTeamlessUser = "teamless_user"
+	// RosterTeamMismatch: a legacy member's user belongs to another team, the user's team wins
+	RosterTeamMismatch = "team_mismatch"
+	// RosterMissingTeam: users reference a team that has no team document
+	RosterMissingTeam = "missing_team"
+)
+
+// RosterDrift is one inconsistency found by the roster check
+type RosterDrift struct {
+	Kind     string `json:"kind"`
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Repaired bool   `json:"repaired"`
+	Error    string `json:"error,omitempty"`
+}
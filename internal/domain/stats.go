@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// UserStats is the review load of a user
+type UserStats struct {
+	UserID        string `bson:"user_id" json:"user_id"`
+	Username      string `bson:"username,omitempty" json:"username,omitempty"`
+	TeamName      string `bson:"team_name,omitempty" json:"team_name,omitempty"`
+	AssignedCount int    `bson:"assigned_count" json:"assigned_count"`
+	OpenPRCount   int    `bson:"open_pr_count" json:"open_pr_count"`
+	MergedPRCount int    `bson:"merged_pr_count" json:"merged_pr_count"`
+}
+
+// Fields UserStats lists can be sorted by
+const (
+	UserStatsSortAssigned = "assigned_count"
+	UserStatsSortOpen     = "open_pr_count"
+	UserStatsSortMerged   = "merged_pr_count"
+)
+
+// SortValue returns the counter named by one of the UserStatsSort constants
+func (s *UserStats) SortValue(field string) int {
+	switch field {
+	case UserStatsSortOpen:
+		return s.OpenPRCount
+	case UserStatsSortMerged:
+		return s.MergedPRCount
+	default:
+		return s.AssignedCount
+	}
+}
+
+// TeamStats sums up PRs authored by a team and review load of its members over a time window
+type TeamStats struct {
+	TeamName string     `json:"team_name"`
+	From     *time.Time `json:"from,omitempty"`
+	To       *time.Time `json:"to,omitempty"`
+
+	TotalPRs  int `json:"total_prs"`
+	DraftPRs  int `json:"draft_prs"`
+	OpenPRs   int `json:"open_prs"`
+	MergedPRs int `json:"merged_prs"`
+	ClosedPRs int `json:"closed_prs"`
+
+	// Members are review assignments of every team member, including members without assignments
+	Members  []*UserStats `json:"members"`
+	Fairness Fairness     `json:"fairness"`
+}
+
+// Fairness tells how evenly review assignments are spread among team members
+type Fairness struct {
+	// Gini coefficient of assignment counts: 0 is a perfectly even load, values close to 1 mean one member reviews everything
+	Gini        float64 `json:"gini"`
+	MaxAssigned int     `json:"max_assigned"`
+	MinAssigned int     `json:"min_assigned"`
+	// MaxMinRatio is omitted when some member has no assignments
+	MaxMinRatio *float64 `json:"max_min_ratio,omitempty"`
+}
+
+func NewFairness(counts []int) Fairness {
+	if len(counts) == 0 {
+		return Fairness{}
+	}
+
+	fairness := Fairness{
+		Gini:        Gini(counts),
+		MaxAssigned: slices.Max(counts),
+		MinAssigned: slices.Min(counts),
+	}
+	if fairness.MinAssigned > 0 {
+		ratio := float64(fairness.MaxAssigned) / float64(fairness.MinAssigned)
+		fairness.MaxMinRatio = &ratio
+	}
+
+	return fairness
+}
+
+// Gini returns the Gini coefficient of non-negative values, 0 for an empty or all-zero input
+func Gini(values []int) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += float64(v)
+		weighted += float64(i+1) * float64(v)
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	n := float64(len(sorted))
+	return 2*weighted/(n*sum) - (n+1)/n
+}
+
+// PRTiming holds the timestamps of a PR latency stats are built from
+type PRTiming struct {
+	PullRequestID string `bson:"pull_request_id" json:"pull_request_id"`
+	AuthorID      string `bson:"author_id" json:"author_id"`
+	// current team of the author, empty if the author is not a registered user
+	TeamName          string     `bson:"team_name,omitempty" json:"team_name,omitempty"`
+	CreatedAt         time.Time  `bson:"created_at" json:"created_at"`
+	MergedAt          *time.Time `bson:"merged_at,omitempty" json:"merged_at,omitempty"`
+	FirstReassignedAt *time.Time `bson:"first_reassigned_at,omitempty" json:"first_reassigned_at,omitempty"`
+	FirstReviewedAt   *time.Time `bson:"first_reviewed_at,omitempty" json:"first_reviewed_at,omitempty"`
+}
+
+// Latency summarises a set of durations
+type Latency struct {
+	Count         int     `json:"count"`
+	MedianSeconds float64 `json:"median_seconds"`
+	P90Seconds    float64 `json:"p90_seconds"`
+}
+
+func NewLatency(durations []time.Duration) Latency {
+	seconds := make([]float64, 0, len(durations))
+	for _, d := range durations {
+		seconds = append(seconds, d.Seconds())
+	}
+	slices.Sort(seconds)
+
+	return Latency{
+		Count:         len(seconds),
+		MedianSeconds: percentile(seconds, 0.5),
+		P90Seconds:    percentile(seconds, 0.9),
+	}
+}
+
+// percentile interpolates linearly between the closest ranks of sorted values, 0 for no values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// TeamLatency is the latency of PRs authored by a team
+type TeamLatency struct {
+	TeamName string `json:"team_name"`
+	Latency
+}
+
+// AuthorLatency is the latency of PRs of a single author
+type AuthorLatency struct {
+	AuthorID string `json:"author_id"`
+	TeamName string `json:"team_name,omitempty"`
+	Latency
+}
+
+// MergeTimeStats is the time from creation to merge of PRs merged within a time window
+type MergeTimeStats struct {
+	From    *time.Time       `json:"from,omitempty"`
+	To      *time.Time       `json:"to,omitempty"`
+	Overall Latency          `json:"overall"`
+	Teams   []*TeamLatency   `json:"teams"`
+	Authors []*AuthorLatency `json:"authors"`
+}
+
+// FirstReviewStats is the time from creation to the first submitted review of PRs created within a time window
+type FirstReviewStats struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+	// PRCount is the number of PRs created within the window, reviewed or not
+	PRCount int `json:"pr_count"`
+	// latencies are built from reviewed PRs only
+	Overall Latency          `json:"overall"`
+	Teams   []*TeamLatency   `json:"teams"`
+	Authors []*AuthorLatency `json:"authors"`
+}
+
+// PRReassignment is the time from creation of a PR to the first replacement of its reviewer
+type PRReassignment struct {
+	PullRequestID     string    `json:"pull_request_id"`
+	AuthorID          string    `json:"author_id"`
+	TeamName          string    `json:"team_name,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	FirstReassignedAt time.Time `json:"first_reassigned_at"`
+	Seconds           float64   `json:"seconds"`
+}
+
+// ReassignmentStats covers PRs created within a time window
+type ReassignmentStats struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+	// PRCount is the number of PRs created within the window, reassigned or not
+	PRCount int `json:"pr_count"`
+	// Latency is built from reassigned PRs only
+	Latency      Latency           `json:"time_to_first_reassignment"`
+	PullRequests []*PRReassignment `json:"pull_requests"`
+}
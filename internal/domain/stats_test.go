@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int
+		expected float64
+	}{
+		{"empty", nil, 0},
+		{"all zero", []int{0, 0, 0}, 0},
+		{"even load", []int{3, 3, 3, 3}, 0},
+		{"one member reviews everything", []int{0, 0, 4}, 2.0 / 3},
+		{"order does not matter", []int{4, 1, 2, 1}, 0.3125},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Gini(tt.values); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("Gini(%v) = %v, want %v", tt.values, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewFairness(t *testing.T) {
+	t.Run("no members", func(t *testing.T) {
+		if got := NewFairness(nil); got != (Fairness{}) {
+			t.Fatalf("NewFairness(nil) = %+v, want zero value", got)
+		}
+	})
+
+	t.Run("ratio of max to min", func(t *testing.T) {
+		got := NewFairness([]int{2, 6, 4})
+
+		if got.MaxAssigned != 6 || got.MinAssigned != 2 {
+			t.Fatalf("max/min = %d/%d, want 6/2", got.MaxAssigned, got.MinAssigned)
+		}
+		if got.MaxMinRatio == nil || *got.MaxMinRatio != 3 {
+			t.Fatalf("MaxMinRatio = %v, want 3", got.MaxMinRatio)
+		}
+	})
+
+	t.Run("no ratio when someone has no assignments", func(t *testing.T) {
+		got := NewFairness([]int{0, 5})
+
+		if got.MaxMinRatio != nil {
+			t.Fatalf("MaxMinRatio = %v, want nil", *got.MaxMinRatio)
+		}
+		if got.Gini != 0.5 {
+			t.Fatalf("Gini = %v, want 0.5", got.Gini)
+		}
+	})
+}
+
+func TestUserStats_SortValue(t *testing.T) {
+	s := UserStats{AssignedCount: 3, OpenPRCount: 2, MergedPRCount: 1}
+
+	tests := map[string]int{
+		UserStatsSortAssigned: 3,
+		UserStatsSortOpen:     2,
+		UserStatsSortMerged:   1,
+		"":                    3,
+	}
+
+	for field, expected := range tests {
+		if got := s.SortValue(field); got != expected {
+			t.Errorf("SortValue(%q) = %d, want %d", field, got, expected)
+		}
+	}
+}
+
+func TestNewLatency(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		expected  Latency
+	}{
+		{"no durations", nil, Latency{}},
+		{"single value", []time.Duration{time.Minute}, Latency{Count: 1, MedianSeconds: 60, P90Seconds: 60}},
+		{"even count averages the middle", []time.Duration{4 * time.Second, time.Second, 3 * time.Second, 2 * time.Second}, Latency{Count: 4, MedianSeconds: 2.5, P90Seconds: 3.7}},
+		{
+			"p90 interpolates between ranks",
+			[]time.Duration{10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110},
+			Latency{Count: 11, MedianSeconds: 60e-9, P90Seconds: 100e-9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewLatency(tt.durations)
+
+			if got.Count != tt.expected.Count ||
+				math.Abs(got.MedianSeconds-tt.expected.MedianSeconds) > 1e-12 ||
+				math.Abs(got.P90Seconds-tt.expected.P90Seconds) > 1e-12 {
+				t.Errorf("NewLatency(%v) = %+v, want %+v", tt.durations, got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,19 +1,107 @@
 package domain
 
+import "strings"
+
 type User struct {
-	UserID   string `bson:"user_id" json:"user_id"`
-	Username string `bson:"username" json:"username"`
-	TeamName string `bson:"team_name" json:"team_name"`
-	IsActive bool   `bson:"is_active" json:"is_active"`
+	UserID         string `bson:"user_id" json:"user_id"`
+	Username       string `bson:"username" json:"username"`
+	TeamName       string `bson:"team_name" json:"team_name"`
+	IsActive       bool   `bson:"is_active" json:"is_active"`
+	MaxOpenReviews int    `bson:"max_open_reviews" json:"max_open_reviews,omitempty"` // 0 means no limit
+	Email          string `bson:"email,omitempty" json:"email,omitempty"`             // stored normalized, see NormalizeEmail
+
+	// out-of-office periods, changed only by the absence endpoints
+	Absences []Absence `bson:"absences,omitempty" json:"absences,omitempty"`
+}
+
+// Fields user lists can be sorted by
+const (
+	UserSortID       = "user_id"
+	UserSortUsername = "username"
+)
+
+// NormalizeEmail makes emails comparable: trimmed and lower-cased
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// HasCapacity reports whether the user can take one more review having openReviews already
+func (u *User) HasCapacity(openReviews int) bool {
+	return u.MaxOpenReviews <= 0 || openReviews < u.MaxOpenReviews
 }
 
 type TeamMember struct {
-	UserID   string `bson:"user_id" json:"user_id"`
-	Username string `bson:"username" json:"username"`
-	IsActive bool   `bson:"is_active" json:"is_active"`
+	UserID         string `bson:"user_id" json:"user_id"`
+	Username       string `bson:"username" json:"username"`
+	IsActive       bool   `bson:"is_active" json:"is_active"`
+	MaxOpenReviews int    `bson:"max_open_reviews" json:"max_open_reviews,omitempty"`
+	Email          string `bson:"email,omitempty" json:"email,omitempty"`
 }
 
+// DefaultReviewersCount is used when neither the team nor the PR sets the number of reviewers
+const DefaultReviewersCount = 2
+
 type Team struct {
-	TeamName string       `bson:"team_name" json:"team_name"`
-	Members  []TeamMember `bson:"members" json:"members"`
+	TeamName string `bson:"team_name" json:"team_name"`
+	// filled from users having the team_name, never stored in the team document
+	Members []TeamMember `bson:"-" json:"members"`
+
+	// reviewers policy, 0 means not set
+	ReviewersCount int `bson:"reviewers_count,omitempty" json:"reviewers_count,omitempty"`
+	MinReviewers   int `bson:"min_reviewers,omitempty" json:"min_reviewers,omitempty"`
+	MaxReviewers   int `bson:"max_reviewers,omitempty" json:"max_reviewers,omitempty"`
+
+	// partner teams asked for reviewers in this order when the team has no free candidates
+	FallbackTeams []string `bson:"fallback_teams,omitempty" json:"fallback_teams,omitempty"`
+
+	// approvals needed to merge a team PR, 0 disables the check
+	RequiredApprovals int `bson:"required_approvals,omitempty" json:"required_approvals,omitempty"`
+}
+
+// ValidateReviewersPolicy checks that the team reviewers settings are consistent
+func (t *Team) ValidateReviewersPolicy() error {
+	if t.ReviewersCount < 0 || t.MinReviewers < 0 || t.MaxReviewers < 0 {
+		return ErrInvalidReviewersCount
+	}
+	if t.MaxReviewers > 0 && t.MinReviewers > t.MaxReviewers {
+		return ErrInvalidReviewersCount
+	}
+	if t.ReviewersCount > 0 && !t.reviewersInBounds(t.ReviewersCount) {
+		return ErrInvalidReviewersCount
+	}
+	return nil
+}
+
+// DefaultReviewers returns the number of reviewers assigned to the team PRs by default
+func (t *Team) DefaultReviewers() int {
+	if t.ReviewersCount > 0 {
+		return t.ReviewersCount
+	}
+
+	count := DefaultReviewersCount
+	if t.MinReviewers > count {
+		count = t.MinReviewers
+	}
+	if t.MaxReviewers > 0 && t.MaxReviewers < count {
+		count = t.MaxReviewers
+	}
+	return count
+}
+
+// ReviewersFor returns the number of reviewers for a PR, requested == 0 means team default
+func (t *Team) ReviewersFor(requested int) (int, error) {
+	if requested == 0 {
+		return t.DefaultReviewers(), nil
+	}
+	if requested < 0 || !t.reviewersInBounds(requested) {
+		return 0, ErrInvalidReviewersCount
+	}
+	return requested, nil
+}
+
+func (t *Team) reviewersInBounds(count int) bool {
+	if count < max(t.MinReviewers, 1) {
+		return false
+	}
+	return t.MaxReviewers == 0 || count <= t.MaxReviewers
 }
@@ -0,0 +1,111 @@
+package domain
+
+import "testing"
+
+func TestUser_HasCapacity(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxReviews  int
+		openReviews int
+		expected    bool
+	}{
+		{"no limit", 0, 100, true},
+		{"below limit", 3, 2, true},
+		{"at limit", 3, 3, false},
+		{"above limit", 3, 5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := User{MaxOpenReviews: tt.maxReviews}
+			if got := u.HasCapacity(tt.openReviews); got != tt.expected {
+				t.Errorf("HasCapacity(%d) = %v, want %v", tt.openReviews, got, tt.expected)
+			}
+		})
+	}
+}
+func TestTeam_ValidateReviewersPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		team    Team
+		wantErr bool
+	}{
+		{"empty policy", Team{}, false},
+		{"count only", Team{ReviewersCount: 3}, false},
+		{"count within bounds", Team{ReviewersCount: 2, MinReviewers: 1, MaxReviewers: 3}, false},
+		{"negative count", Team{ReviewersCount: -1}, true},
+		{"negative min", Team{MinReviewers: -1}, true},
+		{"negative max", Team{MaxReviewers: -1}, true},
+		{"min greater than max", Team{MinReviewers: 3, MaxReviewers: 2}, true},
+		{"count below min", Team{ReviewersCount: 1, MinReviewers: 2}, true},
+		{"count above max", Team{ReviewersCount: 4, MaxReviewers: 3}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.team.ValidateReviewersPolicy()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateReviewersPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err != ErrInvalidReviewersCount {
+				t.Fatalf("expected ErrInvalidReviewersCount, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTeam_DefaultReviewers(t *testing.T) {
+	tests := []struct {
+		name     string
+		team     Team
+		expected int
+	}{
+		{"no policy", Team{}, DefaultReviewersCount},
+		{"explicit count", Team{ReviewersCount: 1}, 1},
+		{"min above default", Team{MinReviewers: 3}, 3},
+		{"max below default", Team{MaxReviewers: 1}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.team.DefaultReviewers(); got != tt.expected {
+				t.Errorf("DefaultReviewers() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTeam_ReviewersFor(t *testing.T) {
+	team := Team{ReviewersCount: 2, MinReviewers: 1, MaxReviewers: 3}
+
+	tests := []struct {
+		name      string
+		requested int
+		expected  int
+		wantErr   bool
+	}{
+		{"team default", 0, 2, false},
+		{"within bounds", 3, 3, false},
+		{"above max", 4, 0, true},
+		{"negative", -1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := team.ReviewersFor(tt.requested)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReviewersFor(%d) error = %v, wantErr %v", tt.requested, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ReviewersFor(%d) = %d, want %d", tt.requested, got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("no max means unbounded above", func(t *testing.T) {
+		got, err := (&Team{}).ReviewersFor(5)
+		if err != nil || got != 5 {
+			t.Fatalf("ReviewersFor(5) = %d, %v", got, err)
+		}
+	})
+}
@@ -1,14 +1,24 @@
 package dto
 
+import "assignment-service/internal/domain"
+
 type CreateTeamRequest struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName       string       `json:"team_name"`
+	Members        []TeamMember `json:"members"`
+	ReviewersCount int          `json:"reviewers_count"`
+	MinReviewers   int          `json:"min_reviewers"`
+	MaxReviewers   int          `json:"max_reviewers"`
+	FallbackTeams  []string     `json:"fallback_teams"`
+
+	RequiredApprovals int `json:"required_approvals"`
 }
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	IsActive       bool   `json:"is_active"`
+	MaxOpenReviews int    `json:"max_open_reviews"`
+	Email          string `json:"email"`
 }
 
 type SetIsActiveRequest struct {
@@ -16,17 +26,82 @@ type SetIsActiveRequest struct {
 	IsActive bool   `json:"is_active"`
 }
 
+// UpdateUserRequest changes only the fields that are present in the body
+type UpdateUserRequest struct {
+	UserID         string  `json:"user_id"`
+	Username       *string `json:"username"`
+	Email          *string `json:"email"`
+	MaxOpenReviews *int    `json:"max_open_reviews"`
+}
+
+// AddAbsenceRequest takes from/to as RFC 3339 timestamps or YYYY-MM-DD dates, to is exclusive
+type AddAbsenceRequest struct {
+	UserID  string `json:"user_id"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Reason  string `json:"reason"`
+	Handoff bool   `json:"handoff"`
+}
+
+type RemoveAbsenceRequest struct {
+	UserID    string `json:"user_id"`
+	AbsenceID string `json:"absence_id"`
+}
+
+// MoveTeamRequest moves the user to team_name
+type MoveTeamRequest struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+}
+
+type DeactivateUsersRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+type AddTeamMemberRequest struct {
+	TeamName string     `json:"team_name"`
+	Member   TeamMember `json:"member"`
+}
+
+type RemoveTeamMemberRequest struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+}
+
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name"`
+	NewTeamName string `json:"new_team_name"`
+}
+
+type DeleteTeamRequest struct {
+	TeamName string `json:"team_name"`
+}
+
 type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	ReviewersCount  *int   `json:"reviewers_count,omitempty"` // team default when omitted
+	Draft           bool   `json:"draft"`
 }
 
 type MergePRRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 }
 
+// PRStatusRequest is the body of /pullRequest/ready, /pullRequest/close and /pullRequest/reopen
+type PRStatusRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
 type ReassignReviewerRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldReviewerID string `json:"old_reviewer_id"`
 }
+
+type SubmitReviewRequest struct {
+	PullRequestID string             `json:"pull_request_id"`
+	ReviewerID    string             `json:"reviewer_id"`
+	State         domain.ReviewState `json:"state"`
+}
@@ -19,6 +19,49 @@ type UserResponse struct {
 	User domain.User `json:"user"`
 }
 
+type SetIsActiveResponse struct {
+	User domain.User `json:"user"`
+	// Reassignments lists review slots released by a deactivated user
+	Reassignments []*domain.ReviewerReassignment `json:"reassignments,omitempty"`
+}
+
+type AbsenceResponse struct {
+	UserID  string         `json:"user_id"`
+	Absence domain.Absence `json:"absence"`
+}
+
+type AbsencesResponse struct {
+	UserID   string           `json:"user_id"`
+	Absences []domain.Absence `json:"absences"`
+}
+
+type ImportAbsencesResponse struct {
+	Results []*domain.AbsenceImportResult `json:"results"`
+}
+
+type MoveTeamResponse struct {
+	User     domain.User `json:"user"`
+	FromTeam string      `json:"from_team"`
+	// Reassignments lists review slots the user left behind in the old team
+	Reassignments []*domain.ReviewerReassignment `json:"reassignments"`
+}
+
+type DeactivateUsersResponse struct {
+	TeamName           string                         `json:"team_name"`
+	DeactivatedUserIDs []string                       `json:"deactivated_user_ids"`
+	Reassignments      []*domain.ReviewerReassignment `json:"reassignments"`
+}
+
+type RemoveTeamMemberResponse struct {
+	TeamName      string                         `json:"team_name"`
+	UserID        string                         `json:"user_id"`
+	Reassignments []*domain.ReviewerReassignment `json:"reassignments"`
+}
+
+type DeleteTeamResponse struct {
+	TeamName string `json:"team_name"`
+}
+
 type PRResponse struct {
 	PR domain.PullRequest `json:"pr"`
 }
@@ -28,6 +71,11 @@ type ReassignResponse struct {
 	ReplacedBy string             `json:"replaced_by"`
 }
 
+type PRHistoryResponse struct {
+	PullRequestID string            `json:"pull_request_id"`
+	Events        []*domain.PREvent `json:"events"`
+}
+
 type GetReviewResponse struct {
 	UserID       string                    `json:"user_id"`
 	PullRequests []domain.PullRequestShort `json:"pull_requests"`
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 
 	"assignment-service/internal/domain"
 	"assignment-service/internal/http/dto"
@@ -40,9 +43,21 @@ func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.prService.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	reviewersCount := 0
+	if req.ReviewersCount != nil {
+		if *req.ReviewersCount < 1 {
+			h.sendError(w, domain.ErrorCodeInvalidReviewersCount, "reviewers_count must be >= 1", http.StatusBadRequest)
+			return
+		}
+		reviewersCount = *req.ReviewersCount
+	}
+
+	pr, err := h.prService.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, reviewersCount, req.Draft)
 	if err != nil {
 		switch err {
+		case domain.ErrInvalidReviewersCount:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusBadRequest)
+			return
 		case domain.ErrPRExists:
 			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusConflict)
 			return
@@ -80,14 +95,18 @@ func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 
 	pr, err := h.prService.MergePR(r.Context(), req.PullRequestID)
 	if err != nil {
-		if err == domain.ErrPRNotFound {
+		switch err {
+		case domain.ErrPRNotFound, domain.ErrUserNotFound:
 			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
 			return
+		case domain.ErrNotEnoughApprovals, domain.ErrPRClosed, domain.ErrPRDraft:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusConflict)
+			return
+		default:
+			h.logger.Error("failed to merge PR", zap.Error(err))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+			return
 		}
-
-		h.logger.Error("failed to merge PR", zap.Error(err))
-		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
-		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -117,7 +136,7 @@ func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 		case domain.ErrPRNotFound, domain.ErrUserNotFound:
 			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
 			return
-		case domain.ErrPRMerged, domain.ErrNotAssigned, domain.ErrNoCandidate:
+		case domain.ErrPRMerged, domain.ErrPRClosed, domain.ErrPRDraft, domain.ErrNotAssigned, domain.ErrNoCandidate:
 			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusConflict)
 			return
 		default:
@@ -134,6 +153,214 @@ func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *PRHandler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prService.MarkReady, "failed to mark PR as ready")
+}
+
+func (h *PRHandler) ClosePR(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prService.ClosePR, "failed to close PR")
+}
+
+func (h *PRHandler) ReopenPR(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prService.ReopenPR, "failed to reopen PR")
+}
+
+// changeStatus serves the status transition endpoints which share request and error handling
+func (h *PRHandler) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	transition func(ctx context.Context, prID string) (*domain.PullRequest, error),
+	failureMessage string,
+) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.PRStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.PullRequestID == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := transition(r.Context(), req.PullRequestID)
+	if err != nil {
+		switch err {
+		case domain.ErrPRNotFound, domain.ErrUserNotFound:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+			return
+		case domain.ErrPRMerged, domain.ErrPRClosed, domain.ErrPRDraft, domain.ErrInvalidTransition:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusConflict)
+			return
+		default:
+			h.logger.Error(failureMessage, zap.Error(err))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.PRResponse{PR: *pr})
+}
+
+func (h *PRHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.SubmitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.PullRequestID == "" || req.ReviewerID == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "pull_request_id and reviewer_id are required", http.StatusBadRequest)
+		return
+	}
+
+	if !req.State.IsSubmittable() {
+		h.sendError(w, domain.ErrorCodeInvalidReviewState, domain.ErrInvalidReviewState.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.prService.SubmitReview(r.Context(), req.PullRequestID, req.ReviewerID, req.State)
+	if err != nil {
+		switch err {
+		case domain.ErrPRNotFound:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+			return
+		case domain.ErrPRMerged, domain.ErrPRClosed, domain.ErrPRDraft, domain.ErrNotAssigned:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusConflict)
+			return
+		default:
+			h.logger.Error("failed to submit review", zap.Error(err))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.PRResponse{PR: *pr})
+}
+
+func (h *PRHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.prService.GetHistory(r.Context(), prID)
+	if err != nil {
+		if err == domain.ErrPRNotFound {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get PR history", zap.Error(err))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.PRHistoryResponse{
+		PullRequestID: prID,
+		Events:        events,
+	})
+}
+
+func (h *PRHandler) ListPRs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	limit, err := parseLimit(query)
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidQuery, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, to, err := parseWindow(query)
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidQuery, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.prService.ListPRs(r.Context(), service.PRListParams{
+		TeamName:   query.Get("team_name"),
+		AuthorID:   query.Get("author_id"),
+		ReviewerID: query.Get("reviewer_id"),
+		Status:     domain.PRStatus(query.Get("status")),
+		From:       from,
+		To:         to,
+		SortBy:     query.Get("sort_by"),
+		Order:      query.Get("order"),
+		Cursor:     query.Get("cursor"),
+		Limit:      limit,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to list PRs", zap.Error(err))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(page)
+}
+
+func (h *PRHandler) SearchPRs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	if strings.TrimSpace(query.Get("q")) == "" {
+		h.sendError(w, domain.ErrorCodeInvalidQuery, "q is required", http.StatusBadRequest)
+		return
+	}
+	limit, err := parseLimit(query)
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidQuery, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.prService.SearchPRs(r.Context(), service.PRSearchParams{
+		Query:  query.Get("q"),
+		Status: domain.PRStatus(query.Get("status")),
+		Cursor: query.Get("cursor"),
+		Limit:  limit,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to search PRs", zap.Error(err))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(page)
+}
+
 func (h *PRHandler) sendError(w http.ResponseWriter, code domain.ErrorCode, message string, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
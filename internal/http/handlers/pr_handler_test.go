@@ -10,11 +10,13 @@ import (
 
 	"assignment-service/internal/domain"
 	"assignment-service/internal/http/dto"
+	"assignment-service/internal/repository"
 	"assignment-service/internal/repository/mocks"
 	"assignment-service/internal/service"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -24,7 +26,8 @@ func TestPRHandlerCreatePR(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		author := &domain.User{
@@ -41,6 +44,7 @@ func TestPRHandlerCreatePR(t *testing.T) {
 
 		mockPRRepo.On("Exists", mock.Anything, "pr-1").Return(false, nil)
 		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(nil, domain.ErrTeamNotFound)
 		mockUserRepo.On("GetActiveByTeam", mock.Anything, "team-1").Return(teamMembers, nil)
 		mockPRRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
@@ -66,10 +70,49 @@ func TestPRHandlerCreatePR(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 
+	t.Run("reviewers_count out of team policy", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewPRHandler(prService, logger)
+
+		author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+
+		mockPRRepo.On("Exists", mock.Anything, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(&domain.Team{TeamName: "team-1", MaxReviewers: 2}, nil)
+
+		body := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Test PR","author_id":"user-1","reviewers_count":3}`)
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.CreatePR(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response dto.ErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, string(domain.ErrorCodeInvalidReviewersCount), response.Error.Code)
+	})
+
+	t.Run("non-positive reviewers_count", func(t *testing.T) {
+		handler := NewPRHandler(nil, logger)
+
+		body := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Test PR","author_id":"user-1","reviewers_count":0}`)
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.CreatePR(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("PR already exists", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		mockPRRepo.On("Exists", mock.Anything, "pr-1").Return(true, nil)
@@ -92,7 +135,8 @@ func TestPRHandlerCreatePR(t *testing.T) {
 	t.Run("user not found", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		mockPRRepo.On("Exists", mock.Anything, "pr-1").Return(false, nil)
@@ -158,7 +202,8 @@ func TestPRHandlerMergePR(t *testing.T) {
 	t.Run("successful merge", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		now := time.Now()
@@ -172,6 +217,8 @@ func TestPRHandlerMergePR(t *testing.T) {
 		}
 
 		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-1"}, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(nil, domain.ErrTeamNotFound)
 		mockPRRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
 		reqBody := dto.MergePRRequest{
@@ -190,7 +237,8 @@ func TestPRHandlerMergePR(t *testing.T) {
 	t.Run("already merged PR", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		now := time.Now()
@@ -223,7 +271,8 @@ func TestPRHandlerMergePR(t *testing.T) {
 	t.Run("PR not found", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, domain.ErrPRNotFound)
@@ -263,7 +312,8 @@ func TestPRHandlerReassignReviewer(t *testing.T) {
 	t.Run("successful reassignment", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		now := time.Now()
@@ -292,6 +342,7 @@ func TestPRHandlerReassignReviewer(t *testing.T) {
 
 		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil)
 		mockUserRepo.On("GetByID", mock.Anything, "user-2").Return(oldReviewer, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(nil, domain.ErrTeamNotFound)
 		mockUserRepo.On("GetActiveByTeam", mock.Anything, "team-1").Return([]*domain.User{newReviewer}, nil)
 		mockPRRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
@@ -318,7 +369,8 @@ func TestPRHandlerReassignReviewer(t *testing.T) {
 	t.Run("PR not found", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, domain.ErrPRNotFound)
@@ -340,7 +392,8 @@ func TestPRHandlerReassignReviewer(t *testing.T) {
 	t.Run("PR already merged", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		now := time.Now()
@@ -374,7 +427,8 @@ func TestPRHandlerReassignReviewer(t *testing.T) {
 	t.Run("reviewer not assigned", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		now := time.Now()
@@ -406,7 +460,8 @@ func TestPRHandlerReassignReviewer(t *testing.T) {
 	t.Run("no candidate", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewPRHandler(prService, logger)
 
 		now := time.Now()
@@ -428,6 +483,7 @@ func TestPRHandlerReassignReviewer(t *testing.T) {
 
 		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil)
 		mockUserRepo.On("GetByID", mock.Anything, "user-2").Return(oldReviewer, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(nil, domain.ErrTeamNotFound)
 		mockUserRepo.On("GetActiveByTeam", mock.Anything, "team-1").Return([]*domain.User{}, nil) // no candidates
 
 		reqBody := dto.ReassignReviewerRequest{
@@ -461,3 +517,494 @@ func TestPRHandlerReassignReviewer(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
+
+func TestPRHandlerMergePRApprovals(t *testing.T) {
+	logger := zap.NewNop()
+
+	mockPRRepo := new(mocks.MockPRRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockTeamRepo := new(mocks.MockTeamRepository)
+	prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+	handler := NewPRHandler(prService, logger)
+
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "user-1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"user-2"},
+	}
+
+	mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(pr, nil)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-1"}, nil)
+	mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(&domain.Team{TeamName: "team-1", RequiredApprovals: 1}, nil)
+
+	body, _ := json.Marshal(dto.MergePRRequest{PullRequestID: "pr-1"})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.MergePR(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response dto.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, string(domain.ErrorCodeNotEnoughApprovals), response.Error.Code)
+	mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestPRHandlerSubmitReview(t *testing.T) {
+	logger := zap.NewNop()
+
+	newPR := func(status domain.PRStatus) *domain.PullRequest {
+		return &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "user-1",
+			Status:            status,
+			AssignedReviewers: []string{"user-2"},
+		}
+	}
+
+	t.Run("successful review", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewPRHandler(prService, logger)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(newPR(domain.PRStatusOpen), nil)
+		mockPRRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		body, _ := json.Marshal(dto.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "user-2", State: domain.ReviewStateApproved})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.SubmitReview(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.PRResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Review{{
+			ReviewerID: "user-2",
+			State:      domain.ReviewStateApproved,
+			UpdatedAt:  response.PR.Reviews[0].UpdatedAt,
+		}}, response.PR.Reviews)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("service errors", func(t *testing.T) {
+		cases := []struct {
+			name       string
+			pr         *domain.PullRequest
+			err        error
+			reviewerID string
+			statusCode int
+		}{
+			{"PR not found", nil, domain.ErrPRNotFound, "user-2", http.StatusNotFound},
+			{"PR merged", newPR(domain.PRStatusMerged), nil, "user-2", http.StatusConflict},
+			{"not assigned", newPR(domain.PRStatusOpen), nil, "user-9", http.StatusConflict},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockPRRepo := new(mocks.MockPRRepository)
+				prService := service.NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+				handler := NewPRHandler(prService, logger)
+
+				mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(tc.pr, tc.err)
+
+				body, _ := json.Marshal(dto.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: tc.reviewerID, State: domain.ReviewStateApproved})
+				req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewReader(body))
+				w := httptest.NewRecorder()
+
+				handler.SubmitReview(w, req)
+
+				assert.Equal(t, tc.statusCode, w.Code)
+			})
+		}
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		handler := NewPRHandler(nil, logger)
+
+		cases := map[string]string{
+			"invalid body":     "invalid json",
+			"missing reviewer": `{"pull_request_id": "pr-1", "state": "APPROVED"}`,
+			"missing pr":       `{"reviewer_id": "user-2", "state": "APPROVED"}`,
+			"pending state":    `{"pull_request_id": "pr-1", "reviewer_id": "user-2", "state": "PENDING"}`,
+			"unknown state":    `{"pull_request_id": "pr-1", "reviewer_id": "user-2", "state": "LGTM"}`,
+			"missing state":    `{"pull_request_id": "pr-1", "reviewer_id": "user-2"}`,
+		}
+
+		for name, raw := range cases {
+			t.Run(name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewReader([]byte(raw)))
+				w := httptest.NewRecorder()
+
+				handler.SubmitReview(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewPRHandler(nil, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/review", nil)
+		w := httptest.NewRecorder()
+
+		handler.SubmitReview(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestPRHandlerChangeStatus(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("close PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewPRHandler(prService, logger)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{
+			PullRequestID:     "pr-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+		}, nil)
+		mockPRRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		body, _ := json.Marshal(dto.PRStatusRequest{PullRequestID: "pr-1"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/close", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ClosePR(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.PRResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusClosed, response.PR.Status)
+		assert.Empty(t, response.PR.AssignedReviewers)
+	})
+
+	t.Run("illegal transitions", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			status domain.PRStatus
+			call   func(h *PRHandler) http.HandlerFunc
+			code   domain.ErrorCode
+		}{
+			{"ready open PR", domain.PRStatusOpen, func(h *PRHandler) http.HandlerFunc { return h.MarkReady }, domain.ErrorCodeInvalidTransition},
+			{"reopen draft", domain.PRStatusDraft, func(h *PRHandler) http.HandlerFunc { return h.ReopenPR }, domain.ErrorCodePRDraft},
+			{"close merged", domain.PRStatusMerged, func(h *PRHandler) http.HandlerFunc { return h.ClosePR }, domain.ErrorCodePRMerged},
+			{"merge closed", domain.PRStatusClosed, func(h *PRHandler) http.HandlerFunc { return h.MergePR }, domain.ErrorCodePRClosed},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				mockPRRepo := new(mocks.MockPRRepository)
+				prService := service.NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+				handler := NewPRHandler(prService, logger)
+
+				mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1", Status: tc.status}, nil)
+
+				body, _ := json.Marshal(dto.PRStatusRequest{PullRequestID: "pr-1"})
+				req := httptest.NewRequest(http.MethodPost, "/pullRequest/status", bytes.NewReader(body))
+				w := httptest.NewRecorder()
+
+				tc.call(handler)(w, req)
+
+				assert.Equal(t, http.StatusConflict, w.Code)
+
+				var response dto.ErrorResponse
+				_ = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.Equal(t, string(tc.code), response.Error.Code)
+				mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("PR not found", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewPRHandler(prService, logger)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, domain.ErrPRNotFound)
+
+		body, _ := json.Marshal(dto.PRStatusRequest{PullRequestID: "pr-1"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/reopen", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ReopenPR(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewPRHandler(prService, logger)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusDraft}, nil)
+		mockPRRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(assert.AnError)
+
+		body, _ := json.Marshal(dto.PRStatusRequest{PullRequestID: "pr-1"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/close", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ClosePR(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		handler := NewPRHandler(nil, logger)
+
+		for name, raw := range map[string]string{
+			"invalid body":            "invalid json",
+			"missing pull_request_id": `{}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/pullRequest/ready", bytes.NewReader([]byte(raw)))
+				w := httptest.NewRecorder()
+
+				handler.MarkReady(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewPRHandler(nil, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/close", nil)
+		w := httptest.NewRecorder()
+
+		handler.ClosePR(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+// newEventRepo returns an event repository accepting any events for tests that do not check the history
+func newEventRepo() *mocks.MockPREventRepository {
+	eventRepo := new(mocks.MockPREventRepository)
+	eventRepo.On("Append", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return eventRepo
+}
+
+func TestPRHandlerGetHistory(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("successful get history", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockEventRepo := new(mocks.MockPREventRepository)
+		prService := service.NewPRService(mockPRRepo, nil, nil, mockEventRepo, mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewPRHandler(prService, logger)
+
+		now := time.Now().UTC()
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1"}, nil)
+		mockEventRepo.On("ListByPR", mock.Anything, "pr-1").Return([]*domain.PREvent{
+			{PullRequestID: "pr-1", Type: domain.PREventCreated, At: now},
+			{PullRequestID: "pr-1", Type: domain.PREventReviewerAssigned, ReviewerID: "user-2", TeamName: "team-1", At: now},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/history?pull_request_id=pr-1", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetHistory(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.PRHistoryResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "pr-1", response.PullRequestID)
+		assert.Len(t, response.Events, 2)
+		assert.Equal(t, "user-2", response.Events[1].ReviewerID)
+	})
+
+	t.Run("PR not found", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewPRHandler(prService, logger)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(nil, domain.ErrPRNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/history?pull_request_id=pr-1", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetHistory(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockEventRepo := new(mocks.MockPREventRepository)
+		prService := service.NewPRService(mockPRRepo, nil, nil, mockEventRepo, mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewPRHandler(prService, logger)
+
+		mockPRRepo.On("GetByID", mock.Anything, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1"}, nil)
+		mockEventRepo.On("ListByPR", mock.Anything, "pr-1").Return(nil, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/history?pull_request_id=pr-1", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetHistory(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("missing pull_request_id", func(t *testing.T) {
+		handler := NewPRHandler(nil, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/history", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetHistory(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewPRHandler(nil, logger)
+
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/history?pull_request_id=pr-1", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetHistory(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestPRHandlerListPRs(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("passes filters to the repository", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, new(mocks.MockUserRepository), new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewPRHandler(prService, logger)
+
+		from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		mockPRRepo.On("List", mock.Anything, repository.PRListQuery{
+			AuthorIDs:  []string{"user-1"},
+			Status:     domain.PRStatusMerged,
+			ReviewerID: "user-2",
+			Created:    repository.TimeWindow{From: &from},
+			SortBy:     domain.PRSortID,
+			Limit:      11,
+		}).Return([]*domain.PullRequest{{PullRequestID: "pr-1", Status: domain.PRStatusMerged}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/pullRequests?author_id=user-1&reviewer_id=user-2&status=MERGED&from=2024-06-01&sort_by=pull_request_id&order=asc&limit=10", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListPRs(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var page service.PullRequestsPage
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		assert.Len(t, page.PullRequests, 1)
+		assert.Empty(t, page.NextCursor)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		handler := NewPRHandler(service.NewPRService(new(mocks.MockPRRepository), new(mocks.MockUserRepository), new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger), logger)
+
+		for _, query := range []string{"status=REVIEWED", "limit=ten", "from=yesterday", "sort_by=title"} {
+			req := httptest.NewRequest(http.MethodGet, "/pullRequests?"+query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListPRs(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			assert.Contains(t, w.Body.String(), "INVALID_QUERY", query)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		handler := NewPRHandler(service.NewPRService(mockPRRepo, new(mocks.MockUserRepository), new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger), logger)
+
+		mockPRRepo.On("List", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequests", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListPRs(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestPRHandlerSearchPRs(t *testing.T) {
+	logger := zap.NewNop()
+	newHandler := func(prRepo *mocks.MockPRRepository) *PRHandler {
+		return NewPRHandler(service.NewPRService(prRepo, new(mocks.MockUserRepository), new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger), logger)
+	}
+
+	t.Run("returns ranked PRs", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		handler := newHandler(mockPRRepo)
+
+		mockPRRepo.On("Search", mock.Anything, repository.PRSearchQuery{
+			Text:   "payment retry",
+			Status: domain.PRStatusOpen,
+			Limit:  11,
+		}).Return([]*domain.PRSearchHit{
+			{PullRequestShort: domain.PullRequestShort{PullRequestID: "pr-2", PullRequestName: "Payment retry", AuthorID: "user-1", Status: domain.PRStatusOpen}, Score: 2},
+			{PullRequestShort: domain.PullRequestShort{PullRequestID: "pr-1", PullRequestName: "Payment page", AuthorID: "user-2", Status: domain.PRStatusOpen}, Score: 1},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/search?q=payment+retry&status=OPEN&limit=10", nil)
+		w := httptest.NewRecorder()
+
+		handler.SearchPRs(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "score")
+
+		var page service.PRSearchPage
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		require.Len(t, page.PullRequests, 2)
+		assert.Equal(t, "pr-2", page.PullRequests[0].PullRequestID)
+		assert.Equal(t, "Payment retry", page.PullRequests[0].PullRequestName)
+		assert.Empty(t, page.NextCursor)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		handler := newHandler(new(mocks.MockPRRepository))
+
+		for _, query := range []string{"", "q=+", "q=payment&status=REVIEWED", "q=payment&limit=ten", "q=payment&cursor=abc"} {
+			req := httptest.NewRequest(http.MethodGet, "/pullRequest/search?"+query, nil)
+			w := httptest.NewRecorder()
+
+			handler.SearchPRs(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			assert.Contains(t, w.Body.String(), "INVALID_QUERY", query)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		handler := newHandler(mockPRRepo)
+
+		mockPRRepo.On("Search", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/search?q=payment", nil)
+		w := httptest.NewRecorder()
+
+		handler.SearchPRs(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"assignment-service/internal/domain"
 	"assignment-service/internal/http/dto"
@@ -50,6 +56,172 @@ func (h *StatsHandler) GetUserStats(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(stats)
 }
 
+func (h *StatsHandler) GetAllUserStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	limit, err := parseLimit(query)
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidQuery, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := service.UserStatsParams{
+		TeamName: query.Get("team_name"),
+		SortBy:   query.Get("sort_by"),
+		Order:    query.Get("order"),
+		Cursor:   query.Get("cursor"),
+		Limit:    limit,
+	}
+
+	page, err := h.statsService.GetAllUserStats(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to get users stats", zap.Error(err))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(page)
+}
+
+func (h *StatsHandler) GetTeamStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	teamName := query.Get("team_name")
+	if teamName == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "team_name is required", http.StatusBadRequest)
+		return
+	}
+
+	from, to, err := parseWindow(query)
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidQuery, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.statsService.GetTeamStats(r.Context(), teamName, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTeamNotFound):
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidQuery):
+			h.sendError(w, domain.ToErrorCode(err), "from must be before to", http.StatusBadRequest)
+		default:
+			h.logger.Error("failed to get team stats", zap.Error(err))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(stats)
+}
+
+func (h *StatsHandler) GetMergeTimeStats(w http.ResponseWriter, r *http.Request) {
+	h.getLatencyStats(w, r, func(ctx context.Context, teamName string, from, to *time.Time) (any, error) {
+		return h.statsService.GetMergeTimeStats(ctx, teamName, from, to)
+	})
+}
+
+func (h *StatsHandler) GetFirstReviewStats(w http.ResponseWriter, r *http.Request) {
+	h.getLatencyStats(w, r, func(ctx context.Context, teamName string, from, to *time.Time) (any, error) {
+		return h.statsService.GetFirstReviewStats(ctx, teamName, from, to)
+	})
+}
+
+func (h *StatsHandler) GetReassignmentStats(w http.ResponseWriter, r *http.Request) {
+	h.getLatencyStats(w, r, func(ctx context.Context, teamName string, from, to *time.Time) (any, error) {
+		return h.statsService.GetReassignmentStats(ctx, teamName, from, to)
+	})
+}
+
+// getLatencyStats serves latency endpoints that take optional team_name, from and to parameters
+func (h *StatsHandler) getLatencyStats(
+	w http.ResponseWriter,
+	r *http.Request,
+	load func(ctx context.Context, teamName string, from, to *time.Time) (any, error),
+) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	from, to, err := parseWindow(query)
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidQuery, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := load(r.Context(), query.Get("team_name"), from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			h.sendError(w, domain.ToErrorCode(err), "from must be before to", http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to get latency stats", zap.Error(err))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(stats)
+}
+
+// parseWindow reads optional from/to query parameters given as RFC 3339 timestamps or dates (YYYY-MM-DD)
+func parseWindow(query url.Values) (from, to *time.Time, err error) {
+	if from, err = parseTimeParam(query, "from"); err != nil {
+		return nil, nil, err
+	}
+	if to, err = parseTimeParam(query, "to"); err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// parseLimit returns 0 for an empty limit, the service applies the default page size
+func parseLimit(query url.Values) (int, error) {
+	raw := query.Get("limit")
+	if raw == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, errors.New("limit must be a number")
+	}
+	return limit, nil
+}
+
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	return parseTime(query.Get(name), name)
+}
+
+// parseTime returns nil for an empty value
+func parseTime(raw, name string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s must be an RFC 3339 timestamp or a YYYY-MM-DD date", name)
+}
+
 func (h *StatsHandler) sendError(w http.ResponseWriter, code domain.ErrorCode, message string, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -1,17 +1,20 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 	"assignment-service/internal/repository/mocks"
 	"assignment-service/internal/service"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -21,7 +24,7 @@ func TestStatsHandlerGetUserStats(t *testing.T) {
 	t.Run("successful get stats", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		statsService := service.NewStatsService(mockPRRepo, mockUserRepo, logger)
+		statsService := service.NewStatsService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), logger)
 		handler := NewStatsHandler(statsService, logger)
 
 		user := &domain.User{
@@ -68,7 +71,7 @@ func TestStatsHandlerGetUserStats(t *testing.T) {
 	t.Run("user not found", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		statsService := service.NewStatsService(mockPRRepo, mockUserRepo, logger)
+		statsService := service.NewStatsService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), logger)
 		handler := NewStatsHandler(statsService, logger)
 
 		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(nil, domain.ErrUserNotFound)
@@ -104,3 +107,278 @@ func TestStatsHandlerGetUserStats(t *testing.T) {
 		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
 	})
 }
+
+func TestStatsHandlerGetAllUserStats(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("successful get stats", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		statsService := service.NewStatsService(mockPRRepo, new(mocks.MockUserRepository), new(mocks.MockTeamRepository), logger)
+		handler := NewStatsHandler(statsService, logger)
+
+		rows := []*domain.UserStats{
+			{UserID: "user-1", AssignedCount: 0},
+			{UserID: "user-2", AssignedCount: 2},
+		}
+		mockPRRepo.On("ListUserStats", mock.Anything, repository.UserStatsQuery{
+			TeamName: "backend",
+			SortBy:   domain.UserStatsSortOpen,
+			Limit:    11,
+		}).Return(rows, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/users?team_name=backend&sort_by=open_pr_count&order=asc&limit=10", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetAllUserStats(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var response service.UserStatsPage
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, rows, response.Users)
+		assert.Empty(t, response.NextCursor)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		handler := NewStatsHandler(service.NewStatsService(nil, nil, nil, logger), logger)
+
+		for _, query := range []string{"limit=ten", "sort_by=username", "cursor=broken"} {
+			t.Run(query, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/stats/users?"+query, nil)
+				w := httptest.NewRecorder()
+
+				handler.GetAllUserStats(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Contains(t, w.Body.String(), string(domain.ErrorCodeInvalidQuery))
+			})
+		}
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		handler := NewStatsHandler(service.NewStatsService(mockPRRepo, nil, nil, logger), logger)
+
+		mockPRRepo.On("ListUserStats", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/users", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetAllUserStats(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewStatsHandler(nil, logger)
+
+		req := httptest.NewRequest(http.MethodPost, "/stats/users", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetAllUserStats(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestStatsHandlerGetTeamStats(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("successful get stats", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		handler := NewStatsHandler(service.NewStatsService(mockPRRepo, mockUserRepo, mockTeamRepo, logger), logger)
+
+		from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		mockTeamRepo.On("Exists", mock.Anything, "backend").Return(true, nil)
+		mockUserRepo.On("GetByTeam", mock.Anything, "backend").Return([]*domain.User{{UserID: "u1"}, {UserID: "u2"}}, nil)
+		mockPRRepo.On("CountByStatus", mock.Anything, mock.MatchedBy(func(q repository.PRCountQuery) bool {
+			return q.Created.From != nil && q.Created.From.Equal(from) && q.Created.To == nil
+		})).Return(map[domain.PRStatus]int{domain.PRStatusOpen: 1}, nil)
+		mockPRRepo.On("ListUserStats", mock.Anything, mock.Anything).Return([]*domain.UserStats{
+			{UserID: "u1", AssignedCount: 1},
+			{UserID: "u2", AssignedCount: 0},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/team?team_name=backend&from=2025-03-01", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetTeamStats(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domain.TeamStats
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, 1, response.TotalPRs)
+		assert.Len(t, response.Members, 2)
+		assert.Nil(t, response.Fairness.MaxMinRatio)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		handler := NewStatsHandler(service.NewStatsService(nil, nil, mockTeamRepo, logger), logger)
+
+		mockTeamRepo.On("Exists", mock.Anything, "ghosts").Return(false, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/team?team_name=ghosts", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetTeamStats(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		handler := NewStatsHandler(service.NewStatsService(nil, nil, nil, logger), logger)
+
+		cases := map[string]string{
+			"missing team_name": "from=2025-03-01",
+			"malformed from":    "team_name=backend&from=yesterday",
+			"malformed to":      "team_name=backend&to=03/15/2025",
+			"to before from":    "team_name=backend&from=2025-03-15&to=2025-03-01T00:00:00Z",
+		}
+
+		for name, query := range cases {
+			t.Run(name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/stats/team?"+query, nil)
+				w := httptest.NewRecorder()
+
+				handler.GetTeamStats(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewStatsHandler(nil, logger)
+
+		req := httptest.NewRequest(http.MethodPost, "/stats/team?team_name=backend", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetTeamStats(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestStatsHandlerLatencyStats(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("time to merge", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		handler := NewStatsHandler(service.NewStatsService(mockPRRepo, nil, nil, logger), logger)
+
+		created := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+		merged := created.Add(time.Hour)
+		mockPRRepo.On("ListMergeTimings", mock.Anything, mock.MatchedBy(func(q repository.PRTimingQuery) bool {
+			return q.TeamName == "backend" && q.Window.From != nil && q.Window.To != nil
+		})).Return([]*domain.PRTiming{
+			{PullRequestID: "pr-1", AuthorID: "u1", TeamName: "backend", CreatedAt: created, MergedAt: &merged},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/timeToMerge?team_name=backend&from=2025-03-01&to=2025-03-15", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetMergeTimeStats(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domain.MergeTimeStats
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, 1, response.Overall.Count)
+		assert.InDelta(t, 3600, response.Overall.MedianSeconds, 1e-6)
+		require.Len(t, response.Authors, 1)
+		assert.Equal(t, "u1", response.Authors[0].AuthorID)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("time to first review", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		handler := NewStatsHandler(service.NewStatsService(mockPRRepo, nil, nil, logger), logger)
+
+		created := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		reviewed := created.Add(time.Hour)
+		mockPRRepo.On("ListReviewTimings", mock.Anything, repository.PRTimingQuery{TeamName: "backend"}).Return([]*domain.PRTiming{
+			{PullRequestID: "pr-1", AuthorID: "u1", TeamName: "backend", CreatedAt: created, FirstReviewedAt: &reviewed},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/timeToFirstReview?team_name=backend", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetFirstReviewStats(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domain.FirstReviewStats
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, 1, response.PRCount)
+		assert.InDelta(t, 3600, response.Overall.MedianSeconds, 1e-6)
+		require.Len(t, response.Teams, 1)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("time to first reassignment", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		handler := NewStatsHandler(service.NewStatsService(mockPRRepo, nil, nil, logger), logger)
+
+		mockPRRepo.On("ListReassignmentTimings", mock.Anything, repository.PRTimingQuery{}).Return([]*domain.PRTiming{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/timeToFirstReassignment", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetReassignmentStats(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domain.ReassignmentStats
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Zero(t, response.PRCount)
+		assert.Empty(t, response.PullRequests)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		handler := NewStatsHandler(service.NewStatsService(nil, nil, nil, logger), logger)
+
+		for _, query := range []string{"from=tomorrow", "from=2025-03-15&to=2025-03-01"} {
+			t.Run(query, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/stats/timeToMerge?"+query, nil)
+				w := httptest.NewRecorder()
+
+				handler.GetMergeTimeStats(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Contains(t, w.Body.String(), string(domain.ErrorCodeInvalidQuery))
+			})
+		}
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		handler := NewStatsHandler(service.NewStatsService(mockPRRepo, nil, nil, logger), logger)
+
+		mockPRRepo.On("ListReassignmentTimings", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats/timeToFirstReassignment", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetReassignmentStats(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewStatsHandler(nil, logger)
+
+		req := httptest.NewRequest(http.MethodPost, "/stats/timeToMerge", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetMergeTimeStats(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
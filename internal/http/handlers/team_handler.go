@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
+	"strings"
 
 	"assignment-service/internal/domain"
 	"assignment-service/internal/http/dto"
@@ -42,16 +45,42 @@ func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 
 	members := make([]domain.TeamMember, len(req.Members))
 	for i, m := range req.Members {
-		members[i] = domain.TeamMember{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+		member, problem := toTeamMember(m)
+		if problem != "" {
+			h.sendError(w, domain.ErrorCodeNotFound, problem, http.StatusBadRequest)
+			return
 		}
+		members[i] = member
 	}
 
 	team := &domain.Team{
-		TeamName: req.TeamName,
-		Members:  members,
+		TeamName:       req.TeamName,
+		Members:        members,
+		ReviewersCount: req.ReviewersCount,
+		MinReviewers:   req.MinReviewers,
+		MaxReviewers:   req.MaxReviewers,
+		FallbackTeams:  req.FallbackTeams,
+
+		RequiredApprovals: req.RequiredApprovals,
+	}
+
+	if err := team.ValidateReviewersPolicy(); err != nil {
+		h.sendError(w, domain.ToErrorCode(err), "reviewers_count, min_reviewers and max_reviewers must be non-negative and consistent", http.StatusBadRequest)
+		return
+	}
+
+	if req.RequiredApprovals < 0 {
+		h.sendError(w, domain.ErrorCodeNotFound, "required_approvals must be >= 0", http.StatusBadRequest)
+		return
+	}
+
+	seen := make(map[string]bool, len(req.FallbackTeams))
+	for _, fallback := range req.FallbackTeams {
+		if fallback == "" || fallback == req.TeamName || seen[fallback] {
+			h.sendError(w, domain.ErrorCodeNotFound, "fallback_teams must be unique, non-empty and must not contain the team itself", http.StatusBadRequest)
+			return
+		}
+		seen[fallback] = true
 	}
 
 	if err := h.teamService.CreateTeam(r.Context(), team); err != nil {
@@ -59,6 +88,10 @@ func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusBadRequest)
 			return
 		}
+		if err == domain.ErrUserInAnotherTeam {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusConflict)
+			return
+		}
 
 		h.logger.Error("failed to create team", zap.Error(err))
 		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
@@ -98,6 +131,296 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(team)
 }
 
+func (h *TeamHandler) DeactivateUsers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.DeactivateUsersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.TeamName == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "team_name is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.UserIDs) == 0 {
+		h.sendError(w, domain.ErrorCodeNotFound, "user_ids is required", http.StatusBadRequest)
+		return
+	}
+	if slices.Contains(req.UserIDs, "") {
+		h.sendError(w, domain.ErrorCodeNotFound, "user_ids must not contain empty values", http.StatusBadRequest)
+		return
+	}
+
+	userIDs := slices.Clone(req.UserIDs)
+	slices.Sort(userIDs)
+	userIDs = slices.Compact(userIDs)
+
+	reassignments, err := h.teamService.DeactivateUsers(r.Context(), req.TeamName, userIDs)
+	if err != nil {
+		switch err {
+		case domain.ErrTeamNotFound, domain.ErrUserNotFound:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+		default:
+			h.logger.Error("failed to deactivate users", zap.Error(err), zap.String("team_name", req.TeamName))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.DeactivateUsersResponse{
+		TeamName:           req.TeamName,
+		DeactivatedUserIDs: userIDs,
+		Reassignments:      reassignments,
+	})
+}
+
+func (h *TeamHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.AddTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.TeamName == "" || req.Member.UserID == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "team_name and member.user_id are required", http.StatusBadRequest)
+		return
+	}
+
+	member, problem := toTeamMember(req.Member)
+	if problem != "" {
+		h.sendError(w, domain.ErrorCodeNotFound, problem, http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.teamService.AddMember(r.Context(), req.TeamName, member)
+	if err != nil {
+		switch err {
+		case domain.ErrTeamNotFound:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+		case domain.ErrUserInAnotherTeam:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusConflict)
+		default:
+			h.logger.Error("failed to add team member", zap.Error(err), zap.String("team_name", req.TeamName))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.TeamResponse{Team: *team})
+}
+
+// RemoveMember takes the user out of the team and hands their OPEN reviews over to the remaining members
+func (h *TeamHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.RemoveTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.TeamName == "" || req.UserID == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "team_name and user_id are required", http.StatusBadRequest)
+		return
+	}
+
+	reassignments, err := h.teamService.RemoveMember(r.Context(), req.TeamName, req.UserID)
+	if err != nil {
+		switch err {
+		case domain.ErrTeamNotFound, domain.ErrUserNotFound:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+		default:
+			h.logger.Error("failed to remove team member", zap.Error(err), zap.String("team_name", req.TeamName))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.RemoveTeamMemberResponse{
+		TeamName:      req.TeamName,
+		UserID:        req.UserID,
+		Reassignments: reassignments,
+	})
+}
+
+func (h *TeamHandler) RenameTeam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.RenameTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.TeamName == "" || req.NewTeamName == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "team_name and new_team_name are required", http.StatusBadRequest)
+		return
+	}
+	if req.TeamName == req.NewTeamName {
+		h.sendError(w, domain.ErrorCodeNotFound, "new_team_name must differ from team_name", http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.teamService.RenameTeam(r.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		switch err {
+		case domain.ErrTeamNotFound:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+		case domain.ErrTeamExists:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusBadRequest)
+		default:
+			h.logger.Error("failed to rename team", zap.Error(err), zap.String("team_name", req.TeamName))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.TeamResponse{Team: *team})
+}
+
+func (h *TeamHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.DeleteTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.TeamName == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "team_name is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.teamService.DeleteTeam(r.Context(), req.TeamName); err != nil {
+		switch err {
+		case domain.ErrTeamNotFound:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+		case domain.ErrTeamNotEmpty:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusConflict)
+		default:
+			h.logger.Error("failed to delete team", zap.Error(err), zap.String("team_name", req.TeamName))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.DeleteTeamResponse{TeamName: req.TeamName})
+}
+
+// MoveUser transfers the user to another team and hands their OPEN reviews on the old team's PRs over
+// to the old team
+func (h *TeamHandler) MoveUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.MoveTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UserID == "" || req.TeamName == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "user_id and team_name are required", http.StatusBadRequest)
+		return
+	}
+
+	user, fromTeam, reassignments, err := h.teamService.MoveUser(r.Context(), req.UserID, req.TeamName)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound, domain.ErrTeamNotFound:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+		default:
+			h.logger.Error("failed to move user", zap.Error(err), zap.String("user_id", req.UserID))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.MoveTeamResponse{
+		User:          *user,
+		FromTeam:      fromTeam,
+		Reassignments: reassignments,
+	})
+}
+
+// toTeamMember validates a member from a request, a non-empty problem describes what is wrong
+func toTeamMember(m dto.TeamMember) (domain.TeamMember, string) {
+	if m.MaxOpenReviews < 0 {
+		return domain.TeamMember{}, "max_open_reviews must be >= 0"
+	}
+	if m.Email != "" && !strings.Contains(m.Email, "@") {
+		return domain.TeamMember{}, "email must be a valid address"
+	}
+
+	return domain.TeamMember{
+		UserID:         m.UserID,
+		Username:       m.Username,
+		IsActive:       m.IsActive,
+		MaxOpenReviews: m.MaxOpenReviews,
+		Email:          domain.NormalizeEmail(m.Email),
+	}, ""
+}
+
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	limit, err := parseLimit(query)
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidQuery, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.teamService.ListTeams(r.Context(), service.TeamListParams{
+		Order:  query.Get("order"),
+		Cursor: query.Get("cursor"),
+		Limit:  limit,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to list teams", zap.Error(err))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(page)
+}
+
 func (h *TeamHandler) sendError(w http.ResponseWriter, code domain.ErrorCode, message string, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -9,11 +9,13 @@ import (
 
 	"assignment-service/internal/domain"
 	"assignment-service/internal/http/dto"
+	"assignment-service/internal/repository"
 	"assignment-service/internal/repository/mocks"
 	"assignment-service/internal/service"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -23,10 +25,11 @@ func TestTeamHandlerCreateTeam(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
 		mockTeamRepo := new(mocks.MockTeamRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, logger)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
 		handler := NewTeamHandler(teamService, logger)
 
 		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(false, nil)
+		mockUserRepo.On("GetByIDs", mock.Anything, []string{"user-1", "user-2"}).Return([]*domain.User{}, nil)
 		mockTeamRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Team")).Return(nil)
 		mockUserRepo.On("CreateOrUpdate", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Times(2)
 
@@ -52,7 +55,7 @@ func TestTeamHandlerCreateTeam(t *testing.T) {
 	t.Run("team already exists", func(t *testing.T) {
 		mockTeamRepo := new(mocks.MockTeamRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, logger)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
 		handler := NewTeamHandler(teamService, logger)
 
 		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
@@ -71,6 +74,83 @@ func TestTeamHandlerCreateTeam(t *testing.T) {
 		mockTeamRepo.AssertExpectations(t)
 	})
 
+	t.Run("inconsistent reviewers policy", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		reqBody := dto.CreateTeamRequest{
+			TeamName:     "team-1",
+			MinReviewers: 3,
+			MaxReviewers: 2,
+		}
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.CreateTeam(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("negative max_open_reviews", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		reqBody := dto.CreateTeamRequest{
+			TeamName: "team-1",
+			Members: []dto.TeamMember{
+				{UserID: "user-1", Username: "user1", IsActive: true, MaxOpenReviews: -1},
+			},
+		}
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.CreateTeam(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("negative required_approvals", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		reqBody := dto.CreateTeamRequest{
+			TeamName:          "team-1",
+			RequiredApprovals: -1,
+		}
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.CreateTeam(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid fallback_teams", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		cases := map[string][]string{
+			"empty name": {""},
+			"self":       {"team-1"},
+			"duplicate":  {"mobile", "mobile"},
+		}
+
+		for name, fallbackTeams := range cases {
+			t.Run(name, func(t *testing.T) {
+				reqBody := dto.CreateTeamRequest{
+					TeamName:      "team-1",
+					FallbackTeams: fallbackTeams,
+				}
+				body, _ := json.Marshal(reqBody)
+				req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+				w := httptest.NewRecorder()
+
+				handler.CreateTeam(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+
 	t.Run("missing team_name", func(t *testing.T) {
 		handler := NewTeamHandler(nil, logger)
 
@@ -115,22 +195,17 @@ func TestTeamHandlerGetTeam(t *testing.T) {
 	t.Run("successful get team", func(t *testing.T) {
 		mockTeamRepo := new(mocks.MockTeamRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, logger)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
 		handler := NewTeamHandler(teamService, logger)
 
 		team := &domain.Team{
 			TeamName: "team-1",
 			Members: []domain.TeamMember{
-				{UserID: "user-1", Username: "user1", IsActive: true},
+				{UserID: "user-1", Username: "user1", IsActive: false},
 			},
 		}
 
-		users := []*domain.User{
-			{UserID: "user-1", Username: "user1", TeamName: "team-1", IsActive: false},
-		}
-
-		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(team, nil)
-		mockUserRepo.On("GetByTeam", mock.Anything, "team-1").Return(users, nil)
+		mockTeamRepo.On("GetWithMembers", mock.Anything, "team-1").Return(team, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=team-1", nil)
 		w := httptest.NewRecorder()
@@ -146,10 +221,10 @@ func TestTeamHandlerGetTeam(t *testing.T) {
 	t.Run("team not found", func(t *testing.T) {
 		mockTeamRepo := new(mocks.MockTeamRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, logger)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
 		handler := NewTeamHandler(teamService, logger)
 
-		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockTeamRepo.On("GetWithMembers", mock.Anything, "team-1").Return(nil, domain.ErrTeamNotFound)
 
 		req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=team-1", nil)
 		w := httptest.NewRecorder()
@@ -182,3 +257,556 @@ func TestTeamHandlerGetTeam(t *testing.T) {
 		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
 	})
 }
+
+func TestTeamHandlerDeactivateUsers(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("successful deactivation", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, prService, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		members := []*domain.User{
+			{UserID: "user-1", TeamName: "team-1", IsActive: true},
+			{UserID: "user-2", TeamName: "team-1", IsActive: true},
+			{UserID: "user-3", TeamName: "team-1", IsActive: true},
+		}
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "user-3", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-1"}},
+		}
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(&domain.Team{TeamName: "team-1"}, nil)
+		mockUserRepo.On("GetByTeam", mock.Anything, "team-1").Return(members, nil)
+		mockUserRepo.On("UpdateIsActiveMany", mock.Anything, []string{"user-1"}, false).Return(nil)
+		mockUserRepo.On("GetActiveByTeam", mock.Anything, "team-1").Return(members[1:], nil)
+		mockPRRepo.On("GetOpenByReviewers", mock.Anything, []string{"user-1"}).Return(prs, nil)
+		mockPRRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		body := createJSONBody(t, map[string]any{
+			"team_name": "team-1",
+			"user_ids":  []string{"user-1", "user-1"},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/team/deactivateUsers", body)
+		w := httptest.NewRecorder()
+
+		handler.DeactivateUsers(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.DeactivateUsersResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, []string{"user-1"}, response.DeactivatedUserIDs)
+		assert.Equal(t, []*domain.ReviewerReassignment{
+			{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-2", Outcome: domain.ReassignmentReplaced},
+		}, response.Reassignments)
+		mockUserRepo.AssertExpectations(t)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not in team", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
+		mockUserRepo.On("GetByTeam", mock.Anything, "team-1").Return([]*domain.User{}, nil)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1", "user_ids": []string{"user-1"}})
+		req := httptest.NewRequest(http.MethodPost, "/team/deactivateUsers", body)
+		w := httptest.NewRecorder()
+
+		handler.DeactivateUsers(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockUserRepo.AssertNotCalled(t, "UpdateIsActiveMany")
+	})
+
+	t.Run("reassignment error", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, prService, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(&domain.Team{TeamName: "team-1"}, nil)
+		mockUserRepo.On("GetByTeam", mock.Anything, "team-1").Return([]*domain.User{{UserID: "user-1"}}, nil)
+		mockUserRepo.On("UpdateIsActiveMany", mock.Anything, []string{"user-1"}, false).Return(nil)
+		mockPRRepo.On("GetOpenByReviewers", mock.Anything, []string{"user-1"}).Return(nil, assert.AnError)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1", "user_ids": []string{"user-1"}})
+		req := httptest.NewRequest(http.MethodPost, "/team/deactivateUsers", body)
+		w := httptest.NewRecorder()
+
+		handler.DeactivateUsers(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		cases := map[string]string{
+			"invalid body":      "invalid json",
+			"missing team_name": `{"user_ids": ["user-1"]}`,
+			"missing user_ids":  `{"team_name": "team-1"}`,
+			"empty user_id":     `{"team_name": "team-1", "user_ids": ["user-1", ""]}`,
+		}
+
+		for name, raw := range cases {
+			t.Run(name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/team/deactivateUsers", bytes.NewReader([]byte(raw)))
+				w := httptest.NewRecorder()
+
+				handler.DeactivateUsers(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/team/deactivateUsers", nil)
+		w := httptest.NewRecorder()
+
+		handler.DeactivateUsers(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestTeamHandlerAddMember(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("successful add", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		member := domain.TeamMember{UserID: "user-1", Username: "alice", IsActive: true, Email: "alice@example.com"}
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(nil, domain.ErrUserNotFound)
+		mockUserRepo.On("CreateOrUpdate", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+		mockTeamRepo.On("GetWithMembers", mock.Anything, "team-1").Return(&domain.Team{TeamName: "team-1", Members: []domain.TeamMember{member}}, nil)
+
+		body := createJSONBody(t, map[string]any{
+			"team_name": "team-1",
+			"member":    map[string]any{"user_id": "user-1", "username": "alice", "is_active": true, "email": "Alice@Example.com"},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/team/addMember", body)
+		w := httptest.NewRecorder()
+
+		handler.AddMember(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.TeamResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, []domain.TeamMember{member}, response.Team.Members)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("user of another team", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-2"}, nil)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1", "member": map[string]any{"user_id": "user-1"}})
+		req := httptest.NewRequest(http.MethodPost, "/team/addMember", body)
+		w := httptest.NewRecorder()
+
+		handler.AddMember(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "USER_IN_ANOTHER_TEAM")
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		for _, payload := range []map[string]any{
+			{"team_name": "team-1", "member": map[string]any{}},
+			{"member": map[string]any{"user_id": "user-1"}},
+			{"team_name": "team-1", "member": map[string]any{"user_id": "user-1", "max_open_reviews": -1}},
+			{"team_name": "team-1", "member": map[string]any{"user_id": "user-1", "email": "nope"}},
+		} {
+			req := httptest.NewRequest(http.MethodPost, "/team/addMember", createJSONBody(t, payload))
+			w := httptest.NewRecorder()
+
+			handler.AddMember(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, payload)
+		}
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/team/addMember", nil)
+		w := httptest.NewRecorder()
+
+		handler.AddMember(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestTeamHandlerRemoveMember(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("removes member and reassigns reviews", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, prService, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "user-3", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-1"}},
+		}
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-1"}, nil)
+		mockUserRepo.On("UpdateTeam", mock.Anything, "user-1", "").Return(nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(&domain.Team{TeamName: "team-1"}, nil)
+		mockUserRepo.On("GetActiveByTeam", mock.Anything, "team-1").Return([]*domain.User{
+			{UserID: "user-2", TeamName: "team-1", IsActive: true},
+		}, nil)
+		mockPRRepo.On("GetOpenByReviewers", mock.Anything, []string{"user-1"}).Return(prs, nil)
+		mockPRRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1", "user_id": "user-1"})
+		req := httptest.NewRequest(http.MethodPost, "/team/removeMember", body)
+		w := httptest.NewRecorder()
+
+		handler.RemoveMember(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.RemoveTeamMemberResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "user-1", response.UserID)
+		assert.Equal(t, []*domain.ReviewerReassignment{
+			{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-2", Outcome: domain.ReassignmentReplaced},
+		}, response.Reassignments)
+		mockUserRepo.AssertExpectations(t)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not in team", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-2"}, nil)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1", "user_id": "user-1"})
+		req := httptest.NewRequest(http.MethodPost, "/team/removeMember", body)
+		w := httptest.NewRecorder()
+
+		handler.RemoveMember(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1"})
+		req := httptest.NewRequest(http.MethodPost, "/team/removeMember", body)
+		w := httptest.NewRecorder()
+
+		handler.RemoveMember(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTeamHandlerRenameTeam(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("successful rename", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockTeamRepo.On("Rename", mock.Anything, "team-1", "platform").Return(nil)
+		mockUserRepo.On("RenameTeam", mock.Anything, "team-1", "platform").Return(nil)
+		mockTeamRepo.On("GetWithMembers", mock.Anything, "platform").Return(&domain.Team{TeamName: "platform", Members: []domain.TeamMember{}}, nil)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1", "new_team_name": "platform"})
+		req := httptest.NewRequest(http.MethodPost, "/team/rename", body)
+		w := httptest.NewRecorder()
+
+		handler.RenameTeam(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.TeamResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "platform", response.Team.TeamName)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockTeamRepo.On("Rename", mock.Anything, "team-1", "team-2").Return(domain.ErrTeamExists)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1", "new_team_name": "team-2"})
+		req := httptest.NewRequest(http.MethodPost, "/team/rename", body)
+		w := httptest.NewRecorder()
+
+		handler.RenameTeam(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "TEAM_EXISTS")
+	})
+
+	t.Run("same name", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1", "new_team_name": "team-1"})
+		req := httptest.NewRequest(http.MethodPost, "/team/rename", body)
+		w := httptest.NewRecorder()
+
+		handler.RenameTeam(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTeamHandlerDeleteTeam(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("successful delete", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
+		mockUserRepo.On("GetByTeam", mock.Anything, "team-1").Return([]*domain.User{}, nil)
+		mockTeamRepo.On("Delete", mock.Anything, "team-1").Return(nil)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1"})
+		req := httptest.NewRequest(http.MethodPost, "/team/delete", body)
+		w := httptest.NewRecorder()
+
+		handler.DeleteTeam(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("team not empty", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(true, nil)
+		mockUserRepo.On("GetByTeam", mock.Anything, "team-1").Return([]*domain.User{{UserID: "user-1"}}, nil)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1"})
+		req := httptest.NewRequest(http.MethodPost, "/team/delete", body)
+		w := httptest.NewRecorder()
+
+		handler.DeleteTeam(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "TEAM_NOT_EMPTY")
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		teamService := service.NewTeamService(mockTeamRepo, new(mocks.MockUserRepository), nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(false, nil)
+
+		body := createJSONBody(t, map[string]any{"team_name": "team-1"})
+		req := httptest.NewRequest(http.MethodPost, "/team/delete", body)
+		w := httptest.NewRecorder()
+
+		handler.DeleteTeam(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestTeamHandlerMoveUser(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("moves user and reassigns old team reviews", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, prService, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "user-3", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-1"}},
+		}
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}, nil)
+		mockTeamRepo.On("Exists", mock.Anything, "team-2").Return(true, nil)
+		mockUserRepo.On("UpdateTeam", mock.Anything, "user-1", "team-2").Return(nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(&domain.Team{TeamName: "team-1"}, nil)
+		mockPRRepo.On("GetOpenByReviewers", mock.Anything, []string{"user-1"}).Return(prs, nil)
+		mockUserRepo.On("GetByTeam", mock.Anything, "team-2").Return([]*domain.User{{UserID: "user-1", TeamName: "team-2"}}, nil)
+		mockUserRepo.On("GetActiveByTeam", mock.Anything, "team-1").Return([]*domain.User{
+			{UserID: "user-2", TeamName: "team-1", IsActive: true},
+		}, nil)
+		mockPRRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "team_name": "team-2"})
+		req := httptest.NewRequest(http.MethodPost, "/users/moveTeam", body)
+		w := httptest.NewRecorder()
+
+		handler.MoveUser(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.MoveTeamResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "team-2", response.User.TeamName)
+		assert.Equal(t, "team-1", response.FromTeam)
+		assert.Equal(t, []*domain.ReviewerReassignment{
+			{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-2", Outcome: domain.ReassignmentReplaced},
+		}, response.Reassignments)
+		mockTeamRepo.AssertExpectations(t)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("same team", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(new(mocks.MockTeamRepository), mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-1"}, nil)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "team_name": "team-1"})
+		req := httptest.NewRequest(http.MethodPost, "/users/moveTeam", body)
+		w := httptest.NewRecorder()
+
+		handler.MoveUser(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"reassignments":[]`)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+		handler := NewTeamHandler(teamService, logger)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-1"}, nil)
+		mockTeamRepo.On("Exists", mock.Anything, "team-2").Return(false, nil)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "team_name": "team-2"})
+		req := httptest.NewRequest(http.MethodPost, "/users/moveTeam", body)
+		w := httptest.NewRecorder()
+
+		handler.MoveUser(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		handler := NewTeamHandler(nil, logger)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1"})
+		req := httptest.NewRequest(http.MethodPost, "/users/moveTeam", body)
+		w := httptest.NewRecorder()
+
+		handler.MoveUser(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTeamHandlerCreateTeamUserInAnotherTeam(t *testing.T) {
+	logger := zap.NewNop()
+	mockTeamRepo := new(mocks.MockTeamRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	teamService := service.NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
+	handler := NewTeamHandler(teamService, logger)
+
+	mockTeamRepo.On("Exists", mock.Anything, "team-1").Return(false, nil)
+	mockUserRepo.On("GetByIDs", mock.Anything, []string{"user-1"}).Return([]*domain.User{{UserID: "user-1", TeamName: "team-2"}}, nil)
+
+	body := createJSONBody(t, map[string]any{
+		"team_name": "team-1",
+		"members":   []map[string]any{{"user_id": "user-1", "username": "alice", "is_active": true}},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/team/add", body)
+	w := httptest.NewRecorder()
+
+	handler.CreateTeam(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "USER_IN_ANOTHER_TEAM")
+	mockTeamRepo.AssertNotCalled(t, "Create")
+}
+
+func TestTeamHandlerListTeams(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("returns page with next cursor", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		handler := NewTeamHandler(service.NewTeamService(mockTeamRepo, new(mocks.MockUserRepository), nil, mocks.MockUnitOfWork{}, logger), logger)
+
+		mockTeamRepo.On("List", mock.Anything, repository.TeamListQuery{Limit: 2}).Return([]*domain.Team{
+			{TeamName: "backend", Members: []domain.TeamMember{}},
+			{TeamName: "frontend", Members: []domain.TeamMember{}},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/teams?limit=1", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListTeams(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var page service.TeamsPage
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		require.Len(t, page.Teams, 1)
+		assert.Equal(t, "backend", page.Teams[0].TeamName)
+		assert.NotEmpty(t, page.NextCursor)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		handler := NewTeamHandler(service.NewTeamService(new(mocks.MockTeamRepository), new(mocks.MockUserRepository), nil, mocks.MockUnitOfWork{}, logger), logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/teams?limit=1000", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListTeams(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_QUERY")
+	})
+}
@@ -2,7 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"assignment-service/internal/domain"
 	"assignment-service/internal/http/dto"
@@ -53,10 +58,259 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	response := dto.SetIsActiveResponse{User: *user}
+	if !req.IsActive {
+		response.Reassignments, err = h.prService.ReleaseReviewer(r.Context(), user.UserID, domain.PREventReasonDeactivated)
+		if err != nil {
+			h.logger.Error("failed to reassign reviews of deactivated user", zap.Error(err), zap.String("user_id", user.UserID))
+			h.sendError(w, domain.ErrorCodeNotFound, "user deactivated, but reviews were not reassigned", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UserID == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "user_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.Username == nil && req.Email == nil && req.MaxOpenReviews == nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "nothing to update", http.StatusBadRequest)
+		return
+	}
+	if req.Username != nil && *req.Username == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "username must not be empty", http.StatusBadRequest)
+		return
+	}
+	// an empty email removes it
+	if req.Email != nil && *req.Email != "" && !strings.Contains(*req.Email, "@") {
+		h.sendError(w, domain.ErrorCodeNotFound, "email must be a valid address", http.StatusBadRequest)
+		return
+	}
+	if req.MaxOpenReviews != nil && *req.MaxOpenReviews < 0 {
+		h.sendError(w, domain.ErrorCodeNotFound, "max_open_reviews must be >= 0", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userService.UpdateUser(r.Context(), req.UserID, service.UserUpdate{
+		Username:       req.Username,
+		Email:          req.Email,
+		MaxOpenReviews: req.MaxOpenReviews,
+	})
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to update user", zap.Error(err))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(dto.UserResponse{User: *user})
 }
 
+func (h *UserHandler) AddAbsence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.AddAbsenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UserID == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "user_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.From == "" || req.To == "" {
+		h.sendError(w, domain.ErrorCodeInvalidAbsence, "from and to are required", http.StatusBadRequest)
+		return
+	}
+
+	from, err := parseTime(req.From, "from")
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidAbsence, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := parseTime(req.To, "to")
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidAbsence, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	absence, err := h.userService.AddAbsence(r.Context(), req.UserID, &domain.Absence{
+		From:    from.UTC(),
+		To:      to.UTC(),
+		Reason:  req.Reason,
+		Handoff: req.Handoff,
+	})
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidAbsence:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusBadRequest)
+		case domain.ErrUserNotFound:
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+		default:
+			h.logger.Error("failed to add absence", zap.Error(err), zap.String("user_id", req.UserID))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(dto.AbsenceResponse{UserID: req.UserID, Absence: *absence})
+}
+
+func (h *UserHandler) ListAbsences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	absences, err := h.userService.ListAbsences(r.Context(), userID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to list absences", zap.Error(err), zap.String("user_id", userID))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if absences == nil {
+		absences = []domain.Absence{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.AbsencesResponse{UserID: userID, Absences: absences})
+}
+
+func (h *UserHandler) RemoveAbsence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dto.RemoveAbsenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, domain.ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UserID == "" || req.AbsenceID == "" {
+		h.sendError(w, domain.ErrorCodeNotFound, "user_id and absence_id are required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.userService.RemoveAbsence(r.Context(), req.UserID, req.AbsenceID); err != nil {
+		if err == domain.ErrUserNotFound || err == domain.ErrAbsenceNotFound {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to remove absence", zap.Error(err), zap.String("user_id", req.UserID))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	absences, err := h.userService.ListAbsences(r.Context(), req.UserID)
+	if err != nil {
+		h.logger.Error("failed to list absences", zap.Error(err), zap.String("user_id", req.UserID))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if absences == nil {
+		absences = []domain.Absence{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.AbsencesResponse{UserID: req.UserID, Absences: absences})
+}
+
+// maxCalendarSize limits uploaded .ics files
+const maxCalendarSize = 5 << 20
+
+// ImportAbsences takes an .ics file either as multipart/form-data field "file" or as the raw request body
+func (h *UserHandler) ImportAbsences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	handoff := false
+	if raw := r.URL.Query().Get("handoff"); raw != "" {
+		var err error
+		if handoff, err = strconv.ParseBool(raw); err != nil {
+			h.sendError(w, domain.ErrorCodeNotFound, "handoff must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCalendarSize)
+
+	var calendar io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				h.sendError(w, domain.ErrorCodeInvalidCalendar, "calendar file is too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			h.sendError(w, domain.ErrorCodeInvalidCalendar, "file is required", http.StatusBadRequest)
+			return
+		}
+		//nolint:errcheck
+		defer file.Close()
+		calendar = file
+	}
+
+	results, err := h.userService.ImportAbsences(r.Context(), calendar, handoff)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			h.sendError(w, domain.ErrorCodeInvalidCalendar, "calendar file is too large", http.StatusRequestEntityTooLarge)
+		case errors.Is(err, domain.ErrInvalidCalendar):
+			h.sendError(w, domain.ErrorCodeInvalidCalendar, err.Error(), http.StatusBadRequest)
+		default:
+			h.logger.Error("failed to import absences", zap.Error(err))
+			h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.ImportAbsencesResponse{Results: results})
+}
+
 func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -97,6 +351,49 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	limit, err := parseLimit(query)
+	if err != nil {
+		h.sendError(w, domain.ErrorCodeInvalidQuery, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := service.UserListParams{
+		TeamName: query.Get("team_name"),
+		SortBy:   query.Get("sort_by"),
+		Order:    query.Get("order"),
+		Cursor:   query.Get("cursor"),
+		Limit:    limit,
+	}
+	if raw := query.Get("is_active"); raw != "" {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			h.sendError(w, domain.ErrorCodeInvalidQuery, "is_active must be true or false", http.StatusBadRequest)
+			return
+		}
+		params.IsActive = &isActive
+	}
+
+	page, err := h.userService.ListUsers(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			h.sendError(w, domain.ToErrorCode(err), err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to list users", zap.Error(err))
+		h.sendError(w, domain.ErrorCodeNotFound, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(page)
+}
+
 func (h *UserHandler) sendError(w http.ResponseWriter, code domain.ErrorCode, message string, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -3,13 +3,16 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"assignment-service/internal/domain"
 	"assignment-service/internal/http/dto"
+	"assignment-service/internal/repository"
 	"assignment-service/internal/repository/mocks"
 	"assignment-service/internal/service"
 
@@ -26,7 +29,8 @@ func TestUserHandlerSetIsActive(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		userService := service.NewUserService(mockUserRepo, logger)
 		mockPRRepo := new(mocks.MockPRRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewUserHandler(userService, prService, logger)
 
 		user := &domain.User{
@@ -54,11 +58,69 @@ func TestUserHandlerSetIsActive(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 
+	t.Run("deactivation reassigns open reviews", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		userService := service.NewUserService(mockUserRepo, logger)
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewUserHandler(userService, prService, logger)
+
+		user := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: false}
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "author-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-1"}},
+		}
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(user, nil)
+		mockUserRepo.On("UpdateIsActive", mock.Anything, "user-1", false).Return(nil)
+		mockPRRepo.On("GetByReviewer", mock.Anything, "user-1").Return(prs, nil)
+		mockTeamRepo.On("GetByName", mock.Anything, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", mock.Anything, "team-1").Return([]*domain.User{{UserID: "user-2", IsActive: true}}, nil)
+		mockPRRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "is_active": false})
+		req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", body)
+		w := httptest.NewRecorder()
+
+		handler.SetIsActive(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.SetIsActiveResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.False(t, response.User.IsActive)
+		assert.Equal(t, []*domain.ReviewerReassignment{
+			{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-2", Outcome: domain.ReassignmentReplaced},
+		}, response.Reassignments)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("reassignment error", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		userService := service.NewUserService(mockUserRepo, logger)
+		mockPRRepo := new(mocks.MockPRRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
+		handler := NewUserHandler(userService, prService, logger)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1"}, nil)
+		mockUserRepo.On("UpdateIsActive", mock.Anything, "user-1", false).Return(nil)
+		mockPRRepo.On("GetByReviewer", mock.Anything, "user-1").Return(nil, assert.AnError)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "is_active": false})
+		req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", body)
+		w := httptest.NewRecorder()
+
+		handler.SetIsActive(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
 	t.Run("user not found", func(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		userService := service.NewUserService(mockUserRepo, logger)
 		mockPRRepo := new(mocks.MockPRRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		handler := NewUserHandler(userService, prService, logger)
 
 		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(nil, domain.ErrUserNotFound)
@@ -110,7 +172,8 @@ func TestUserHandlerGetReview(t *testing.T) {
 	t.Run("successful get review", func(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockPRRepo := new(mocks.MockPRRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		userService := service.NewUserService(mockUserRepo, logger)
 		handler := NewUserHandler(userService, prService, logger)
 
@@ -161,7 +224,8 @@ func TestUserHandlerGetReview(t *testing.T) {
 	t.Run("user not found", func(t *testing.T) {
 		mockUserRepo := new(mocks.MockUserRepository)
 		mockPRRepo := new(mocks.MockPRRepository)
-		prService := service.NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := service.NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, service.NewSelectorRegistry(), service.AssignmentPolicy{}, logger)
 		userService := service.NewUserService(mockUserRepo, logger)
 		handler := NewUserHandler(userService, prService, logger)
 
@@ -199,6 +263,435 @@ func TestUserHandlerGetReview(t *testing.T) {
 	})
 }
 
+func TestUserHandlerUpdateUser(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("successful update", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		userService := service.NewUserService(mockUserRepo, logger)
+		handler := NewUserHandler(userService, nil, logger)
+
+		user := &domain.User{UserID: "user-1", Username: "testuser", TeamName: "team-1", IsActive: true, MaxOpenReviews: 4}
+
+		mockUserRepo.On("UpdateProfile", mock.Anything, "user-1", mock.AnythingOfType("repository.UserProfileUpdate")).Return(nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(user, nil)
+
+		body := createJSONBody(t, map[string]any{
+			"user_id":          "user-1",
+			"max_open_reviews": 4,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/users/update", body)
+		w := httptest.NewRecorder()
+
+		handler.UpdateUser(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.UserResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, 4, response.User.MaxOpenReviews)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		userService := service.NewUserService(mockUserRepo, logger)
+		handler := NewUserHandler(userService, nil, logger)
+
+		mockUserRepo.On("UpdateProfile", mock.Anything, "user-1", mock.AnythingOfType("repository.UserProfileUpdate")).Return(domain.ErrUserNotFound)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "username": "new"})
+		req := httptest.NewRequest(http.MethodPost, "/users/update", body)
+		w := httptest.NewRecorder()
+
+		handler.UpdateUser(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		userService := service.NewUserService(mockUserRepo, logger)
+		handler := NewUserHandler(userService, nil, logger)
+
+		mockUserRepo.On("UpdateProfile", mock.Anything, "user-1", mock.AnythingOfType("repository.UserProfileUpdate")).Return(assert.AnError)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "max_open_reviews": 0})
+		req := httptest.NewRequest(http.MethodPost, "/users/update", body)
+		w := httptest.NewRecorder()
+
+		handler.UpdateUser(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("empty email removes it", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		userService := service.NewUserService(mockUserRepo, logger)
+		handler := NewUserHandler(userService, nil, logger)
+
+		mockUserRepo.On("UpdateProfile", mock.Anything, "user-1", mock.MatchedBy(func(update repository.UserProfileUpdate) bool {
+			return update.Email != nil && *update.Email == ""
+		})).Return(nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1"}, nil)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "email": ""})
+		req := httptest.NewRequest(http.MethodPost, "/users/update", body)
+		w := httptest.NewRecorder()
+
+		handler.UpdateUser(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		handler := NewUserHandler(nil, nil, logger)
+
+		cases := map[string]string{
+			"invalid body":      "invalid json",
+			"missing user_id":   `{"max_open_reviews": 1}`,
+			"nothing to update": `{"user_id": "user-1"}`,
+			"empty username":    `{"user_id": "user-1", "username": ""}`,
+			"negative max":      `{"user_id": "user-1", "max_open_reviews": -1}`,
+			"invalid email":     `{"user_id": "user-1", "email": "alice"}`,
+		}
+
+		for name, raw := range cases {
+			t.Run(name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/users/update", bytes.NewReader([]byte(raw)))
+				w := httptest.NewRecorder()
+
+				handler.UpdateUser(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewUserHandler(nil, nil, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/update", nil)
+		w := httptest.NewRecorder()
+
+		handler.UpdateUser(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestUserHandlerAbsences(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("add absence", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		mockUserRepo.On("AddAbsence", mock.Anything, "user-1", mock.MatchedBy(func(a *domain.Absence) bool {
+			return a.From.Equal(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)) &&
+				a.To.Equal(time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)) &&
+				a.Reason == "vacation" && a.Handoff
+		})).Return(nil)
+
+		body := createJSONBody(t, map[string]any{
+			"user_id": "user-1",
+			"from":    "2024-07-01",
+			"to":      "2024-07-15T00:00:00Z",
+			"reason":  "vacation",
+			"handoff": true,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/users/addAbsence", body)
+		w := httptest.NewRecorder()
+
+		handler.AddAbsence(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response dto.AbsenceResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "user-1", response.UserID)
+		assert.NotEmpty(t, response.Absence.AbsenceID)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("add absence for unknown user", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		mockUserRepo.On("AddAbsence", mock.Anything, "user-1", mock.AnythingOfType("*domain.Absence")).Return(domain.ErrUserNotFound)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "from": "2024-07-01", "to": "2024-07-02"})
+		req := httptest.NewRequest(http.MethodPost, "/users/addAbsence", body)
+		w := httptest.NewRecorder()
+
+		handler.AddAbsence(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("add absence validation errors", func(t *testing.T) {
+		handler := NewUserHandler(service.NewUserService(new(mocks.MockUserRepository), logger), nil, logger)
+
+		cases := map[string]string{
+			"invalid body":    "invalid json",
+			"missing user_id": `{"from": "2024-07-01", "to": "2024-07-02"}`,
+			"missing to":      `{"user_id": "user-1", "from": "2024-07-01"}`,
+			"bad from":        `{"user_id": "user-1", "from": "01.07.2024", "to": "2024-07-02"}`,
+			"bad to":          `{"user_id": "user-1", "from": "2024-07-01", "to": "tomorrow"}`,
+			"reversed range":  `{"user_id": "user-1", "from": "2024-07-02", "to": "2024-07-01"}`,
+		}
+
+		for name, raw := range cases {
+			t.Run(name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/users/addAbsence", bytes.NewReader([]byte(raw)))
+				w := httptest.NewRecorder()
+
+				handler.AddAbsence(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+
+	t.Run("list absences", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1", Absences: []domain.Absence{
+			{AbsenceID: "abs-1", From: from, To: from.Add(24 * time.Hour)},
+		}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/absences?user_id=user-1", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListAbsences(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.AbsencesResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		require.Len(t, response.Absences, 1)
+		assert.Equal(t, "abs-1", response.Absences[0].AbsenceID)
+	})
+
+	t.Run("list absences of user without absences", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/absences?user_id=user-1", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListAbsences(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"absences":[]`)
+	})
+
+	t.Run("list absences errors", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(nil, domain.ErrUserNotFound)
+
+		w := httptest.NewRecorder()
+		handler.ListAbsences(w, httptest.NewRequest(http.MethodGet, "/users/absences?user_id=user-1", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = httptest.NewRecorder()
+		handler.ListAbsences(w, httptest.NewRequest(http.MethodGet, "/users/absences", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("remove absence", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		mockUserRepo.On("RemoveAbsence", mock.Anything, "user-1", "abs-1").Return(nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{UserID: "user-1"}, nil)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "absence_id": "abs-1"})
+		req := httptest.NewRequest(http.MethodPost, "/users/removeAbsence", body)
+		w := httptest.NewRecorder()
+
+		handler.RemoveAbsence(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("remove unknown absence", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		mockUserRepo.On("RemoveAbsence", mock.Anything, "user-1", "abs-1").Return(domain.ErrAbsenceNotFound)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1", "absence_id": "abs-1"})
+		req := httptest.NewRequest(http.MethodPost, "/users/removeAbsence", body)
+		w := httptest.NewRecorder()
+
+		handler.RemoveAbsence(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("remove absence without absence_id", func(t *testing.T) {
+		handler := NewUserHandler(nil, nil, logger)
+
+		body := createJSONBody(t, map[string]any{"user_id": "user-1"})
+		req := httptest.NewRequest(http.MethodPost, "/users/removeAbsence", body)
+		w := httptest.NewRecorder()
+
+		handler.RemoveAbsence(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewUserHandler(nil, nil, logger)
+
+		w := httptest.NewRecorder()
+		handler.AddAbsence(w, httptest.NewRequest(http.MethodGet, "/users/addAbsence", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+		w = httptest.NewRecorder()
+		handler.ListAbsences(w, httptest.NewRequest(http.MethodPost, "/users/absences", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+		w = httptest.NewRecorder()
+		handler.RemoveAbsence(w, httptest.NewRequest(http.MethodGet, "/users/removeAbsence", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestUserHandlerImportAbsences(t *testing.T) {
+	logger := zap.NewNop()
+
+	calendar := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:vacation-1\nDTSTART;VALUE=DATE:20240701\nDTEND;VALUE=DATE:20240715\n" +
+		"ATTENDEE:mailto:alice@example.com\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	t.Run("raw body", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		mockUserRepo.On("GetByEmails", mock.Anything, []string{"alice@example.com"}).
+			Return([]*domain.User{{UserID: "user-1", Email: "alice@example.com"}}, nil)
+		mockUserRepo.On("AddAbsence", mock.Anything, "user-1", mock.MatchedBy(func(a *domain.Absence) bool {
+			return a.Handoff
+		})).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/importAbsences?handoff=true", strings.NewReader(calendar))
+		req.Header.Set("Content-Type", "text/calendar")
+		w := httptest.NewRecorder()
+
+		handler.ImportAbsences(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.ImportAbsencesResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		require.Len(t, response.Results, 1)
+		assert.Equal(t, domain.AbsenceImportCreated, response.Results[0].Outcome)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("multipart upload", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		mockUserRepo.On("GetByEmails", mock.Anything, []string{"alice@example.com"}).Return([]*domain.User{}, nil)
+
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		part, err := form.CreateFormFile("file", "vacations.ics")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(calendar))
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/users/importAbsences", body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		w := httptest.NewRecorder()
+
+		handler.ImportAbsences(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.ImportAbsencesResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		require.Len(t, response.Results, 1)
+		assert.Equal(t, domain.AbsenceImportSkipped, response.Results[0].Outcome)
+	})
+
+	t.Run("multipart without file", func(t *testing.T) {
+		handler := NewUserHandler(service.NewUserService(new(mocks.MockUserRepository), logger), nil, logger)
+
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		require.NoError(t, form.WriteField("handoff", "true"))
+		require.NoError(t, form.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/users/importAbsences", body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		w := httptest.NewRecorder()
+
+		handler.ImportAbsences(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid calendar", func(t *testing.T) {
+		handler := NewUserHandler(service.NewUserService(new(mocks.MockUserRepository), logger), nil, logger)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/importAbsences", strings.NewReader("not a calendar"))
+		w := httptest.NewRecorder()
+
+		handler.ImportAbsences(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), string(domain.ErrorCodeInvalidCalendar))
+	})
+
+	t.Run("invalid handoff", func(t *testing.T) {
+		handler := NewUserHandler(nil, nil, logger)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/importAbsences?handoff=maybe", strings.NewReader(calendar))
+		w := httptest.NewRecorder()
+
+		handler.ImportAbsences(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		mockUserRepo.On("GetByEmails", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodPost, "/users/importAbsences", strings.NewReader(calendar))
+		w := httptest.NewRecorder()
+
+		handler.ImportAbsences(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("wrong HTTP method", func(t *testing.T) {
+		handler := NewUserHandler(nil, nil, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/importAbsences", nil)
+		w := httptest.NewRecorder()
+
+		handler.ImportAbsences(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
 func createJSONBody(t *testing.T, data any) *bytes.Buffer {
 	t.Helper()
 	body := &bytes.Buffer{}
@@ -208,3 +701,47 @@ func createJSONBody(t *testing.T, data any) *bytes.Buffer {
 	}
 	return body
 }
+
+func TestUserHandlerListUsers(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("filters by team and activity", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(service.NewUserService(mockUserRepo, logger), nil, logger)
+
+		active := false
+		mockUserRepo.On("List", mock.Anything, repository.UserListQuery{
+			TeamName: "backend",
+			IsActive: &active,
+			SortBy:   domain.UserSortUsername,
+			Limit:    service.DefaultPageLimit + 1,
+		}).Return([]*domain.User{{UserID: "user-1", Username: "alice", TeamName: "backend"}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users?team_name=backend&is_active=false&sort_by=username", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListUsers(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var page service.UsersPage
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		require.Len(t, page.Users, 1)
+		assert.Equal(t, "user-1", page.Users[0].UserID)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		handler := NewUserHandler(service.NewUserService(new(mocks.MockUserRepository), logger), nil, logger)
+
+		for _, query := range []string{"is_active=maybe", "limit=0.5", "order=up", "cursor=%21"} {
+			req := httptest.NewRequest(http.MethodGet, "/users?"+query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListUsers(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			assert.Contains(t, w.Body.String(), "INVALID_QUERY", query)
+		}
+	})
+}
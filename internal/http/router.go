@@ -1,27 +1,55 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 
+	"assignment-service/internal/config"
 	"assignment-service/internal/http/handlers"
-	"assignment-service/internal/repository/mongodb"
+	"assignment-service/internal/repository"
 	"assignment-service/internal/service"
 
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
 
-func SetupRouter(client *mongodb.Client, logger *zap.Logger) http.Handler {
+// App is the HTTP router together with the background jobs sharing its services
+type App struct {
+	Router http.Handler
+	// AbsenceHandoff is nil when ABSENCE_HANDOFF_INTERVAL is 0
+	AbsenceHandoff *service.AbsenceHandoff
+}
+
+func Setup(repos repository.Repositories, cfg *config.Config, logger *zap.Logger) (*App, error) {
 	// Repos
-	userRepo := mongodb.NewUserRepository(client, logger)
-	teamRepo := mongodb.NewTeamRepository(client, logger)
-	prRepo := mongodb.NewPRRepository(client, logger)
+	userRepo := repos.Users
+	teamRepo := repos.Teams
+	prRepo := repos.PRs
+	prEventRepo := repos.PREvents
+
+	// Reviewer selection
+	selectors := service.NewSelectorRegistry()
+	if err := selectors.Register(service.SelectorLeastLoaded, service.NewLeastLoadedSelector(prRepo)); err != nil {
+		return nil, fmt.Errorf("failed to register reviewer selector: %w", err)
+	}
+	if err := selectors.Configure(cfg.ReviewerStrategy, cfg.TeamReviewerStrategies); err != nil {
+		return nil, fmt.Errorf("failed to configure reviewer selection: %w", err)
+	}
 
 	// Services
-	teamService := service.NewTeamService(teamRepo, userRepo, logger)
+	prService := service.NewPRService(prRepo, userRepo, teamRepo, prEventRepo, repos.UnitOfWork, selectors, service.AssignmentPolicy{
+		OverflowTeam:      cfg.OverflowTeam,
+		ReassignTimeLimit: cfg.ReassignTimeLimit,
+	}, logger)
+	teamService := service.NewTeamService(teamRepo, userRepo, prService, repos.UnitOfWork, logger)
 	userService := service.NewUserService(userRepo, logger)
-	prService := service.NewPRService(prRepo, userRepo, logger)
-	statsService := service.NewStatsService(prRepo, userRepo, logger)
+	statsService := service.NewStatsService(prRepo, userRepo, teamRepo, logger)
+
+	// Jobs
+	var absenceHandoff *service.AbsenceHandoff
+	if cfg.AbsenceHandoffInterval > 0 {
+		absenceHandoff = service.NewAbsenceHandoff(userRepo, prService, cfg.AbsenceHandoffInterval, logger)
+	}
 
 	// Handlers
 	teamHandler := handlers.NewTeamHandler(teamService, logger)
@@ -36,21 +64,49 @@ func SetupRouter(client *mongodb.Client, logger *zap.Logger) http.Handler {
 	// - Teams
 	router.HandleFunc("/team/add", teamHandler.CreateTeam).Methods(http.MethodPost)
 	router.HandleFunc("/team/get", teamHandler.GetTeam).Methods(http.MethodGet)
+	router.HandleFunc("/teams", teamHandler.ListTeams).Methods(http.MethodGet)
+	router.HandleFunc("/team/deactivateUsers", teamHandler.DeactivateUsers).Methods(http.MethodPost)
+	router.HandleFunc("/team/addMember", teamHandler.AddMember).Methods(http.MethodPost)
+	router.HandleFunc("/team/removeMember", teamHandler.RemoveMember).Methods(http.MethodPost)
+	router.HandleFunc("/team/rename", teamHandler.RenameTeam).Methods(http.MethodPost)
+	router.HandleFunc("/team/delete", teamHandler.DeleteTeam).Methods(http.MethodPost)
 
 	// - Users
+	router.HandleFunc("/users", userHandler.ListUsers).Methods(http.MethodGet)
 	router.HandleFunc("/users/setIsActive", userHandler.SetIsActive).Methods(http.MethodPost)
 	router.HandleFunc("/users/getReview", userHandler.GetReview).Methods(http.MethodGet)
+	router.HandleFunc("/users/update", userHandler.UpdateUser).Methods(http.MethodPost)
+	router.HandleFunc("/users/moveTeam", teamHandler.MoveUser).Methods(http.MethodPost)
+	router.HandleFunc("/users/addAbsence", userHandler.AddAbsence).Methods(http.MethodPost)
+	router.HandleFunc("/users/absences", userHandler.ListAbsences).Methods(http.MethodGet)
+	router.HandleFunc("/users/removeAbsence", userHandler.RemoveAbsence).Methods(http.MethodPost)
+	router.HandleFunc("/users/importAbsences", userHandler.ImportAbsences).Methods(http.MethodPost)
 
 	// - PullRequests
+	router.HandleFunc("/pullRequests", prHandler.ListPRs).Methods(http.MethodGet)
 	router.HandleFunc("/pullRequest/create", prHandler.CreatePR).Methods(http.MethodPost)
 	router.HandleFunc("/pullRequest/merge", prHandler.MergePR).Methods(http.MethodPost)
 	router.HandleFunc("/pullRequest/reassign", prHandler.ReassignReviewer).Methods(http.MethodPost)
+	router.HandleFunc("/pullRequest/review", prHandler.SubmitReview).Methods(http.MethodPost)
+	router.HandleFunc("/pullRequest/ready", prHandler.MarkReady).Methods(http.MethodPost)
+	router.HandleFunc("/pullRequest/close", prHandler.ClosePR).Methods(http.MethodPost)
+	router.HandleFunc("/pullRequest/reopen", prHandler.ReopenPR).Methods(http.MethodPost)
+	router.HandleFunc("/pullRequest/history", prHandler.GetHistory).Methods(http.MethodGet)
+	router.HandleFunc("/pullRequest/search", prHandler.SearchPRs).Methods(http.MethodGet)
 
 	// - Health
 	router.HandleFunc("/health", healthHandler.Health).Methods(http.MethodGet)
 
 	// - Stats
 	router.HandleFunc("/stats/user", statsHandler.GetUserStats).Methods(http.MethodGet)
+	router.HandleFunc("/stats/users", statsHandler.GetAllUserStats).Methods(http.MethodGet)
+	router.HandleFunc("/stats/team", statsHandler.GetTeamStats).Methods(http.MethodGet)
+	router.HandleFunc("/stats/timeToMerge", statsHandler.GetMergeTimeStats).Methods(http.MethodGet)
+	router.HandleFunc("/stats/timeToFirstReview", statsHandler.GetFirstReviewStats).Methods(http.MethodGet)
+	router.HandleFunc("/stats/timeToFirstReassignment", statsHandler.GetReassignmentStats).Methods(http.MethodGet)
 
-	return router
+	return &App{
+		Router:         router,
+		AbsenceHandoff: absenceHandoff,
+	}, nil
 }
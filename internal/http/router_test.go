@@ -0,0 +1,85 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"assignment-service/internal/config"
+	"assignment-service/internal/domain"
+	"assignment-service/internal/http/dto"
+	"assignment-service/internal/repository/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestSetupEndToEnd runs the whole router on the memory backend
+func TestSetupEndToEnd(t *testing.T) {
+	app, err := Setup(memory.NewRepositories(), &config.Config{
+		StorageBackend:    config.StorageMemory,
+		ReviewerStrategy:  "round_robin",
+		ReassignTimeLimit: time.Second,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Nil(t, app.AbsenceHandoff)
+
+	call := func(method, target string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		payload := &bytes.Buffer{}
+		if body != nil {
+			require.NoError(t, json.NewEncoder(payload).Encode(body))
+		}
+		w := httptest.NewRecorder()
+		app.Router.ServeHTTP(w, httptest.NewRequest(method, target, payload))
+		return w
+	}
+
+	team := dto.CreateTeamRequest{
+		TeamName: "backend",
+		Members: []dto.TeamMember{
+			{UserID: "u1", Username: "alice", IsActive: true},
+			{UserID: "u2", Username: "bob", IsActive: true},
+			{UserID: "u3", Username: "carol", IsActive: true},
+		},
+	}
+	w := call(http.MethodPost, "/team/add", team)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = call(http.MethodPost, "/team/add", team)
+	assert.Contains(t, w.Body.String(), string(domain.ErrorCodeTeamExists))
+
+	pr := dto.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Add payment retry", AuthorID: "u1"}
+	w = call(http.MethodPost, "/pullRequest/create", pr)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created dto.PRResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	assert.ElementsMatch(t, []string{"u2", "u3"}, created.PR.AssignedReviewers)
+
+	w = call(http.MethodPost, "/pullRequest/create", pr)
+	assert.Contains(t, w.Body.String(), string(domain.ErrorCodePRExists))
+
+	w = call(http.MethodGet, "/users/getReview?user_id=u2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pr-1"`)
+
+	w = call(http.MethodPost, "/pullRequest/merge", dto.MergePRRequest{PullRequestID: "pr-1"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), string(domain.PRStatusMerged))
+
+	w = call(http.MethodGet, "/pullRequest/search?q=payments", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pr-1"`)
+
+	w = call(http.MethodGet, "/team/get?team_name=backend", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got domain.Team
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Len(t, got.Members, 3)
+}
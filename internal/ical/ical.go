@@ -0,0 +1,311 @@
+// Package ical reads events out of iCalendar (RFC 5545) files.
+// Only what is needed to import absences is supported: VEVENT time ranges, attendees and organizer.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// calendars name IANA zones in TZID, the service image has no system zoneinfo
+	_ "time/tzdata"
+)
+
+var ErrNotCalendar = errors.New("not an iCalendar file: BEGIN:VCALENDAR not found")
+
+type Person struct {
+	Email string
+	Name  string
+}
+
+type Event struct {
+	UID       string
+	Summary   string
+	Start     time.Time
+	End       time.Time
+	AllDay    bool
+	Attendees []Person
+	Organizer *Person
+	Cancelled bool
+	Recurring bool
+
+	// Err is set when the event time range could not be read, other fields may be incomplete
+	Err error
+}
+
+// People returns the attendees, or the organizer when the event has no attendees
+func (e *Event) People() []Person {
+	if len(e.Attendees) > 0 || e.Organizer == nil {
+		return e.Attendees
+	}
+	return []Person{*e.Organizer}
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse returns the events of the calendar in file order
+func Parse(r io.Reader) ([]*Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events   []*Event
+		current  *Event
+		raw      map[string]property
+		stack    []string
+		calendar bool
+	)
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		prop, err := parseProperty(line)
+		if err != nil {
+			if current != nil && current.Err == nil {
+				current.Err = err
+			}
+			continue
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			stack = append(stack, component)
+			if component == "VCALENDAR" {
+				calendar = true
+			}
+			if component == "VEVENT" && len(stack) == 2 {
+				current = &Event{}
+				raw = make(map[string]property)
+			}
+			continue
+		case "END":
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			if strings.ToUpper(prop.value) == "VEVENT" && current != nil && len(stack) == 1 {
+				current.finish(raw)
+				events = append(events, current)
+				current = nil
+			}
+			continue
+		}
+
+		// properties of nested components (VALARM) do not describe the event
+		if current == nil || len(stack) != 2 {
+			continue
+		}
+
+		switch prop.name {
+		case "UID":
+			current.UID = prop.value
+		case "SUMMARY":
+			current.Summary = unescapeText(prop.value)
+		case "STATUS":
+			current.Cancelled = strings.EqualFold(prop.value, "CANCELLED")
+		case "RRULE", "RDATE":
+			current.Recurring = true
+		case "ATTENDEE":
+			if p, ok := parsePerson(prop); ok {
+				current.Attendees = append(current.Attendees, p)
+			}
+		case "ORGANIZER":
+			if p, ok := parsePerson(prop); ok {
+				current.Organizer = &p
+			}
+		case "DTSTART", "DTEND", "DURATION":
+			raw[prop.name] = prop
+		}
+	}
+
+	if !calendar {
+		return nil, ErrNotCalendar
+	}
+
+	return events, nil
+}
+
+func (e *Event) finish(raw map[string]property) {
+	if e.Err != nil {
+		return
+	}
+
+	start, ok := raw["DTSTART"]
+	if !ok {
+		e.Err = errors.New("DTSTART is missing")
+		return
+	}
+
+	var err error
+	if e.Start, e.AllDay, err = parseTime(start); err != nil {
+		e.Err = fmt.Errorf("invalid DTSTART: %w", err)
+		return
+	}
+
+	if end, ok := raw["DTEND"]; ok {
+		if e.End, _, err = parseTime(end); err != nil {
+			e.Err = fmt.Errorf("invalid DTEND: %w", err)
+		}
+		return
+	}
+
+	if duration, ok := raw["DURATION"]; ok {
+		d, err := parseDuration(duration.value)
+		if err != nil {
+			e.Err = fmt.Errorf("invalid DURATION: %w", err)
+			return
+		}
+		e.End = e.Start.Add(d)
+		return
+	}
+
+	// RFC 5545 3.6.1: an all-day event without end lasts one day, a timed one has no duration
+	if e.AllDay {
+		e.End = e.Start.AddDate(0, 0, 1)
+	} else {
+		e.End = e.Start
+	}
+}
+
+// unfold joins continuation lines (starting with a space or a tab) to the previous line
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseProperty splits NAME;PARAM=VALUE;PARAM="QUOTED:VALUE":value
+func parseProperty(line string) (property, error) {
+	var parts []string
+	quoted := false
+	start := 0
+	valueAt := -1
+	for i, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == ';' && !quoted:
+			parts = append(parts, line[start:i])
+			start = i + 1
+		case c == ':' && !quoted:
+			parts = append(parts, line[start:i])
+			valueAt = i + 1
+		}
+		if valueAt >= 0 {
+			break
+		}
+	}
+	if valueAt < 0 {
+		return property{}, fmt.Errorf("malformed line %q", line)
+	}
+
+	prop := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts)-1),
+		value:  line[valueAt:],
+	}
+	for _, param := range parts[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+
+	return prop, nil
+}
+
+func parsePerson(prop property) (Person, bool) {
+	email := prop.params["EMAIL"]
+	if email == "" && strings.HasPrefix(strings.ToLower(prop.value), "mailto:") {
+		email = prop.value[len("mailto:"):]
+	}
+	if email == "" {
+		return Person{}, false
+	}
+
+	return Person{Email: email, Name: prop.params["CN"]}, true
+}
+
+// parseTime reads DATE and DATE-TIME values, floating times are taken as UTC
+func parseTime(prop property) (time.Time, bool, error) {
+	value := prop.value
+	if prop.params["VALUE"] == "DATE" || (len(value) == 8 && !strings.Contains(value, "T")) {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	loc := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown TZID %q", tzid)
+		}
+		loc = l
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t.UTC(), false, err
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration reads RFC 5545 durations such as P1W, P2DT4H or PT30M
+func parseDuration(value string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("malformed duration %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("malformed duration %q", value)
+		}
+		d += time.Duration(n) * unit
+	}
+
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n")
+
+func unescapeText(value string) string {
+	return textUnescaper.Replace(value)
+}
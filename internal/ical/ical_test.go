@@ -0,0 +1,144 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const calendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example//Team vacations//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:vacation-1@example.com\r\n" +
+	"SUMMARY:Alice\\, vacation\r\n" +
+	"DTSTART;VALUE=DATE:20240701\r\n" +
+	"DTEND;VALUE=DATE:20240715\r\n" +
+	"ATTENDEE;CN=\"Smith, Alice\";ROLE=REQ-PARTICIPANT:mailto:Alice@Example.com\r\n" +
+	"ATTENDEE;CN=Bob;EMAIL=bob@example.com:urn:uuid:1234\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"SUMMARY:reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:conference-2\r\n" +
+	"SUMMARY:Conference\r\n" +
+	"DTSTART;TZID=Europe/Moscow:20240801T090000\r\n" +
+	"DURATION:P2DT8H\r\n" +
+	"ORGANIZER;CN=Carol:mailto:carol@example.com\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:sick-3\r\n" +
+	"SUMMARY:Sick\r\n" +
+	"  leave\r\n" +
+	"DTSTART:20240901T000000Z\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"RRULE:FREQ=WEEKLY;COUNT=2\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:broken-4\r\n" +
+	"DTSTART:tomorrow\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(calendar))
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	t.Run("all-day event with attendees", func(t *testing.T) {
+		e := events[0]
+		require.NoError(t, e.Err)
+		assert.Equal(t, "vacation-1@example.com", e.UID)
+		assert.Equal(t, "Alice, vacation", e.Summary)
+		assert.True(t, e.AllDay)
+		assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), e.Start)
+		assert.Equal(t, time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), e.End)
+		assert.Equal(t, []Person{
+			{Email: "Alice@Example.com", Name: "Smith, Alice"},
+			{Email: "bob@example.com", Name: "Bob"},
+		}, e.People())
+	})
+
+	t.Run("zoned event with duration and organizer", func(t *testing.T) {
+		e := events[1]
+		require.NoError(t, e.Err)
+		assert.False(t, e.AllDay)
+		assert.Equal(t, time.Date(2024, 8, 1, 6, 0, 0, 0, time.UTC), e.Start)
+		assert.Equal(t, time.Date(2024, 8, 3, 14, 0, 0, 0, time.UTC), e.End)
+		assert.Equal(t, []Person{{Email: "carol@example.com", Name: "Carol"}}, e.People())
+	})
+
+	t.Run("folded, cancelled and recurring event", func(t *testing.T) {
+		e := events[2]
+		require.NoError(t, e.Err)
+		assert.Equal(t, "Sick leave", e.Summary)
+		assert.True(t, e.Cancelled)
+		assert.True(t, e.Recurring)
+		assert.Equal(t, e.Start, e.End)
+		assert.Empty(t, e.People())
+	})
+
+	t.Run("invalid start", func(t *testing.T) {
+		assert.Error(t, events[3].Err)
+		assert.Equal(t, "broken-4", events[3].UID)
+	})
+}
+
+func TestParseNotCalendar(t *testing.T) {
+	_, err := Parse(strings.NewReader("user_id,from,to\nu1,2024-07-01,2024-07-02\n"))
+
+	assert.ErrorIs(t, err, ErrNotCalendar)
+}
+
+func TestParseTimeErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing start": "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:1\nEND:VEVENT\nEND:VCALENDAR\n",
+		"unknown zone":  "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;TZID=Mars/Olympus:20240701T090000\nEND:VEVENT\nEND:VCALENDAR\n",
+		"bad end":       "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240701\nDTEND:soon\nEND:VEVENT\nEND:VCALENDAR\n",
+		"bad duration":  "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240701\nDURATION:P1X\nEND:VEVENT\nEND:VCALENDAR\n",
+		"bad line":      "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240701\nno colon here\nEND:VEVENT\nEND:VCALENDAR\n",
+	}
+
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			events, err := Parse(strings.NewReader(raw))
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Error(t, events[0].Err)
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"P1W", 7 * 24 * time.Hour, false},
+		{"P2DT4H", 52 * time.Hour, false},
+		{"PT30M", 30 * time.Minute, false},
+		{"PT1H0M15S", time.Hour + 15*time.Second, false},
+		{"-P1D", -24 * time.Hour, false},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"1D", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			d, err := parseDuration(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
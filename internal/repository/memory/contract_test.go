@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"assignment-service/internal/repository"
+	"assignment-service/internal/repository/repotest"
+)
+
+func TestContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repositories {
+		return NewRepositories()
+	})
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"assignment-service/internal/domain"
+)
+
+type PREventRepository struct {
+	store *Store
+}
+
+func NewPREventRepository(store *Store) *PREventRepository {
+	return &PREventRepository{
+		store: store,
+	}
+}
+
+func (r *PREventRepository) Append(ctx context.Context, events ...*domain.PREvent) error {
+	defer r.store.lock(ctx)()
+
+	for _, event := range events {
+		r.store.events = append(r.store.events, cloneEvent(event))
+	}
+
+	return nil
+}
+
+func (r *PREventRepository) ListByPR(ctx context.Context, prID string) ([]*domain.PREvent, error) {
+	defer r.store.rlock(ctx)()
+
+	events := []*domain.PREvent{}
+	for _, event := range r.store.events {
+		if event.PullRequestID == prID {
+			events = append(events, cloneEvent(event))
+		}
+	}
+
+	// stable sort keeps the append order for equal timestamps
+	slices.SortStableFunc(events, func(a, b *domain.PREvent) int {
+		return a.At.Compare(b.At)
+	})
+
+	return events, nil
+}
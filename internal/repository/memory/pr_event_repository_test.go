@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPREventRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPREventRepository(NewStore())
+	at := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.Append(ctx,
+		&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerAssigned, At: at.Add(time.Minute)},
+		&domain.PREvent{PullRequestID: "pr-2", Type: domain.PREventCreated, At: at},
+	))
+	require.NoError(t, repo.Append(ctx,
+		&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventCreated, At: at},
+		&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerAssigned, ReviewerID: "u1", At: at},
+	))
+
+	events, err := repo.ListByPR(ctx, "pr-1")
+
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, domain.PREventCreated, events[0].Type)
+	assert.Equal(t, "u1", events[1].ReviewerID)
+	assert.Equal(t, at.Add(time.Minute), events[2].At)
+
+	events, err = repo.ListByPR(ctx, "missing")
+	require.NoError(t, err)
+	assert.NotNil(t, events)
+	assert.Empty(t, events)
+}
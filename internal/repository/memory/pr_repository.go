@@ -0,0 +1,403 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+)
+
+type PRRepository struct {
+	store *Store
+}
+
+func NewPRRepository(store *Store) *PRRepository {
+	return &PRRepository{
+		store: store,
+	}
+}
+
+func (r *PRRepository) Create(ctx context.Context, pr *domain.PullRequest) error {
+	defer r.store.lock(ctx)()
+
+	if _, exists := r.store.prs.get(pr.PullRequestID); exists {
+		return domain.ErrPRExists
+	}
+	r.store.prs.insert(pr.PullRequestID, clonePR(pr))
+
+	return nil
+}
+
+func (r *PRRepository) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	defer r.store.rlock(ctx)()
+
+	pr, ok := r.store.prs.get(prID)
+	if !ok {
+		return nil, domain.ErrPRNotFound
+	}
+
+	return clonePR(pr), nil
+}
+
+// Update replaces a stored PR, a PR that does not exist is ignored as in the mongodb repository
+func (r *PRRepository) Update(ctx context.Context, pr *domain.PullRequest) error {
+	defer r.store.lock(ctx)()
+
+	stored, ok := r.store.prs.get(pr.PullRequestID)
+	if !ok {
+		return nil
+	}
+	*stored = *clonePR(pr)
+
+	return nil
+}
+
+func (r *PRRepository) Exists(ctx context.Context, prID string) (bool, error) {
+	defer r.store.rlock(ctx)()
+
+	_, exists := r.store.prs.get(prID)
+	return exists, nil
+}
+
+func (r *PRRepository) GetByReviewer(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
+	return r.find(ctx, func(pr *domain.PullRequest) bool {
+		return slices.Contains(pr.AssignedReviewers, userID)
+	}), nil
+}
+
+// GetOpenByTeam returns all OPEN PRs, the team is resolved by the caller as in the mongodb repository
+func (r *PRRepository) GetOpenByTeam(ctx context.Context, _ string) ([]*domain.PullRequest, error) {
+	return r.find(ctx, func(pr *domain.PullRequest) bool {
+		return pr.Status == domain.PRStatusOpen
+	}), nil
+}
+
+func (r *PRRepository) List(ctx context.Context, query repository.PRListQuery) ([]*domain.PullRequest, error) {
+	if query.AuthorIDs != nil && len(query.AuthorIDs) == 0 {
+		return []*domain.PullRequest{}, nil
+	}
+
+	// a missing created_at goes before any time, the pull_request_id tie-break is always ascending
+	compare := func(aCreated *time.Time, aID string, bCreated *time.Time, bID string) int {
+		c := 0
+		if query.SortBy != domain.PRSortID {
+			c = compareTimes(aCreated, bCreated)
+		} else {
+			c = cmp.Compare(aID, bID)
+		}
+		if query.Descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return cmp.Compare(aID, bID)
+	}
+
+	prs := orEmpty(r.find(ctx, func(pr *domain.PullRequest) bool {
+		if query.AuthorIDs != nil && !slices.Contains(query.AuthorIDs, pr.AuthorID) {
+			return false
+		}
+		if query.Status != "" && pr.Status != query.Status {
+			return false
+		}
+		if query.ReviewerID != "" && !slices.Contains(pr.AssignedReviewers, query.ReviewerID) {
+			return false
+		}
+		if !inWindow(pr.CreatedAt, query.Created) {
+			return false
+		}
+		return query.AfterPRID == "" ||
+			compare(pr.CreatedAt, pr.PullRequestID, query.AfterCreatedAt, query.AfterPRID) > 0
+	}))
+
+	slices.SortFunc(prs, func(a, b *domain.PullRequest) int {
+		return compare(a.CreatedAt, a.PullRequestID, b.CreatedAt, b.PullRequestID)
+	})
+
+	return limit(prs, query.Limit), nil
+}
+
+func (r *PRRepository) Search(ctx context.Context, query repository.PRSearchQuery) ([]*domain.PRSearchHit, error) {
+	search := parseTextSearch(query.Text)
+
+	// best score first, the pull_request_id tie-break is ascending
+	compare := func(aScore float64, aID string, bScore float64, bID string) int {
+		if c := cmp.Compare(bScore, aScore); c != 0 {
+			return c
+		}
+		return cmp.Compare(aID, bID)
+	}
+
+	runlock := r.store.rlock(ctx)
+	hits := []*domain.PRSearchHit{}
+	for _, pr := range r.store.prs.all() {
+		if query.Status != "" && pr.Status != query.Status {
+			continue
+		}
+		score, ok := search.score(pr.PullRequestName)
+		if !ok {
+			continue
+		}
+		if query.AfterPRID != "" && compare(score, pr.PullRequestID, query.AfterScore, query.AfterPRID) <= 0 {
+			continue
+		}
+		hits = append(hits, &domain.PRSearchHit{
+			PullRequestShort: domain.PullRequestShort{
+				PullRequestID:   pr.PullRequestID,
+				PullRequestName: pr.PullRequestName,
+				AuthorID:        pr.AuthorID,
+				Status:          pr.Status,
+			},
+			Score: score,
+		})
+	}
+	runlock()
+
+	slices.SortFunc(hits, func(a, b *domain.PRSearchHit) int {
+		return compare(a.Score, a.PullRequestID, b.Score, b.PullRequestID)
+	})
+
+	return limit(hits, query.Limit), nil
+}
+
+func (r *PRRepository) GetOpenByReviewers(ctx context.Context, userIDs []string) ([]*domain.PullRequest, error) {
+	if len(userIDs) == 0 {
+		return []*domain.PullRequest{}, nil
+	}
+
+	prs := orEmpty(r.find(ctx, func(pr *domain.PullRequest) bool {
+		return pr.Status == domain.PRStatusOpen && containsAny(pr.AssignedReviewers, userIDs)
+	}))
+	slices.SortFunc(prs, func(a, b *domain.PullRequest) int {
+		return cmp.Compare(a.PullRequestID, b.PullRequestID)
+	})
+
+	return prs, nil
+}
+
+func (r *PRRepository) CountOpenByReviewers(ctx context.Context, userIDs []string) (map[string]int, error) {
+	defer r.store.rlock(ctx)()
+
+	counts := make(map[string]int, len(userIDs))
+	for _, pr := range r.store.prs.all() {
+		if pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		for _, reviewerID := range pr.AssignedReviewers {
+			if slices.Contains(userIDs, reviewerID) {
+				counts[reviewerID]++
+			}
+		}
+	}
+
+	return counts, nil
+}
+
+func (r *PRRepository) ListUserStats(ctx context.Context, query repository.UserStatsQuery) ([]*domain.UserStats, error) {
+	defer r.store.rlock(ctx)()
+
+	// users without assignments come with zero counters,
+	// reviewers who are no longer registered users are dropped
+	stats := []*domain.UserStats{}
+	byUser := make(map[string]*domain.UserStats)
+	for _, user := range r.store.users.all() {
+		if query.TeamName != "" && user.TeamName != query.TeamName {
+			continue
+		}
+		row := &domain.UserStats{UserID: user.UserID, Username: user.Username, TeamName: user.TeamName}
+		stats = append(stats, row)
+		byUser[user.UserID] = row
+	}
+
+	for _, pr := range r.store.prs.all() {
+		// DRAFT and CLOSED PRs have no reviewers
+		if pr.Status != domain.PRStatusOpen && pr.Status != domain.PRStatusMerged {
+			continue
+		}
+		if !inWindow(pr.CreatedAt, query.Created) {
+			continue
+		}
+		for _, reviewerID := range pr.AssignedReviewers {
+			row, ok := byUser[reviewerID]
+			if !ok {
+				continue
+			}
+			row.AssignedCount++
+			if pr.Status == domain.PRStatusOpen {
+				row.OpenPRCount++
+			} else {
+				row.MergedPRCount++
+			}
+		}
+	}
+
+	compare := func(aValue int, aID string, bValue int, bID string) int {
+		c := cmp.Compare(aValue, bValue)
+		if query.Descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return cmp.Compare(aID, bID)
+	}
+
+	if query.AfterUserID != "" {
+		stats = slices.DeleteFunc(stats, func(s *domain.UserStats) bool {
+			return compare(s.SortValue(query.SortBy), s.UserID, query.AfterValue, query.AfterUserID) <= 0
+		})
+	}
+	slices.SortFunc(stats, func(a, b *domain.UserStats) int {
+		return compare(a.SortValue(query.SortBy), a.UserID, b.SortValue(query.SortBy), b.UserID)
+	})
+
+	return limit(stats, query.Limit), nil
+}
+
+func (r *PRRepository) CountByStatus(ctx context.Context, query repository.PRCountQuery) (map[domain.PRStatus]int, error) {
+	defer r.store.rlock(ctx)()
+
+	counts := make(map[domain.PRStatus]int)
+	for _, pr := range r.store.prs.all() {
+		if slices.Contains(query.AuthorIDs, pr.AuthorID) && inWindow(pr.CreatedAt, query.Created) {
+			counts[pr.Status]++
+		}
+	}
+
+	return counts, nil
+}
+
+func (r *PRRepository) ListMergeTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	defer r.store.rlock(ctx)()
+
+	return r.timings(query.TeamName, func(pr *domain.PullRequest) bool {
+		return pr.Status == domain.PRStatusMerged && inWindow(pr.MergedAt, query.Window)
+	}), nil
+}
+
+func (r *PRRepository) ListReassignmentTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	defer r.store.rlock(ctx)()
+
+	timings := r.timings(query.TeamName, func(pr *domain.PullRequest) bool {
+		return inWindow(pr.CreatedAt, query.Window)
+	})
+
+	for _, timing := range timings {
+		timing.FirstReassignedAt = r.firstEventAt(timing.PullRequestID, (*domain.PREvent).IsReassignment)
+	}
+
+	return timings, nil
+}
+
+func (r *PRRepository) ListReviewTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	defer r.store.rlock(ctx)()
+
+	timings := r.timings(query.TeamName, func(pr *domain.PullRequest) bool {
+		return inWindow(pr.CreatedAt, query.Window)
+	})
+
+	for _, timing := range timings {
+		timing.FirstReviewedAt = r.firstEventAt(timing.PullRequestID, func(event *domain.PREvent) bool {
+			return event.Type == domain.PREventReviewSubmitted
+		})
+	}
+
+	return timings, nil
+}
+
+// firstEventAt returns the time of the earliest event of the PR matching the filter, nil if there is none.
+// The caller holds the store lock.
+func (r *PRRepository) firstEventAt(prID string, match func(event *domain.PREvent) bool) *time.Time {
+	var first *time.Time
+	for _, event := range r.store.events {
+		if event.PullRequestID != prID || !match(event) {
+			continue
+		}
+		if first == nil || event.At.Before(*first) {
+			at := event.At
+			first = &at
+		}
+	}
+
+	return first
+}
+
+// timings returns PRs with created_at matching the filter, with the current team of the author.
+// The caller holds the store lock.
+func (r *PRRepository) timings(teamName string, match func(pr *domain.PullRequest) bool) []*domain.PRTiming {
+	timings := []*domain.PRTiming{}
+	for _, pr := range r.store.prs.all() {
+		if pr.CreatedAt == nil || !match(pr) {
+			continue
+		}
+
+		timing := &domain.PRTiming{
+			PullRequestID: pr.PullRequestID,
+			AuthorID:      pr.AuthorID,
+			CreatedAt:     *pr.CreatedAt,
+			MergedAt:      storedTimePtr(pr.MergedAt),
+		}
+		if author, ok := r.store.users.get(pr.AuthorID); ok {
+			timing.TeamName = author.TeamName
+		}
+		if teamName != "" && timing.TeamName != teamName {
+			continue
+		}
+
+		timings = append(timings, timing)
+	}
+
+	return timings
+}
+
+// find returns copies of the PRs matching the filter in insertion order, nil if there are none
+func (r *PRRepository) find(ctx context.Context, match func(pr *domain.PullRequest) bool) []*domain.PullRequest {
+	defer r.store.rlock(ctx)()
+
+	var prs []*domain.PullRequest
+	for _, pr := range r.store.prs.all() {
+		if match(pr) {
+			prs = append(prs, clonePR(pr))
+		}
+	}
+
+	return prs
+}
+
+// inWindow reports whether t is within the window, a missing time only matches an unbounded window
+func inWindow(t *time.Time, window repository.TimeWindow) bool {
+	if window.From == nil && window.To == nil {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	if window.From != nil && t.Before(*window.From) {
+		return false
+	}
+	return window.To == nil || t.Before(*window.To)
+}
+
+// compareTimes orders a missing time before any other one, as MongoDB does
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return a.Compare(*b)
+	}
+}
+
+func containsAny(values, wanted []string) bool {
+	return slices.ContainsFunc(values, func(v string) bool {
+		return slices.Contains(wanted, v)
+	})
+}
@@ -0,0 +1,240 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRRepositoryCreateAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPRRepository(NewStore())
+	created := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	closed := created.Add(time.Hour)
+
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusClosed,
+		AssignedReviewers: []string{},
+		CreatedAt:         &created,
+		ClosedAt:          &closed,
+	}
+	require.NoError(t, repo.Create(ctx, pr))
+	assert.Equal(t, domain.ErrPRExists, repo.Create(ctx, pr))
+
+	t.Run("callers do not share memory with the store", func(t *testing.T) {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, "u2")
+
+		stored, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.NotNil(t, stored.AssignedReviewers)
+		assert.Empty(t, stored.AssignedReviewers)
+	})
+
+	t.Run("update replaces the PR", func(t *testing.T) {
+		require.NoError(t, repo.Update(ctx, &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+			CreatedAt:         &created,
+		}))
+
+		stored, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, stored.Status)
+		assert.Equal(t, []string{"u2"}, stored.AssignedReviewers)
+		assert.Equal(t, created, *stored.CreatedAt)
+		assert.Nil(t, stored.ClosedAt)
+	})
+
+	t.Run("unknown PR", func(t *testing.T) {
+		assert.NoError(t, repo.Update(ctx, &domain.PullRequest{PullRequestID: "missing"}))
+
+		exists, err := repo.Exists(ctx, "missing")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		_, err = repo.GetByID(ctx, "missing")
+		assert.Equal(t, domain.ErrPRNotFound, err)
+	})
+}
+
+func TestPRRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPRRepository(NewStore())
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	next := day.Add(24 * time.Hour)
+
+	for _, pr := range []*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, CreatedAt: &day, AssignedReviewers: []string{"u2"}},
+		{PullRequestID: "pr-2", AuthorID: "u2", Status: domain.PRStatusMerged, CreatedAt: &next},
+		{PullRequestID: "pr-3", AuthorID: "u1", Status: domain.PRStatusOpen},
+		{PullRequestID: "pr-0", AuthorID: "u1", Status: domain.PRStatusOpen, CreatedAt: &day},
+	} {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+
+	ids := func(prs []*domain.PullRequest) []string {
+		result := []string{}
+		for _, pr := range prs {
+			result = append(result, pr.PullRequestID)
+		}
+		return result
+	}
+
+	t.Run("missing created_at goes first ascending and last descending", func(t *testing.T) {
+		asc, err := repo.List(ctx, repository.PRListQuery{SortBy: domain.PRSortCreatedAt})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-3", "pr-0", "pr-1", "pr-2"}, ids(asc))
+
+		desc, err := repo.List(ctx, repository.PRListQuery{SortBy: domain.PRSortCreatedAt, Descending: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-2", "pr-0", "pr-1", "pr-3"}, ids(desc))
+	})
+
+	t.Run("pages continue after position", func(t *testing.T) {
+		prs, err := repo.List(ctx, repository.PRListQuery{
+			SortBy:         domain.PRSortCreatedAt,
+			Descending:     true,
+			AfterCreatedAt: &day,
+			AfterPRID:      "pr-0",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1", "pr-3"}, ids(prs))
+
+		prs, err = repo.List(ctx, repository.PRListQuery{SortBy: domain.PRSortCreatedAt, AfterPRID: "pr-3", Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-0"}, ids(prs))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		prs, err := repo.List(ctx, repository.PRListQuery{
+			AuthorIDs:  []string{"u1"},
+			Status:     domain.PRStatusOpen,
+			ReviewerID: "u2",
+			Created:    repository.TimeWindow{From: &day, To: &next},
+			SortBy:     domain.PRSortID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1"}, ids(prs))
+
+		prs, err = repo.List(ctx, repository.PRListQuery{AuthorIDs: []string{}, SortBy: domain.PRSortID})
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+
+	t.Run("unsorted reads keep insertion order", func(t *testing.T) {
+		prs, err := repo.GetOpenByTeam(ctx, "any")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1", "pr-3", "pr-0"}, ids(prs))
+	})
+}
+
+func TestPRRepositorySearch(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPRRepository(NewStore())
+
+	for _, pr := range []*domain.PullRequest{
+		{PullRequestID: "pr-1", PullRequestName: "Add payment retry with backoff", Status: domain.PRStatusOpen},
+		{PullRequestID: "pr-2", PullRequestName: "Retry failed payments", Status: domain.PRStatusMerged},
+		{PullRequestID: "pr-3", PullRequestName: "Fix typo in README", Status: domain.PRStatusOpen},
+		{PullRequestID: "pr-4", PullRequestName: "Payment page layout", Status: domain.PRStatusOpen},
+	} {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+
+	hits, err := repo.Search(ctx, repository.PRSearchQuery{Text: "payment retry"})
+	require.NoError(t, err)
+	require.Len(t, hits, 3)
+	assert.Equal(t, "pr-2", hits[0].PullRequestID)
+	assert.Equal(t, "pr-1", hits[1].PullRequestID)
+	assert.Equal(t, "pr-4", hits[2].PullRequestID)
+
+	rest, err := repo.Search(ctx, repository.PRSearchQuery{Text: "payment retry", AfterScore: hits[0].Score, AfterPRID: "pr-2", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "pr-1", rest[0].PullRequestID)
+
+	merged, err := repo.Search(ctx, repository.PRSearchQuery{Text: "payment", Status: domain.PRStatusMerged})
+	require.NoError(t, err)
+	require.Len(t, merged, 1)
+	assert.Equal(t, "pr-2", merged[0].PullRequestID)
+}
+
+func TestPRRepositoryStats(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewPRRepository(store)
+	users := NewUserRepository(store)
+	events := NewPREventRepository(store)
+	created := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	merged := created.Add(2 * time.Hour)
+
+	require.NoError(t, users.CreateOrUpdate(ctx, &domain.User{UserID: "u1", Username: "alice", TeamName: "backend"}))
+	require.NoError(t, users.CreateOrUpdate(ctx, &domain.User{UserID: "u2", Username: "bob", TeamName: "backend"}))
+	require.NoError(t, users.CreateOrUpdate(ctx, &domain.User{UserID: "u3", Username: "carol", TeamName: "frontend"}))
+
+	for _, pr := range []*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2", "u3"}, CreatedAt: &created},
+		{PullRequestID: "pr-2", AuthorID: "u1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u2", "gone"}, CreatedAt: &created, MergedAt: &merged},
+		{PullRequestID: "pr-3", AuthorID: "u3", Status: domain.PRStatusClosed, AssignedReviewers: []string{}, CreatedAt: &created},
+	} {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+	require.NoError(t, events.Append(ctx,
+		&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerReplaced, At: created.Add(2 * time.Hour)},
+		&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerReplaced, At: created.Add(time.Hour)},
+		&domain.PREvent{PullRequestID: "pr-2", Type: domain.PREventReviewerAssigned, At: created.Add(time.Hour)},
+	))
+
+	t.Run("open reviews per reviewer", func(t *testing.T) {
+		counts, err := repo.CountOpenByReviewers(ctx, []string{"u2", "u1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"u2": 1}, counts)
+	})
+
+	t.Run("user stats of a team", func(t *testing.T) {
+		stats, err := repo.ListUserStats(ctx, repository.UserStatsQuery{TeamName: "backend", SortBy: domain.UserStatsSortAssigned, Descending: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*domain.UserStats{
+			{UserID: "u2", Username: "bob", TeamName: "backend", AssignedCount: 2, OpenPRCount: 1, MergedPRCount: 1},
+			{UserID: "u1", Username: "alice", TeamName: "backend"},
+		}, stats)
+	})
+
+	t.Run("PRs by status", func(t *testing.T) {
+		counts, err := repo.CountByStatus(ctx, repository.PRCountQuery{AuthorIDs: []string{"u1", "u3"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.PRStatus]int{domain.PRStatusOpen: 1, domain.PRStatusMerged: 1, domain.PRStatusClosed: 1}, counts)
+	})
+
+	t.Run("merge timings", func(t *testing.T) {
+		timings, err := repo.ListMergeTimings(ctx, repository.PRTimingQuery{TeamName: "backend"})
+
+		require.NoError(t, err)
+		require.Len(t, timings, 1)
+		assert.Equal(t, &domain.PRTiming{PullRequestID: "pr-2", AuthorID: "u1", TeamName: "backend", CreatedAt: created, MergedAt: &merged}, timings[0])
+	})
+
+	t.Run("first reassignment", func(t *testing.T) {
+		timings, err := repo.ListReassignmentTimings(ctx, repository.PRTimingQuery{})
+
+		require.NoError(t, err)
+		require.Len(t, timings, 3)
+		require.NotNil(t, timings[0].FirstReassignedAt)
+		assert.Equal(t, created.Add(time.Hour), *timings[0].FirstReassignedAt)
+		assert.Nil(t, timings[1].FirstReassignedAt)
+		assert.Equal(t, "frontend", timings[2].TeamName)
+	})
+}
@@ -0,0 +1,177 @@
+// Package memory keeps the repositories data in process memory.
+// It follows the mongodb repositories semantics and is meant for tests and local runs, data is lost on exit.
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+)
+
+// Store is the data shared by the memory repositories, like collections of one MongoDB database
+type Store struct {
+	mu     sync.RWMutex
+	users  table[domain.User]
+	teams  table[domain.Team]
+	prs    table[domain.PullRequest]
+	events []*domain.PREvent
+}
+
+func NewStore() *Store {
+	return &Store{
+		users: newTable[domain.User](),
+		teams: newTable[domain.Team](),
+		prs:   newTable[domain.PullRequest](),
+	}
+}
+
+// NewRepositories returns repositories sharing a new empty store
+func NewRepositories() repository.Repositories {
+	store := NewStore()
+	return repository.Repositories{
+		Users:      NewUserRepository(store),
+		Teams:      NewTeamRepository(store),
+		PRs:        NewPRRepository(store),
+		PREvents:   NewPREventRepository(store),
+		UnitOfWork: NewUnitOfWork(store),
+	}
+}
+
+// lock takes the write lock, unless ctx belongs to a unit of work of the store that holds it already
+func (s *Store) lock(ctx context.Context) (unlock func()) {
+	if s.inUnitOfWork(ctx) {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// rlock is lock for reads
+func (s *Store) rlock(ctx context.Context) (runlock func()) {
+	if s.inUnitOfWork(ctx) {
+		return func() {}
+	}
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+// table keeps rows by key in insertion order, the order MongoDB returns documents of unsorted queries in
+type table[T any] struct {
+	keys []string
+	rows map[string]*T
+}
+
+func newTable[T any]() table[T] {
+	return table[T]{rows: make(map[string]*T)}
+}
+
+func (t *table[T]) get(key string) (*T, bool) {
+	row, ok := t.rows[key]
+	return row, ok
+}
+
+func (t *table[T]) insert(key string, row *T) {
+	t.keys = append(t.keys, key)
+	t.rows[key] = row
+}
+
+func (t *table[T]) delete(key string) {
+	delete(t.rows, key)
+	t.keys = slices.DeleteFunc(t.keys, func(k string) bool { return k == key })
+}
+
+// rename changes the key of a row keeping its position
+func (t *table[T]) rename(oldKey, newKey string) {
+	t.rows[newKey] = t.rows[oldKey]
+	delete(t.rows, oldKey)
+	t.keys[slices.Index(t.keys, oldKey)] = newKey
+}
+
+// clone copies the table with every row copied by cloneRow
+func (t *table[T]) clone(cloneRow func(*T) *T) table[T] {
+	clone := table[T]{
+		keys: slices.Clone(t.keys),
+		rows: make(map[string]*T, len(t.rows)),
+	}
+	for key, row := range t.rows {
+		clone.rows[key] = cloneRow(row)
+	}
+	return clone
+}
+
+func (t *table[T]) all() []*T {
+	rows := make([]*T, 0, len(t.keys))
+	for _, key := range t.keys {
+		rows = append(rows, t.rows[key])
+	}
+	return rows
+}
+
+// Rows are copied on the way in and out so that callers never share memory with the store.
+// Times are kept the way MongoDB stores them: UTC with millisecond precision.
+
+func storedTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Millisecond)
+}
+
+func storedTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	stored := storedTime(*t)
+	return &stored
+}
+
+func cloneUser(u *domain.User) *domain.User {
+	clone := *u
+	clone.Absences = nil
+	for _, absence := range u.Absences {
+		clone.Absences = append(clone.Absences, cloneAbsence(&absence))
+	}
+	return &clone
+}
+
+func cloneAbsence(a *domain.Absence) domain.Absence {
+	clone := *a
+	clone.From = storedTime(a.From)
+	clone.To = storedTime(a.To)
+	clone.HandedOffAt = storedTimePtr(a.HandedOffAt)
+	return clone
+}
+
+func cloneTeam(t *domain.Team) *domain.Team {
+	clone := *t
+	// members are never stored in the team, they are read from users
+	clone.Members = nil
+	// omitempty: an empty list is not stored and is read back as nil
+	if len(t.FallbackTeams) == 0 {
+		clone.FallbackTeams = nil
+	} else {
+		clone.FallbackTeams = slices.Clone(t.FallbackTeams)
+	}
+	return &clone
+}
+
+func clonePR(pr *domain.PullRequest) *domain.PullRequest {
+	clone := *pr
+	clone.AssignedReviewers = slices.Clone(pr.AssignedReviewers)
+	clone.CreatedAt = storedTimePtr(pr.CreatedAt)
+	clone.MergedAt = storedTimePtr(pr.MergedAt)
+	clone.ClosedAt = storedTimePtr(pr.ClosedAt)
+	clone.Reviews = nil
+	for _, review := range pr.Reviews {
+		review.UpdatedAt = storedTimePtr(review.UpdatedAt)
+		clone.Reviews = append(clone.Reviews, review)
+	}
+	return &clone
+}
+
+func cloneEvent(e *domain.PREvent) *domain.PREvent {
+	clone := *e
+	clone.At = storedTime(e.At)
+	return &clone
+}
@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+)
+
+type TeamRepository struct {
+	store *Store
+}
+
+func NewTeamRepository(store *Store) *TeamRepository {
+	return &TeamRepository{
+		store: store,
+	}
+}
+
+func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	defer r.store.lock(ctx)()
+
+	if _, exists := r.store.teams.get(team.TeamName); exists {
+		return domain.ErrTeamExists
+	}
+	r.store.teams.insert(team.TeamName, cloneTeam(team))
+
+	return nil
+}
+
+func (r *TeamRepository) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	defer r.store.rlock(ctx)()
+
+	team, ok := r.store.teams.get(teamName)
+	if !ok {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	return cloneTeam(team), nil
+}
+
+func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, error) {
+	defer r.store.rlock(ctx)()
+
+	_, exists := r.store.teams.get(teamName)
+	return exists, nil
+}
+
+func (r *TeamRepository) GetWithMembers(ctx context.Context, teamName string) (*domain.Team, error) {
+	defer r.store.rlock(ctx)()
+
+	team, ok := r.store.teams.get(teamName)
+	if !ok {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	return r.withMembers(team), nil
+}
+
+func (r *TeamRepository) List(ctx context.Context, query repository.TeamListQuery) ([]*domain.Team, error) {
+	defer r.store.rlock(ctx)()
+
+	compare := func(a, b string) int {
+		if query.Descending {
+			return cmp.Compare(b, a)
+		}
+		return cmp.Compare(a, b)
+	}
+
+	var page []*domain.Team
+	for _, team := range r.store.teams.all() {
+		if query.AfterTeamName == "" || compare(team.TeamName, query.AfterTeamName) > 0 {
+			page = append(page, team)
+		}
+	}
+	slices.SortFunc(page, func(a, b *domain.Team) int {
+		return compare(a.TeamName, b.TeamName)
+	})
+
+	// members are looked up for the page only
+	teams := []*domain.Team{}
+	for _, team := range limit(page, query.Limit) {
+		teams = append(teams, r.withMembers(team))
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) ListNames(ctx context.Context) ([]string, error) {
+	defer r.store.rlock(ctx)()
+
+	names := slices.Clone(r.store.teams.keys)
+	slices.Sort(names)
+
+	return orEmpty(names), nil
+}
+
+func (r *TeamRepository) Rename(ctx context.Context, oldName, newName string) error {
+	defer r.store.lock(ctx)()
+
+	team, ok := r.store.teams.get(oldName)
+	if !ok {
+		return domain.ErrTeamNotFound
+	}
+	if oldName == newName {
+		return nil
+	}
+	if _, exists := r.store.teams.get(newName); exists {
+		return domain.ErrTeamExists
+	}
+
+	team.TeamName = newName
+	r.store.teams.rename(oldName, newName)
+
+	for _, other := range r.store.teams.all() {
+		// like the positional $ update, only the first mention is renamed
+		if i := slices.Index(other.FallbackTeams, oldName); i >= 0 {
+			other.FallbackTeams[i] = newName
+		}
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, teamName string) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.teams.get(teamName); !ok {
+		return domain.ErrTeamNotFound
+	}
+	r.store.teams.delete(teamName)
+
+	for _, other := range r.store.teams.all() {
+		other.FallbackTeams = slices.DeleteFunc(other.FallbackTeams, func(name string) bool {
+			return name == teamName
+		})
+	}
+
+	return nil
+}
+
+// ListLegacyMembers returns nothing: the memory store has never kept rosters in teams
+func (r *TeamRepository) ListLegacyMembers(ctx context.Context) (map[string][]domain.TeamMember, error) {
+	return map[string][]domain.TeamMember{}, nil
+}
+
+func (r *TeamRepository) DropLegacyMembers(ctx context.Context, teamName string) error {
+	defer r.store.rlock(ctx)()
+
+	if _, ok := r.store.teams.get(teamName); !ok {
+		return domain.ErrTeamNotFound
+	}
+
+	return nil
+}
+
+// withMembers copies the team with Members read from users of the team ordered by user_id.
+// The caller holds the store lock.
+func (r *TeamRepository) withMembers(team *domain.Team) *domain.Team {
+	result := cloneTeam(team)
+	result.Members = []domain.TeamMember{}
+
+	for _, user := range r.store.users.all() {
+		if user.TeamName != team.TeamName {
+			continue
+		}
+		result.Members = append(result.Members, domain.TeamMember{
+			UserID:         user.UserID,
+			Username:       user.Username,
+			IsActive:       user.IsActive,
+			MaxOpenReviews: user.MaxOpenReviews,
+			Email:          user.Email,
+		})
+	}
+	slices.SortFunc(result.Members, func(a, b domain.TeamMember) int {
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+
+	return result
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamRepository(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewTeamRepository(store)
+	users := NewUserRepository(store)
+
+	require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "backend", FallbackTeams: []string{"platform"}}))
+	require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "platform", Members: []domain.TeamMember{{UserID: "ignored"}}}))
+	require.NoError(t, users.CreateOrUpdate(ctx, &domain.User{UserID: "u2", Username: "bob", TeamName: "backend", IsActive: true}))
+	require.NoError(t, users.CreateOrUpdate(ctx, &domain.User{UserID: "u1", Username: "alice", TeamName: "backend"}))
+
+	t.Run("duplicate", func(t *testing.T) {
+		assert.Equal(t, domain.ErrTeamExists, repo.Create(ctx, &domain.Team{TeamName: "backend"}))
+	})
+
+	t.Run("members are read from users", func(t *testing.T) {
+		team, err := repo.GetWithMembers(ctx, "backend")
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TeamMember{
+			{UserID: "u1", Username: "alice"},
+			{UserID: "u2", Username: "bob", IsActive: true},
+		}, team.Members)
+
+		platform, err := repo.GetWithMembers(ctx, "platform")
+		require.NoError(t, err)
+		assert.NotNil(t, platform.Members)
+		assert.Empty(t, platform.Members)
+	})
+
+	t.Run("list pages by name", func(t *testing.T) {
+		teams, err := repo.List(ctx, repository.TeamListQuery{Descending: true, AfterTeamName: "platform"})
+
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, "backend", teams[0].TeamName)
+		assert.Len(t, teams[0].Members, 2)
+	})
+
+	t.Run("rename updates fallback teams", func(t *testing.T) {
+		assert.Equal(t, domain.ErrTeamExists, repo.Rename(ctx, "platform", "backend"))
+		assert.Equal(t, domain.ErrTeamNotFound, repo.Rename(ctx, "missing", "other"))
+		require.NoError(t, repo.Rename(ctx, "platform", "infra"))
+
+		backend, err := repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"infra"}, backend.FallbackTeams)
+
+		names, err := repo.ListNames(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "infra"}, names)
+	})
+
+	t.Run("delete drops fallback mentions", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, "infra"))
+		assert.Equal(t, domain.ErrTeamNotFound, repo.Delete(ctx, "infra"))
+
+		exists, err := repo.Exists(ctx, "infra")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		backend, err := repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Empty(t, backend.FallbackTeams)
+	})
+
+	t.Run("no legacy members", func(t *testing.T) {
+		legacy, err := repo.ListLegacyMembers(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, legacy)
+		assert.NoError(t, repo.DropLegacyMembers(ctx, "backend"))
+		assert.Equal(t, domain.ErrTeamNotFound, repo.DropLegacyMembers(ctx, "missing"))
+	})
+}
@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"strings"
+	"unicode"
+)
+
+// textSearch is a parsed MongoDB $text query: a document matches when it has one of the terms
+// (or no terms are given), all the phrases and none of the excluded terms
+type textSearch struct {
+	terms    []string
+	phrases  []string
+	excluded []string
+}
+
+func parseTextSearch(query string) textSearch {
+	var search textSearch
+
+	// "quoted phrases" are matched as case-insensitive substrings, their words also count as terms
+	parts := strings.Split(query, `"`)
+	for i, part := range parts {
+		if i%2 == 1 {
+			if phrase := strings.ToLower(strings.TrimSpace(part)); phrase != "" {
+				search.phrases = append(search.phrases, phrase)
+				search.terms = append(search.terms, textTokens(phrase)...)
+			}
+			continue
+		}
+
+		for _, word := range strings.Fields(part) {
+			if strings.HasPrefix(word, "-") {
+				search.excluded = append(search.excluded, textTokens(word[1:])...)
+				continue
+			}
+			search.terms = append(search.terms, textTokens(word)...)
+		}
+	}
+
+	return search
+}
+
+// score returns the relevance of text the way MongoDB computes textScore for a single field of weight 1
+func (s textSearch) score(text string) (float64, bool) {
+	if len(s.terms) == 0 && len(s.phrases) == 0 {
+		return 0, false
+	}
+
+	lower := strings.ToLower(text)
+	for _, phrase := range s.phrases {
+		if !strings.Contains(lower, phrase) {
+			return 0, false
+		}
+	}
+
+	tokens := textTokens(text)
+	type termStats struct {
+		count int
+		freq  float64
+		exp   float64
+	}
+	stats := make(map[string]*termStats)
+	for _, token := range tokens {
+		st, ok := stats[token]
+		if !ok {
+			st = &termStats{exp: 1}
+			stats[token] = st
+		} else {
+			st.exp *= 2
+		}
+		// repeated words add less and less
+		st.freq += 1 / st.exp
+		st.count++
+	}
+
+	for _, term := range s.excluded {
+		if _, found := stats[term]; found {
+			return 0, false
+		}
+	}
+
+	score := 0.0
+	seen := make(map[string]bool)
+	for _, term := range s.terms {
+		st, found := stats[term]
+		if !found || seen[term] {
+			continue
+		}
+		seen[term] = true
+		score += st.freq * (0.5*float64(st.count)/float64(len(tokens)) + 0.5)
+	}
+
+	if score == 0 && len(s.phrases) == 0 {
+		return 0, false
+	}
+	return score, true
+}
+
+// textTokens splits text into lower-cased stemmed words without stop words
+func textTokens(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.TrimSuffix(strings.Trim(word, "'"), "'s")
+		if word == "" || englishStopWords[word] {
+			continue
+		}
+		tokens = append(tokens, stem(word))
+	}
+	return tokens
+}
+
+// stem applies the plural, -ed/-ing and final -e steps of the Porter stemmer, enough to match word forms in PR names
+func stem(word string) string {
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		word = strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ies"):
+		word = strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"):
+	case strings.HasSuffix(word, "s") && len(word) > 2:
+		word = strings.TrimSuffix(word, "s")
+	}
+
+	switch {
+	case strings.HasSuffix(word, "eed"):
+		if len(word) > 4 {
+			word = strings.TrimSuffix(word, "d")
+		}
+	case strings.HasSuffix(word, "ed") && hasVowel(strings.TrimSuffix(word, "ed")):
+		word = restoreStem(strings.TrimSuffix(word, "ed"))
+	case strings.HasSuffix(word, "ing") && hasVowel(strings.TrimSuffix(word, "ing")):
+		word = restoreStem(strings.TrimSuffix(word, "ing"))
+	}
+
+	if strings.HasSuffix(word, "y") && hasVowel(strings.TrimSuffix(word, "y")) {
+		word = strings.TrimSuffix(word, "y") + "i"
+	}
+	if strings.HasSuffix(word, "e") && len(word) > 3 {
+		word = strings.TrimSuffix(word, "e")
+	}
+
+	return word
+}
+
+// restoreStem fixes a stem left by removing -ed or -ing: "hopp" becomes "hop", "creat" becomes "create"
+func restoreStem(stem string) string {
+	switch {
+	case strings.HasSuffix(stem, "at"), strings.HasSuffix(stem, "bl"), strings.HasSuffix(stem, "iz"):
+		return stem + "e"
+	case len(stem) > 1 && stem[len(stem)-1] == stem[len(stem)-2] && !strings.ContainsAny(stem[len(stem)-1:], "aeioulsz"):
+		return stem[:len(stem)-1]
+	default:
+		return stem
+	}
+}
+
+func hasVowel(s string) bool {
+	return strings.ContainsAny(s, "aeiouy")
+}
+
+var englishStopWords = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`a about above after again against all am an and any are as at be because been
+		before being below between both but by can did do does doing down during each few for from further had has have
+		having he her here hers herself him himself his how i if in into is it its itself just me more most my myself no
+		nor not now of off on once only or other our ours ourselves out over own same she should so some such than that
+		the their theirs them themselves then there these they this those through to too under until up very was we were
+		what when where which while who whom why will with you your yours yourself yourselves`) {
+		englishStopWords[word] = true
+	}
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextTokens(t *testing.T) {
+	assert.Equal(t, []string{"retri", "fail", "payment"}, textTokens("Retrying the failed payments"))
+	assert.Equal(t, []string{"retri", "payment", "webhook"}, textTokens("retries: payment's webhooks"))
+	assert.Equal(t, []string{"fix", "creat", "hop"}, textTokens("fixes created hopping"))
+}
+
+func TestTextSearchScore(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		text  string
+		match bool
+	}{
+		{"any term", "payment kubernetes", "Payment page layout", true},
+		{"no term", "kubernetes", "Payment page layout", false},
+		{"word forms", "retried payments", "Retry payment", true},
+		{"stop words only", "the", "The payment", false},
+		{"phrase", `"page layout"`, "Payment page layout", true},
+		{"missing phrase", `payment "layout page"`, "Payment page layout", false},
+		{"excluded", "payment -layout", "Payment page layout", false},
+		{"excluded only", "-layout", "Payment retry", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ok := parseTextSearch(tc.query).score(tc.text)
+			assert.Equal(t, tc.match, ok)
+		})
+	}
+
+	t.Run("more matched words rank higher", func(t *testing.T) {
+		search := parseTextSearch("payment retry")
+
+		both, _ := search.score("Add payment retry with backoff")
+		one, _ := search.score("Payment page layout")
+
+		assert.Greater(t, both, one)
+	})
+
+	t.Run("shorter text ranks higher", func(t *testing.T) {
+		search := parseTextSearch("payment")
+
+		short, _ := search.score("Payment")
+		long, _ := search.score("Payment page layout")
+
+		assert.InDelta(t, 1.0, short, 1e-9)
+		assert.Greater(t, short, long)
+	})
+}
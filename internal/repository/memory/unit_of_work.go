@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+
+	"assignment-service/internal/domain"
+)
+
+type unitOfWorkKey struct{}
+
+// UnitOfWork holds the store lock while the work runs, so no other call sees its writes half done.
+// The store data is copied when the work starts and put back if the work fails.
+type UnitOfWork struct {
+	store *Store
+}
+
+func NewUnitOfWork(store *Store) *UnitOfWork {
+	return &UnitOfWork{
+		store: store,
+	}
+}
+
+// Do runs fn holding the store lock, a Do within fn puts back only its own writes on failure
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !u.store.inUnitOfWork(ctx) {
+		u.store.mu.Lock()
+		defer u.store.mu.Unlock()
+		ctx = context.WithValue(ctx, unitOfWorkKey{}, u.store)
+	}
+
+	saved := u.store.snapshot()
+	if err := fn(ctx); err != nil {
+		u.store.restore(saved)
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) inUnitOfWork(ctx context.Context) bool {
+	store, _ := ctx.Value(unitOfWorkKey{}).(*Store)
+	return store == s
+}
+
+// snapshot is a copy of the store data, events are only ever appended so their count is enough
+type snapshot struct {
+	users  table[domain.User]
+	teams  table[domain.Team]
+	prs    table[domain.PullRequest]
+	events int
+}
+
+func (s *Store) snapshot() snapshot {
+	return snapshot{
+		users:  s.users.clone(cloneUser),
+		teams:  s.teams.clone(cloneTeam),
+		prs:    s.prs.clone(clonePR),
+		events: len(s.events),
+	}
+}
+
+func (s *Store) restore(saved snapshot) {
+	s.users = saved.users
+	s.teams = saved.teams
+	s.prs = saved.prs
+	s.events = s.events[:saved.events]
+}
@@ -0,0 +1,311 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+)
+
+type UserRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) *UserRepository {
+	return &UserRepository{
+		store: store,
+	}
+}
+
+func (r *UserRepository) CreateOrUpdate(ctx context.Context, user *domain.User) error {
+	defer r.store.lock(ctx)()
+
+	stored, ok := r.store.users.get(user.UserID)
+	if !ok {
+		r.store.users.insert(user.UserID, cloneUser(user))
+		return nil
+	}
+
+	// the mongodb repository $sets the user: empty omitempty fields are not sent and keep the stored values
+	updated := cloneUser(user)
+	if updated.Email == "" {
+		updated.Email = stored.Email
+	}
+	if updated.Absences == nil {
+		updated.Absences = stored.Absences
+	}
+	*stored = *updated
+
+	return nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	defer r.store.rlock(ctx)()
+
+	user, ok := r.store.users.get(userID)
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return cloneUser(user), nil
+}
+
+func (r *UserRepository) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	if len(userIDs) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	return orEmpty(r.find(ctx, func(u *domain.User) bool {
+		return slices.Contains(userIDs, u.UserID)
+	})), nil
+}
+
+func (r *UserRepository) GetActiveByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
+	return r.find(ctx, func(u *domain.User) bool {
+		return u.TeamName == teamName && u.IsActive
+	}), nil
+}
+
+func (r *UserRepository) UpdateIsActive(ctx context.Context, userID string, isActive bool) error {
+	defer r.store.lock(ctx)()
+
+	user, ok := r.store.users.get(userID)
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+	user.IsActive = isActive
+
+	return nil
+}
+
+func (r *UserRepository) UpdateIsActiveMany(ctx context.Context, userIDs []string, isActive bool) error {
+	defer r.store.lock(ctx)()
+
+	for _, userID := range userIDs {
+		if user, ok := r.store.users.get(userID); ok {
+			user.IsActive = isActive
+		}
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
+	return r.find(ctx, func(u *domain.User) bool {
+		return u.TeamName == teamName
+	}), nil
+}
+
+func (r *UserRepository) List(ctx context.Context, query repository.UserListQuery) ([]*domain.User, error) {
+	value := func(u *domain.User) string {
+		if query.SortBy == domain.UserSortUsername {
+			return u.Username
+		}
+		return u.UserID
+	}
+	// the user_id tie-break is always ascending
+	compare := func(aValue, aID, bValue, bID string) int {
+		c := cmp.Compare(aValue, bValue)
+		if query.Descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return cmp.Compare(aID, bID)
+	}
+
+	users := orEmpty(r.find(ctx, func(u *domain.User) bool {
+		if query.TeamName != "" && u.TeamName != query.TeamName {
+			return false
+		}
+		if query.IsActive != nil && u.IsActive != *query.IsActive {
+			return false
+		}
+		return query.AfterUserID == "" || compare(value(u), u.UserID, query.AfterValue, query.AfterUserID) > 0
+	}))
+
+	slices.SortFunc(users, func(a, b *domain.User) int {
+		return compare(value(a), a.UserID, value(b), b.UserID)
+	})
+
+	return limit(users, query.Limit), nil
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, userID string, update repository.UserProfileUpdate) error {
+	defer r.store.lock(ctx)()
+
+	user, ok := r.store.users.get(userID)
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+	if update.Username != nil {
+		user.Username = *update.Username
+	}
+	if update.Email != nil {
+		user.Email = *update.Email
+	}
+	if update.MaxOpenReviews != nil {
+		user.MaxOpenReviews = *update.MaxOpenReviews
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateTeam(ctx context.Context, userID, teamName string) error {
+	defer r.store.lock(ctx)()
+
+	user, ok := r.store.users.get(userID)
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+	user.TeamName = teamName
+
+	return nil
+}
+
+func (r *UserRepository) RenameTeam(ctx context.Context, oldName, newName string) error {
+	defer r.store.lock(ctx)()
+
+	for _, user := range r.store.users.all() {
+		if user.TeamName == oldName {
+			user.TeamName = newName
+		}
+	}
+
+	return nil
+}
+
+func (r *UserRepository) ListTeamNames(ctx context.Context) ([]string, error) {
+	defer r.store.rlock(ctx)()
+
+	names := []string{}
+	for _, user := range r.store.users.all() {
+		if user.TeamName != "" && !slices.Contains(names, user.TeamName) {
+			names = append(names, user.TeamName)
+		}
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+func (r *UserRepository) GetByEmails(ctx context.Context, emails []string) ([]*domain.User, error) {
+	if len(emails) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	return orEmpty(r.find(ctx, func(u *domain.User) bool {
+		return u.Email != "" && slices.Contains(emails, u.Email)
+	})), nil
+}
+
+func (r *UserRepository) AddAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	defer r.store.lock(ctx)()
+
+	user, ok := r.store.users.get(userID)
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+	user.Absences = append(user.Absences, cloneAbsence(absence))
+
+	return nil
+}
+
+func (r *UserRepository) RemoveAbsence(ctx context.Context, userID, absenceID string) error {
+	defer r.store.lock(ctx)()
+
+	user, ok := r.store.users.get(userID)
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(user.Absences), func(a domain.Absence) bool {
+		return a.AbsenceID == absenceID
+	})
+	if len(remaining) == len(user.Absences) {
+		return domain.ErrAbsenceNotFound
+	}
+	user.Absences = remaining
+
+	return nil
+}
+
+func (r *UserRepository) ListAbsenceHandoffs(ctx context.Context, at time.Time) ([]*domain.User, error) {
+	return r.find(ctx, func(u *domain.User) bool {
+		return slices.ContainsFunc(u.Absences, func(a domain.Absence) bool {
+			return a.HandoffDue(at)
+		})
+	}), nil
+}
+
+func (r *UserRepository) UpdateAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	defer r.store.lock(ctx)()
+
+	user, ok := r.store.users.get(userID)
+	if !ok {
+		return domain.ErrAbsenceNotFound
+	}
+
+	i := slices.IndexFunc(user.Absences, func(a domain.Absence) bool {
+		return a.AbsenceID == absence.AbsenceID
+	})
+	if i < 0 {
+		return domain.ErrAbsenceNotFound
+	}
+	user.Absences[i] = cloneAbsence(absence)
+
+	return nil
+}
+
+func (r *UserRepository) MarkAbsenceHandedOff(ctx context.Context, userID, absenceID string, at time.Time) error {
+	defer r.store.lock(ctx)()
+
+	user, ok := r.store.users.get(userID)
+	if !ok {
+		return domain.ErrAbsenceNotFound
+	}
+
+	i := slices.IndexFunc(user.Absences, func(a domain.Absence) bool {
+		return a.AbsenceID == absenceID
+	})
+	if i < 0 {
+		return domain.ErrAbsenceNotFound
+	}
+	user.Absences[i].HandedOffAt = storedTimePtr(&at)
+
+	return nil
+}
+
+// find returns copies of the users matching the filter in insertion order, nil if there are none
+func (r *UserRepository) find(ctx context.Context, match func(u *domain.User) bool) []*domain.User {
+	defer r.store.rlock(ctx)()
+
+	var users []*domain.User
+	for _, user := range r.store.users.all() {
+		if match(user) {
+			users = append(users, cloneUser(user))
+		}
+	}
+
+	return users
+}
+
+// orEmpty replaces nil with an empty slice, for methods whose mongodb version never returns nil
+func orEmpty[T any](rows []T) []T {
+	if rows == nil {
+		return []T{}
+	}
+	return rows
+}
+
+// limit keeps the first n rows, 0 means no limit
+func limit[T any](rows []T, n int) []T {
+	if n > 0 && len(rows) > n {
+		return rows[:n]
+	}
+	return rows
+}
@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepositoryCreateOrUpdate(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository(NewStore())
+	from := time.Date(2024, 7, 1, 9, 30, 0, 123456789, time.FixedZone("MSK", 3*60*60))
+
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{
+		UserID:   "user-1",
+		Username: "alice",
+		TeamName: "backend",
+		IsActive: true,
+		Email:    "alice@example.com",
+	}))
+	require.NoError(t, repo.AddAbsence(ctx, "user-1", &domain.Absence{AbsenceID: "abs-1", From: from, To: from.Add(time.Hour)}))
+
+	t.Run("update keeps omitted email and absences", func(t *testing.T) {
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", Username: "alice2", TeamName: "backend"}))
+
+		user, err := repo.GetByID(ctx, "user-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "alice2", user.Username)
+		assert.False(t, user.IsActive)
+		assert.Equal(t, "alice@example.com", user.Email)
+		require.Len(t, user.Absences, 1)
+		assert.Equal(t, time.Date(2024, 7, 1, 6, 30, 0, 123000000, time.UTC), user.Absences[0].From)
+	})
+
+	t.Run("callers do not share memory with the store", func(t *testing.T) {
+		user, err := repo.GetByID(ctx, "user-1")
+		require.NoError(t, err)
+
+		user.Username = "changed"
+		user.Absences[0].Reason = "changed"
+
+		stored, err := repo.GetByID(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, "alice2", stored.Username)
+		assert.Empty(t, stored.Absences[0].Reason)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		user, err := repo.GetByID(ctx, "missing")
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+		assert.Nil(t, user)
+		assert.Equal(t, domain.ErrUserNotFound, repo.UpdateIsActive(ctx, "missing", true))
+		assert.Equal(t, domain.ErrUserNotFound, repo.UpdateTeam(ctx, "missing", "backend"))
+	})
+}
+
+func TestUserRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository(NewStore())
+
+	for _, u := range []*domain.User{
+		{UserID: "u3", Username: "bob", TeamName: "backend", IsActive: true},
+		{UserID: "u1", Username: "carol", TeamName: "backend", IsActive: false},
+		{UserID: "u2", Username: "bob", TeamName: "frontend", IsActive: true},
+	} {
+		require.NoError(t, repo.CreateOrUpdate(ctx, u))
+	}
+
+	ids := func(users []*domain.User) []string {
+		result := []string{}
+		for _, u := range users {
+			result = append(result, u.UserID)
+		}
+		return result
+	}
+
+	t.Run("unsorted reads keep insertion order", func(t *testing.T) {
+		users, err := repo.GetByTeam(ctx, "backend")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3", "u1"}, ids(users))
+	})
+
+	t.Run("username desc with user_id tie-break", func(t *testing.T) {
+		users, err := repo.List(ctx, repository.UserListQuery{SortBy: domain.UserSortUsername, Descending: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u2", "u3"}, ids(users))
+	})
+
+	t.Run("page after position", func(t *testing.T) {
+		users, err := repo.List(ctx, repository.UserListQuery{
+			SortBy:      domain.UserSortUsername,
+			AfterValue:  "bob",
+			AfterUserID: "u2",
+			Limit:       1,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, ids(users))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		active := true
+		users, err := repo.List(ctx, repository.UserListQuery{TeamName: "backend", IsActive: &active, SortBy: domain.UserSortID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, ids(users))
+	})
+}
+
+func TestUserRepositoryAbsences(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository(NewStore())
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1"}))
+	require.NoError(t, repo.AddAbsence(ctx, "user-1", &domain.Absence{AbsenceID: "abs-1", From: now.Add(-time.Hour), To: now.Add(time.Hour), Handoff: true}))
+
+	users, err := repo.ListAbsenceHandoffs(ctx, now)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	require.NoError(t, repo.MarkAbsenceHandedOff(ctx, "user-1", "abs-1", now))
+	assert.Equal(t, domain.ErrAbsenceNotFound, repo.MarkAbsenceHandedOff(ctx, "user-1", "abs-2", now))
+	assert.Equal(t, domain.ErrAbsenceNotFound, repo.MarkAbsenceHandedOff(ctx, "missing", "abs-1", now))
+
+	users, err = repo.ListAbsenceHandoffs(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	assert.Equal(t, domain.ErrAbsenceNotFound, repo.RemoveAbsence(ctx, "user-1", "abs-2"))
+	assert.Equal(t, domain.ErrUserNotFound, repo.RemoveAbsence(ctx, "missing", "abs-1"))
+	require.NoError(t, repo.RemoveAbsence(ctx, "user-1", "abs-1"))
+
+	user, err := repo.GetByID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, user.Absences)
+}
+
+func TestUserRepositoryConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository(NewStore())
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1"}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, repo.AddAbsence(ctx, "user-1", &domain.Absence{AbsenceID: "abs"}))
+			_, err := repo.GetByID(ctx, "user-1")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	user, err := repo.GetByID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, user.Absences, 50)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"assignment-service/internal/domain"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPREventRepository struct {
+	mock.Mock
+}
+
+func (m *MockPREventRepository) Append(ctx context.Context, events ...*domain.PREvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockPREventRepository) ListByPR(ctx context.Context, prID string) ([]*domain.PREvent, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PREvent), args.Error(1)
+}
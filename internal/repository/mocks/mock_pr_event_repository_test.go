@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockPREventRepositoryAppend(t *testing.T) {
+	mockRepo := new(MockPREventRepository)
+	ctx := context.Background()
+
+	events := []*domain.PREvent{
+		{PullRequestID: "pr-1", Type: domain.PREventCreated, At: time.Now()},
+		{PullRequestID: "pr-1", Type: domain.PREventReviewerAssigned, ReviewerID: "user-2", At: time.Now()},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("Append", ctx, events).Return(nil).Once()
+
+		err := mockRepo.Append(ctx, events...)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expectedErr := errors.New("insert failed")
+		mockRepo.On("Append", ctx, events).Return(expectedErr).Once()
+
+		err := mockRepo.Append(ctx, events...)
+
+		assert.ErrorIs(t, err, expectedErr)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockPREventRepositoryListByPR(t *testing.T) {
+	mockRepo := new(MockPREventRepository)
+	ctx := context.Background()
+
+	events := []*domain.PREvent{
+		{PullRequestID: "pr-1", Type: domain.PREventCreated, At: time.Now()},
+	}
+
+	t.Run("returns events", func(t *testing.T) {
+		mockRepo.On("ListByPR", ctx, "pr-1").Return(events, nil).Once()
+
+		result, err := mockRepo.ListByPR(ctx, "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, events, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockRepo.On("ListByPR", ctx, "pr-1").Return(nil, errors.New("db down")).Once()
+
+		result, err := mockRepo.ListByPR(ctx, "pr-1")
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -50,3 +51,75 @@ func (m *MockPRRepository) GetOpenByTeam(ctx context.Context, teamName string) (
 	}
 	return args.Get(0).([]*domain.PullRequest), args.Error(1)
 }
+
+func (m *MockPRRepository) ListUserStats(ctx context.Context, query repository.UserStatsQuery) ([]*domain.UserStats, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.UserStats), args.Error(1)
+}
+
+func (m *MockPRRepository) List(ctx context.Context, query repository.PRListQuery) ([]*domain.PullRequest, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRRepository) Search(ctx context.Context, query repository.PRSearchQuery) ([]*domain.PRSearchHit, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PRSearchHit), args.Error(1)
+}
+
+func (m *MockPRRepository) GetOpenByReviewers(ctx context.Context, userIDs []string) ([]*domain.PullRequest, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PullRequest), args.Error(1)
+}
+
+func (m *MockPRRepository) CountByStatus(ctx context.Context, query repository.PRCountQuery) (map[domain.PRStatus]int, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[domain.PRStatus]int), args.Error(1)
+}
+
+func (m *MockPRRepository) ListMergeTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PRTiming), args.Error(1)
+}
+
+func (m *MockPRRepository) ListReassignmentTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PRTiming), args.Error(1)
+}
+
+func (m *MockPRRepository) ListReviewTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PRTiming), args.Error(1)
+}
+
+func (m *MockPRRepository) CountOpenByReviewers(ctx context.Context, userIDs []string) (map[string]int, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -272,3 +273,245 @@ func TestMockPRRepositoryGetOpenByTeam(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestMockPRRepositoryGetOpenByReviewers(t *testing.T) {
+	mockRepo := new(MockPRRepository)
+	ctx := context.Background()
+	userIDs := []string{"user-1", "user-2"}
+
+	t.Run("returns PRs", func(t *testing.T) {
+		expected := []*domain.PullRequest{{PullRequestID: "pr-1", Status: domain.PRStatusOpen}}
+		mockRepo.On("GetOpenByReviewers", ctx, userIDs).Return(expected, nil).Once()
+
+		prs, err := mockRepo.GetOpenByReviewers(ctx, userIDs)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, prs)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nil slice with error - covers nil branch", func(t *testing.T) {
+		mockRepo.On("GetOpenByReviewers", ctx, userIDs).Return(nil, errors.New("find failed")).Once()
+
+		prs, err := mockRepo.GetOpenByReviewers(ctx, userIDs)
+
+		assert.Nil(t, prs)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockPRRepositoryCountOpenByReviewers(t *testing.T) {
+	mockRepo := new(MockPRRepository)
+	ctx := context.Background()
+	userIDs := []string{"user-1", "user-2"}
+
+	t.Run("returns counts", func(t *testing.T) {
+		mockRepo.On("CountOpenByReviewers", ctx, userIDs).Return(map[string]int{"user-1": 3}, nil).Once()
+
+		counts, err := mockRepo.CountOpenByReviewers(ctx, userIDs)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, counts["user-1"])
+		assert.Zero(t, counts["user-2"])
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nil map with error - covers nil branch", func(t *testing.T) {
+		mockRepo.On("CountOpenByReviewers", ctx, userIDs).Return(nil, errors.New("aggregation failed")).Once()
+
+		counts, err := mockRepo.CountOpenByReviewers(ctx, userIDs)
+
+		assert.Nil(t, counts)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockPRRepositoryListUserStats(t *testing.T) {
+	mockRepo := new(MockPRRepository)
+	ctx := context.Background()
+	query := repository.UserStatsQuery{SortBy: domain.UserStatsSortAssigned, Limit: 10}
+
+	t.Run("returns stats", func(t *testing.T) {
+		expected := []*domain.UserStats{{UserID: "user-1", AssignedCount: 2}}
+		mockRepo.On("ListUserStats", ctx, query).Return(expected, nil).Once()
+
+		stats, err := mockRepo.ListUserStats(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, stats)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nil slice with error - covers nil branch", func(t *testing.T) {
+		mockRepo.On("ListUserStats", ctx, query).Return(nil, errors.New("aggregation failed")).Once()
+
+		stats, err := mockRepo.ListUserStats(ctx, query)
+
+		assert.Nil(t, stats)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockPRRepositoryCountByStatus(t *testing.T) {
+	mockRepo := new(MockPRRepository)
+	ctx := context.Background()
+	query := repository.PRCountQuery{AuthorIDs: []string{"user-1"}}
+
+	t.Run("returns counts", func(t *testing.T) {
+		mockRepo.On("CountByStatus", ctx, query).Return(map[domain.PRStatus]int{domain.PRStatusOpen: 2}, nil).Once()
+
+		counts, err := mockRepo.CountByStatus(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, counts[domain.PRStatusOpen])
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nil map with error - covers nil branch", func(t *testing.T) {
+		mockRepo.On("CountByStatus", ctx, query).Return(nil, errors.New("aggregation failed")).Once()
+
+		counts, err := mockRepo.CountByStatus(ctx, query)
+
+		assert.Nil(t, counts)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockPRRepositoryListMergeTimings(t *testing.T) {
+	mockRepo := new(MockPRRepository)
+	ctx := context.Background()
+	query := repository.PRTimingQuery{TeamName: "backend"}
+
+	t.Run("returns timings", func(t *testing.T) {
+		expected := []*domain.PRTiming{{PullRequestID: "pr-1", AuthorID: "user-1"}}
+		mockRepo.On("ListMergeTimings", ctx, query).Return(expected, nil).Once()
+
+		timings, err := mockRepo.ListMergeTimings(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, timings)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nil slice with error - covers nil branch", func(t *testing.T) {
+		mockRepo.On("ListMergeTimings", ctx, query).Return(nil, errors.New("aggregation failed")).Once()
+
+		timings, err := mockRepo.ListMergeTimings(ctx, query)
+
+		assert.Nil(t, timings)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockPRRepositoryListReassignmentTimings(t *testing.T) {
+	mockRepo := new(MockPRRepository)
+	ctx := context.Background()
+	query := repository.PRTimingQuery{TeamName: "backend"}
+
+	t.Run("returns timings", func(t *testing.T) {
+		expected := []*domain.PRTiming{{PullRequestID: "pr-1", AuthorID: "user-1"}}
+		mockRepo.On("ListReassignmentTimings", ctx, query).Return(expected, nil).Once()
+
+		timings, err := mockRepo.ListReassignmentTimings(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, timings)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nil slice with error - covers nil branch", func(t *testing.T) {
+		mockRepo.On("ListReassignmentTimings", ctx, query).Return(nil, errors.New("aggregation failed")).Once()
+
+		timings, err := mockRepo.ListReassignmentTimings(ctx, query)
+
+		assert.Nil(t, timings)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockPRRepositoryListReviewTimings(t *testing.T) {
+	mockRepo := new(MockPRRepository)
+	ctx := context.Background()
+	query := repository.PRTimingQuery{TeamName: "backend"}
+
+	t.Run("returns timings", func(t *testing.T) {
+		expected := []*domain.PRTiming{{PullRequestID: "pr-1", AuthorID: "user-1"}}
+		mockRepo.On("ListReviewTimings", ctx, query).Return(expected, nil).Once()
+
+		timings, err := mockRepo.ListReviewTimings(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, timings)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nil slice with error - covers nil branch", func(t *testing.T) {
+		mockRepo.On("ListReviewTimings", ctx, query).Return(nil, errors.New("aggregation failed")).Once()
+
+		timings, err := mockRepo.ListReviewTimings(ctx, query)
+
+		assert.Nil(t, timings)
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockPRRepositoryList(t *testing.T) {
+	mockRepo := new(MockPRRepository)
+	ctx := context.Background()
+	query := repository.PRListQuery{Status: domain.PRStatusOpen, SortBy: domain.PRSortCreatedAt, Limit: 10}
+
+	t.Run("returns page", func(t *testing.T) {
+		expected := []*domain.PullRequest{{PullRequestID: "pr-1", Status: domain.PRStatusOpen}}
+		mockRepo.On("List", ctx, query).Return(expected, nil).Once()
+
+		prs, err := mockRepo.List(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, prs)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockRepo.On("List", ctx, query).Return(nil, errors.New("find failed")).Once()
+
+		prs, err := mockRepo.List(ctx, query)
+
+		assert.Error(t, err)
+		assert.Nil(t, prs)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockPRRepositorySearch(t *testing.T) {
+	mockRepo := new(MockPRRepository)
+	ctx := context.Background()
+	query := repository.PRSearchQuery{Text: "payment retry", Limit: 10}
+
+	t.Run("returns hits", func(t *testing.T) {
+		expected := []*domain.PRSearchHit{{PullRequestShort: domain.PullRequestShort{PullRequestID: "pr-1"}, Score: 1.5}}
+		mockRepo.On("Search", ctx, query).Return(expected, nil).Once()
+
+		hits, err := mockRepo.Search(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, hits)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockRepo.On("Search", ctx, query).Return(nil, errors.New("text index required")).Once()
+
+		hits, err := mockRepo.Search(ctx, query)
+
+		assert.Error(t, err)
+		assert.Nil(t, hits)
+		mockRepo.AssertExpectations(t)
+	})
+}
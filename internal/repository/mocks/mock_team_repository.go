@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -29,3 +30,50 @@ func (m *MockTeamRepository) Exists(ctx context.Context, teamName string) (bool,
 	args := m.Called(ctx, teamName)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *MockTeamRepository) GetWithMembers(ctx context.Context, teamName string) (*domain.Team, error) {
+	args := m.Called(ctx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) ListNames(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTeamRepository) List(ctx context.Context, query repository.TeamListQuery) ([]*domain.Team, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) Rename(ctx context.Context, oldName, newName string) error {
+	args := m.Called(ctx, oldName, newName)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) Delete(ctx context.Context, teamName string) error {
+	args := m.Called(ctx, teamName)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) ListLegacyMembers(ctx context.Context) (map[string][]domain.TeamMember, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]domain.TeamMember), args.Error(1)
+}
+
+func (m *MockTeamRepository) DropLegacyMembers(ctx context.Context, teamName string) error {
+	args := m.Called(ctx, teamName)
+	return args.Error(0)
+}
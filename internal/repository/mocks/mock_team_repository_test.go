@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -237,3 +238,167 @@ func TestMockTeamRepositoryEdgeCases(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestMockTeamRepositoryGetWithMembers(t *testing.T) {
+	mockRepo := new(MockTeamRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		team := &domain.Team{TeamName: "backend", Members: []domain.TeamMember{{UserID: "user-1"}}}
+		mockRepo.On("GetWithMembers", ctx, "backend").Return(team, nil).Once()
+
+		result, err := mockRepo.GetWithMembers(ctx, "backend")
+
+		require.NoError(t, err)
+		assert.Equal(t, team, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockRepo.On("GetWithMembers", ctx, "missing").Return(nil, domain.ErrTeamNotFound).Once()
+
+		result, err := mockRepo.GetWithMembers(ctx, "missing")
+
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockTeamRepositoryListNames(t *testing.T) {
+	mockRepo := new(MockTeamRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("ListNames", ctx).Return([]string{"backend", "frontend"}, nil).Once()
+
+		names, err := mockRepo.ListNames(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "frontend"}, names)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expectedErr := errors.New("distinct failed")
+		mockRepo.On("ListNames", ctx).Return(nil, expectedErr).Once()
+
+		names, err := mockRepo.ListNames(ctx)
+
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, names)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockTeamRepositoryRename(t *testing.T) {
+	mockRepo := new(MockTeamRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("Rename", ctx, "backend", "platform").Return(nil).Once()
+
+		err := mockRepo.Rename(ctx, "backend", "platform")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		mockRepo.On("Rename", ctx, "backend", "frontend").Return(domain.ErrTeamExists).Once()
+
+		err := mockRepo.Rename(ctx, "backend", "frontend")
+
+		assert.ErrorIs(t, err, domain.ErrTeamExists)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockTeamRepositoryDelete(t *testing.T) {
+	mockRepo := new(MockTeamRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("Delete", ctx, "backend").Return(nil).Once()
+
+		err := mockRepo.Delete(ctx, "backend")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockRepo.On("Delete", ctx, "missing").Return(domain.ErrTeamNotFound).Once()
+
+		err := mockRepo.Delete(ctx, "missing")
+
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockTeamRepositoryListLegacyMembers(t *testing.T) {
+	mockRepo := new(MockTeamRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		legacy := map[string][]domain.TeamMember{"backend": {{UserID: "user-1"}}}
+		mockRepo.On("ListLegacyMembers", ctx).Return(legacy, nil).Once()
+
+		result, err := mockRepo.ListLegacyMembers(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, legacy, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expectedErr := errors.New("find failed")
+		mockRepo.On("ListLegacyMembers", ctx).Return(nil, expectedErr).Once()
+
+		result, err := mockRepo.ListLegacyMembers(ctx)
+
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockTeamRepositoryDropLegacyMembers(t *testing.T) {
+	mockRepo := new(MockTeamRepository)
+	ctx := context.Background()
+
+	mockRepo.On("DropLegacyMembers", ctx, "backend").Return(nil).Once()
+
+	err := mockRepo.DropLegacyMembers(ctx, "backend")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMockTeamRepositoryList(t *testing.T) {
+	mockRepo := new(MockTeamRepository)
+	ctx := context.Background()
+	query := repository.TeamListQuery{AfterTeamName: "backend", Limit: 10}
+
+	t.Run("returns page", func(t *testing.T) {
+		expected := []*domain.Team{{TeamName: "frontend"}}
+		mockRepo.On("List", ctx, query).Return(expected, nil).Once()
+
+		teams, err := mockRepo.List(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, teams)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockRepo.On("List", ctx, query).Return(nil, errors.New("find failed")).Once()
+
+		teams, err := mockRepo.List(ctx, query)
+
+		assert.Error(t, err)
+		assert.Nil(t, teams)
+		mockRepo.AssertExpectations(t)
+	})
+}
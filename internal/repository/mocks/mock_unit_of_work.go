@@ -0,0 +1,12 @@
+package mocks
+
+import (
+	"context"
+)
+
+// MockUnitOfWork runs the work right away: mocked repositories have no writes to discard
+type MockUnitOfWork struct{}
+
+func (MockUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockUnitOfWorkDo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("runs the work", func(t *testing.T) {
+		ran := false
+
+		err := MockUnitOfWork{}.Do(ctx, func(context.Context) error {
+			ran = true
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("returns the work error", func(t *testing.T) {
+		expectedErr := errors.New("work failed")
+
+		err := MockUnitOfWork{}.Do(ctx, func(context.Context) error {
+			return expectedErr
+		})
+
+		assert.ErrorIs(t, err, expectedErr)
+	})
+}
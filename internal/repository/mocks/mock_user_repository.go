@@ -2,8 +2,10 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -25,6 +27,14 @@ func (m *MockUserRepository) GetByID(ctx context.Context, userID string) (*domai
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetActiveByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
 	args := m.Called(ctx, teamName)
 	if args.Get(0) == nil {
@@ -38,6 +48,11 @@ func (m *MockUserRepository) UpdateIsActive(ctx context.Context, userID string,
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) UpdateIsActiveMany(ctx context.Context, userIDs []string, isActive bool) error {
+	args := m.Called(ctx, userIDs, isActive)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) GetByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
 	args := m.Called(ctx, teamName)
 	if args.Get(0) == nil {
@@ -45,3 +60,70 @@ func (m *MockUserRepository) GetByTeam(ctx context.Context, teamName string) ([]
 	}
 	return args.Get(0).([]*domain.User), args.Error(1)
 }
+
+func (m *MockUserRepository) List(ctx context.Context, query repository.UserListQuery) ([]*domain.User, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, userID string, update repository.UserProfileUpdate) error {
+	args := m.Called(ctx, userID, update)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateTeam(ctx context.Context, userID, teamName string) error {
+	args := m.Called(ctx, userID, teamName)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RenameTeam(ctx context.Context, oldName, newName string) error {
+	args := m.Called(ctx, oldName, newName)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListTeamNames(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmails(ctx context.Context, emails []string) ([]*domain.User, error) {
+	args := m.Called(ctx, emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) AddAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	args := m.Called(ctx, userID, absence)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	args := m.Called(ctx, userID, absence)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RemoveAbsence(ctx context.Context, userID, absenceID string) error {
+	args := m.Called(ctx, userID, absenceID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListAbsenceHandoffs(ctx context.Context, at time.Time) ([]*domain.User, error) {
+	args := m.Called(ctx, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) MarkAbsenceHandedOff(ctx context.Context, userID, absenceID string, at time.Time) error {
+	args := m.Called(ctx, userID, absenceID, at)
+	return args.Error(0)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -211,6 +213,31 @@ func TestMockUserRepositoryUpdateIsActive(t *testing.T) {
 	})
 }
 
+func TestMockUserRepositoryUpdateIsActiveMany(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+	userIDs := []string{"user-1", "user-2"}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("UpdateIsActiveMany", ctx, userIDs, false).Return(nil).Once()
+
+		err := mockRepo.UpdateIsActiveMany(ctx, userIDs, false)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expectedErr := errors.New("update failed")
+		mockRepo.On("UpdateIsActiveMany", ctx, userIDs, false).Return(expectedErr).Once()
+
+		err := mockRepo.UpdateIsActiveMany(ctx, userIDs, false)
+
+		assert.ErrorIs(t, err, expectedErr)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
 func TestMockUserRepositoryGetByTeam(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	ctx := context.Background()
@@ -351,3 +378,308 @@ func TestMockUserRepositoryEdgeCases(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestMockUserRepositoryGetByEmails(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+	emails := []string{"alice@example.com"}
+
+	t.Run("success", func(t *testing.T) {
+		users := []*domain.User{{UserID: "user-1", Email: "alice@example.com"}}
+		mockRepo.On("GetByEmails", ctx, emails).Return(users, nil).Once()
+
+		result, err := mockRepo.GetByEmails(ctx, emails)
+
+		require.NoError(t, err)
+		assert.Equal(t, users, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expectedErr := errors.New("find failed")
+		mockRepo.On("GetByEmails", ctx, emails).Return(nil, expectedErr).Once()
+
+		result, err := mockRepo.GetByEmails(ctx, emails)
+
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryAddAbsence(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+	absence := &domain.Absence{AbsenceID: "abs-1", From: time.Now(), To: time.Now().Add(time.Hour)}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("AddAbsence", ctx, "user-1", absence).Return(nil).Once()
+
+		err := mockRepo.AddAbsence(ctx, "user-1", absence)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo.On("AddAbsence", ctx, "user-1", absence).Return(domain.ErrUserNotFound).Once()
+
+		err := mockRepo.AddAbsence(ctx, "user-1", absence)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryUpdateAbsence(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+	absence := &domain.Absence{AbsenceID: "abs-1", From: time.Now(), To: time.Now().Add(time.Hour)}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("UpdateAbsence", ctx, "user-1", absence).Return(nil).Once()
+
+		err := mockRepo.UpdateAbsence(ctx, "user-1", absence)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("absence not found", func(t *testing.T) {
+		mockRepo.On("UpdateAbsence", ctx, "user-1", absence).Return(domain.ErrAbsenceNotFound).Once()
+
+		err := mockRepo.UpdateAbsence(ctx, "user-1", absence)
+
+		assert.ErrorIs(t, err, domain.ErrAbsenceNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryRemoveAbsence(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("RemoveAbsence", ctx, "user-1", "abs-1").Return(nil).Once()
+
+		err := mockRepo.RemoveAbsence(ctx, "user-1", "abs-1")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("absence not found", func(t *testing.T) {
+		mockRepo.On("RemoveAbsence", ctx, "user-1", "abs-1").Return(domain.ErrAbsenceNotFound).Once()
+
+		err := mockRepo.RemoveAbsence(ctx, "user-1", "abs-1")
+
+		assert.ErrorIs(t, err, domain.ErrAbsenceNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryListAbsenceHandoffs(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+	at := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		users := []*domain.User{{UserID: "user-1"}}
+		mockRepo.On("ListAbsenceHandoffs", ctx, at).Return(users, nil).Once()
+
+		result, err := mockRepo.ListAbsenceHandoffs(ctx, at)
+
+		require.NoError(t, err)
+		assert.Equal(t, users, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expectedErr := errors.New("find failed")
+		mockRepo.On("ListAbsenceHandoffs", ctx, at).Return(nil, expectedErr).Once()
+
+		result, err := mockRepo.ListAbsenceHandoffs(ctx, at)
+
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryMarkAbsenceHandedOff(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+	at := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("MarkAbsenceHandedOff", ctx, "user-1", "abs-1", at).Return(nil).Once()
+
+		err := mockRepo.MarkAbsenceHandedOff(ctx, "user-1", "abs-1", at)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockRepo.On("MarkAbsenceHandedOff", ctx, "user-1", "abs-1", at).Return(domain.ErrAbsenceNotFound).Once()
+
+		err := mockRepo.MarkAbsenceHandedOff(ctx, "user-1", "abs-1", at)
+
+		assert.ErrorIs(t, err, domain.ErrAbsenceNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryUpdateProfile(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		username, email := "Alice", ""
+		update := repository.UserProfileUpdate{Username: &username, Email: &email}
+		mockRepo.On("UpdateProfile", ctx, "user-1", update).Return(nil).Once()
+
+		err := mockRepo.UpdateProfile(ctx, "user-1", update)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		limit := 3
+		update := repository.UserProfileUpdate{MaxOpenReviews: &limit}
+		mockRepo.On("UpdateProfile", ctx, "user-2", update).Return(domain.ErrUserNotFound).Once()
+
+		err := mockRepo.UpdateProfile(ctx, "user-2", update)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryUpdateTeam(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("UpdateTeam", ctx, "user-1", "backend").Return(nil).Once()
+
+		err := mockRepo.UpdateTeam(ctx, "user-1", "backend")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo.On("UpdateTeam", ctx, "user-2", "").Return(domain.ErrUserNotFound).Once()
+
+		err := mockRepo.UpdateTeam(ctx, "user-2", "")
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryRenameTeam(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("RenameTeam", ctx, "backend", "platform").Return(nil).Once()
+
+		err := mockRepo.RenameTeam(ctx, "backend", "platform")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expectedErr := errors.New("update failed")
+		mockRepo.On("RenameTeam", ctx, "backend", "platform").Return(expectedErr).Once()
+
+		err := mockRepo.RenameTeam(ctx, "backend", "platform")
+
+		assert.ErrorIs(t, err, expectedErr)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryGetByIDs(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		users := []*domain.User{{UserID: "user-1"}}
+		mockRepo.On("GetByIDs", ctx, []string{"user-1", "user-2"}).Return(users, nil).Once()
+
+		result, err := mockRepo.GetByIDs(ctx, []string{"user-1", "user-2"})
+
+		require.NoError(t, err)
+		assert.Equal(t, users, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expectedErr := errors.New("find failed")
+		mockRepo.On("GetByIDs", ctx, []string{"user-1"}).Return(nil, expectedErr).Once()
+
+		result, err := mockRepo.GetByIDs(ctx, []string{"user-1"})
+
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryListTeamNames(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.On("ListTeamNames", ctx).Return([]string{"backend"}, nil).Once()
+
+		names, err := mockRepo.ListTeamNames(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend"}, names)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expectedErr := errors.New("distinct failed")
+		mockRepo.On("ListTeamNames", ctx).Return(nil, expectedErr).Once()
+
+		names, err := mockRepo.ListTeamNames(ctx)
+
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, names)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMockUserRepositoryList(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+	query := repository.UserListQuery{TeamName: "backend", SortBy: domain.UserSortID, Limit: 10}
+
+	t.Run("returns page", func(t *testing.T) {
+		expected := []*domain.User{{UserID: "user-1", TeamName: "backend"}}
+		mockRepo.On("List", ctx, query).Return(expected, nil).Once()
+
+		users, err := mockRepo.List(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, users)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockRepo.On("List", ctx, query).Return(nil, errors.New("find failed")).Once()
+
+		users, err := mockRepo.List(ctx, query)
+
+		assert.Error(t, err)
+		assert.Nil(t, users)
+		mockRepo.AssertExpectations(t)
+	})
+}
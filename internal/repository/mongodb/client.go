@@ -2,20 +2,29 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
+// ErrNoTransactions is returned by NewClient for a standalone server unless it is allowed explicitly
+var ErrNoTransactions = errors.New("MongoDB server has no transactions, a replica set or a sharded cluster is required")
+
 type Client struct {
-	db     *mongo.Database
-	logger *zap.Logger
+	db *mongo.Database
+	// transactions is false for a standalone server, only replica sets and sharded clusters run them
+	transactions bool
+	logger       *zap.Logger
 }
 
-func NewClient(ctx context.Context, uri, dbName string, connectTimeout time.Duration, logger *zap.Logger) (*Client, error) {
+// NewClient connects to the server. The unit of work needs transactions, so a standalone server is refused
+// with ErrNoTransactions unless allowStandalone is set, then multi-document writes are applied one by one.
+func NewClient(ctx context.Context, uri, dbName string, connectTimeout time.Duration, allowStandalone bool, logger *zap.Logger) (*Client, error) {
 	opts := options.Client().ApplyURI(uri)
 
 	connectCtx, cancel := context.WithTimeout(ctx, connectTimeout)
@@ -35,16 +44,44 @@ func NewClient(ctx context.Context, uri, dbName string, connectTimeout time.Dura
 
 	db := client.Database(dbName)
 
+	transactions, err := supportsTransactions(pingCtx, db)
+	if err != nil {
+		_ = client.Disconnect(context.WithoutCancel(ctx))
+		return nil, fmt.Errorf("failed to get MongoDB topology: %w", err)
+	}
+	if !transactions {
+		if !allowStandalone {
+			_ = client.Disconnect(context.WithoutCancel(ctx))
+			return nil, ErrNoTransactions
+		}
+		logger.Warn("MongoDB is a standalone server without transactions, multi-document writes are not atomic")
+	}
+
 	logger.Info("Connected to MongoDB successfully",
 		zap.String("database", dbName),
+		zap.Bool("transactions", transactions),
 		zap.Duration("connect_timeout", connectTimeout))
 
 	return &Client{
-		db:     db,
-		logger: logger,
+		db:           db,
+		transactions: transactions,
+		logger:       logger,
 	}, nil
 }
 
+// supportsTransactions tells a replica set member or a mongos from a standalone server
+func supportsTransactions(ctx context.Context, db *mongo.Database) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
+
 func (c *Client) Database() *mongo.Database {
 	return c.db
 }
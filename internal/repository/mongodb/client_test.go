@@ -35,7 +35,7 @@ func TestClientClose(t *testing.T) {
 	dbName := "test_db"
 	timeout := 5 * time.Second
 
-	client, err := NewClient(ctx, uri, dbName, timeout, logger)
+	client, err := NewClient(ctx, uri, dbName, timeout, true, logger)
 	if err != nil {
 		t.Skipf("Skipping integration test - MongoDB not available: %v", err)
 		return
@@ -60,7 +60,7 @@ func TestNewClientIntegration(t *testing.T) {
 	dbName := "test_db"
 	timeout := 5 * time.Second
 
-	client, err := NewClient(ctx, uri, dbName, timeout, logger)
+	client, err := NewClient(ctx, uri, dbName, timeout, true, logger)
 	if err != nil {
 		t.Skipf("Skipping integration test - MongoDB not available: %v", err)
 		return
@@ -0,0 +1,48 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"assignment-service/internal/repository"
+	"assignment-service/internal/repository/repotest"
+
+	"go.uber.org/zap"
+)
+
+func TestContract(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	// one connection for the whole suite, so an unavailable server is waited for once.
+	// The suite checks the unit of work as well, a standalone server without transactions is not enough.
+	conn, err := NewClient(ctx, "mongodb://localhost:27017", "test_contract", 5*time.Second, false, logger)
+	if err != nil {
+		t.Skipf("Skipping integration test - MongoDB replica set not available: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close(ctx) })
+
+	// every test case gets its own database so that indexes and data never leak between cases
+	repotest.Run(t, func(t *testing.T) repository.Repositories {
+		client := &Client{
+			db:           conn.Database().Client().Database(fmt.Sprintf("test_contract_%d", time.Now().UnixNano())),
+			transactions: conn.transactions,
+			logger:       logger,
+		}
+		t.Cleanup(func() { _ = client.Database().Drop(ctx) })
+
+		return repository.Repositories{
+			Users:      NewUserRepository(client, logger),
+			Teams:      NewTeamRepository(client, logger),
+			PRs:        NewPRRepository(client, logger),
+			PREvents:   NewPREventRepository(client, logger),
+			UnitOfWork: NewUnitOfWork(client, logger),
+		}
+	})
+}
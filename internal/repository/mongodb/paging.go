@@ -0,0 +1,56 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// keyset describes a list order: sortField in the requested direction, ties broken by idField ascending.
+// When sortField is idField the list is ordered by the id alone.
+type keyset struct {
+	sortField  string
+	idField    string
+	descending bool
+}
+
+func (k keyset) sort() bson.D {
+	direction := 1
+	if k.descending {
+		direction = -1
+	}
+	if k.sortField == k.idField {
+		return bson.D{{Key: k.idField, Value: direction}}
+	}
+	return bson.D{{Key: k.sortField, Value: direction}, {Key: k.idField, Value: 1}}
+}
+
+// after matches documents following the one with the given sort value and id.
+// A nil value stands for a missing field, MongoDB orders it before any other value.
+func (k keyset) after(value any, id string) bson.M {
+	next := "$gt"
+	if k.descending {
+		next = "$lt"
+	}
+	if k.sortField == k.idField {
+		return bson.M{k.idField: bson.M{next: id}}
+	}
+
+	sameValue := bson.M{k.sortField: value, k.idField: bson.M{"$gt": id}}
+	switch {
+	case value == nil && k.descending:
+		return sameValue
+	case value == nil:
+		return bson.M{"$or": bson.A{bson.M{k.sortField: bson.M{"$ne": nil}}, sameValue}}
+	case k.descending:
+		return bson.M{"$or": bson.A{bson.M{k.sortField: bson.M{next: value}}, bson.M{k.sortField: nil}, sameValue}}
+	default:
+		return bson.M{"$or": bson.A{bson.M{k.sortField: bson.M{next: value}}, sameValue}}
+	}
+}
+
+// pageFilter restricts filter to the documents after the position, nil after leaves it as is
+func pageFilter(filter, after bson.M) bson.M {
+	if after == nil {
+		return filter
+	}
+	return bson.M{"$and": bson.A{filter, after}}
+}
@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"assignment-service/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const prEventsCollection = "pr_events"
+
+type PREventRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+func NewPREventRepository(client *Client, logger *zap.Logger) *PREventRepository {
+	collection := client.Database().Collection(prEventsCollection)
+
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "pull_request_id", Value: 1}, {Key: "at", Value: 1}},
+	})
+
+	return &PREventRepository{
+		collection: collection,
+		logger:     logger,
+	}
+}
+
+func (r *PREventRepository) Append(ctx context.Context, events ...*domain.PREvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := make([]any, 0, len(events))
+	for _, event := range events {
+		docs = append(docs, event)
+	}
+
+	// ordered insert keeps the events order for equal timestamps
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(true))
+	if err != nil {
+		r.logger.Error("failed to append PR events", zap.Error(err), zap.String("pr_id", events[0].PullRequestID))
+		return fmt.Errorf("failed to append PR events: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PREventRepository) ListByPR(ctx context.Context, prID string) ([]*domain.PREvent, error) {
+	filter := bson.M{"pull_request_id": prID}
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to find PR events", zap.Error(err), zap.String("pr_id", prID))
+		return nil, fmt.Errorf("failed to find PR events: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	events := []*domain.PREvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		r.logger.Error("failed to decode PR events", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PR events: %w", err)
+	}
+
+	return events, nil
+}
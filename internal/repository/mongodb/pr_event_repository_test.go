@@ -0,0 +1,66 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPREventRepositoryAppendAndList(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewPREventRepository(client, logger)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	t.Run("keeps order of events", func(t *testing.T) {
+		err := repo.Append(ctx,
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventCreated, At: now},
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerAssigned, ReviewerID: "user-2", At: now},
+			&domain.PREvent{PullRequestID: "pr-2", Type: domain.PREventCreated, At: now},
+		)
+		require.NoError(t, err)
+
+		err = repo.Append(ctx, &domain.PREvent{
+			PullRequestID:      "pr-1",
+			Type:               domain.PREventReviewerReplaced,
+			ReviewerID:         "user-3",
+			ReplacedReviewerID: "user-2",
+			Reason:             domain.PREventReasonManualReassign,
+			At:                 now.Add(time.Second),
+		})
+		require.NoError(t, err)
+
+		events, err := repo.ListByPR(ctx, "pr-1")
+
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		assert.Equal(t, domain.PREventCreated, events[0].Type)
+		assert.Equal(t, domain.PREventReviewerAssigned, events[1].Type)
+		assert.Equal(t, "user-2", events[2].ReplacedReviewerID)
+		assert.Equal(t, domain.PREventReasonManualReassign, events[2].Reason)
+	})
+
+	t.Run("empty append", func(t *testing.T) {
+		assert.NoError(t, repo.Append(ctx))
+	})
+
+	t.Run("unknown PR", func(t *testing.T) {
+		events, err := repo.ListByPR(ctx, "missing")
+
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
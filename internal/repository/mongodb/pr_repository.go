@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -12,7 +13,10 @@ import (
 	"go.uber.org/zap"
 )
 
-const prsCollection = "pull_requests"
+const (
+	prsCollection = "pull_requests"
+	prTextIndex   = "pull_requests_text"
+)
 
 type PRRepository struct {
 	collection *mongo.Collection
@@ -35,6 +39,36 @@ func NewPRRepository(client *Client, logger *zap.Logger) *PRRepository {
 		Keys: bson.D{{Key: "author_id", Value: 1}},
 	})
 
+	// reviewer load queries filter open PRs by reviewer
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "assigned_reviewers", Value: 1}},
+	})
+
+	// team stats count PRs of team authors within a time window
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+
+	// PR lists are ordered by created_at, alone or after a status or reviewer filter
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "pull_request_id", Value: 1}},
+	})
+
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}, {Key: "pull_request_id", Value: 1}},
+	})
+
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "assigned_reviewers", Value: 1}, {Key: "created_at", Value: 1}, {Key: "pull_request_id", Value: 1}},
+	})
+
+	// MongoDB allows one text index per collection: new searchable fields are added to it,
+	// the old index has to be dropped by hand as CreateOne does not change an existing index
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "pull_request_name", Value: "text"}},
+		Options: options.Index().SetName(prTextIndex).SetDefaultLanguage("english"),
+	})
+
 	return &PRRepository{
 		collection: collection,
 		logger:     logger,
@@ -71,11 +105,12 @@ func (r *PRRepository) GetByID(ctx context.Context, prID string) (*domain.PullRe
 	return &pr, nil
 }
 
+// Update replaces the PR document: fields left empty (closed_at, reviews, fallback_team...) are omitted
+// from it and so removed, a $set would keep their stored values
 func (r *PRRepository) Update(ctx context.Context, pr *domain.PullRequest) error {
 	filter := bson.M{"pull_request_id": pr.PullRequestID}
-	update := bson.M{"$set": pr}
 
-	_, err := r.collection.UpdateOne(ctx, filter, update)
+	_, err := r.collection.ReplaceOne(ctx, filter, pr)
 	if err != nil {
 		r.logger.Error("failed to update PR", zap.Error(err), zap.String("pr_id", pr.PullRequestID))
 		return fmt.Errorf("failed to update PR: %w", err)
@@ -134,3 +169,444 @@ func (r *PRRepository) GetOpenByTeam(ctx context.Context, teamName string) ([]*d
 
 	return prs, nil
 }
+
+func (r *PRRepository) List(ctx context.Context, query repository.PRListQuery) ([]*domain.PullRequest, error) {
+	if query.AuthorIDs != nil && len(query.AuthorIDs) == 0 {
+		return []*domain.PullRequest{}, nil
+	}
+
+	filter := windowFilter("created_at", query.Created)
+	if query.AuthorIDs != nil {
+		filter["author_id"] = bson.M{"$in": query.AuthorIDs}
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	if query.ReviewerID != "" {
+		filter["assigned_reviewers"] = query.ReviewerID
+	}
+
+	order := keyset{sortField: query.SortBy, idField: "pull_request_id", descending: query.Descending}
+	if query.AfterPRID != "" {
+		var after any
+		if query.AfterCreatedAt != nil {
+			after = *query.AfterCreatedAt
+		}
+		filter = pageFilter(filter, order.after(after, query.AfterPRID))
+	}
+
+	opts := options.Find().SetSort(order.sort())
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to list PRs", zap.Error(err))
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	prs := []*domain.PullRequest{}
+	if err := cursor.All(ctx, &prs); err != nil {
+		r.logger.Error("failed to decode PRs", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PRs: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (r *PRRepository) Search(ctx context.Context, query repository.PRSearchQuery) ([]*domain.PRSearchHit, error) {
+	match := bson.M{"$text": bson.M{"$search": query.Text}}
+	if query.Status != "" {
+		match["status"] = query.Status
+	}
+
+	order := keyset{sortField: "score", idField: "pull_request_id", descending: true}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+	}
+	if query.AfterPRID != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: order.after(query.AfterScore, query.AfterPRID)}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: order.sort()}})
+	if query.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: query.Limit}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$project", Value: bson.M{
+		"_id":               0,
+		"pull_request_id":   1,
+		"pull_request_name": 1,
+		"author_id":         1,
+		"status":            1,
+		"score":             1,
+	}}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("failed to search PRs", zap.Error(err))
+		return nil, fmt.Errorf("failed to search PRs: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	hits := []*domain.PRSearchHit{}
+	if err := cursor.All(ctx, &hits); err != nil {
+		r.logger.Error("failed to decode PR search hits", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PR search hits: %w", err)
+	}
+
+	return hits, nil
+}
+
+func (r *PRRepository) GetOpenByReviewers(ctx context.Context, userIDs []string) ([]*domain.PullRequest, error) {
+	if len(userIDs) == 0 {
+		return []*domain.PullRequest{}, nil
+	}
+
+	filter := bson.M{
+		"status":             domain.PRStatusOpen,
+		"assigned_reviewers": bson.M{"$in": userIDs},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "pull_request_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to find open PRs by reviewers", zap.Error(err))
+		return nil, fmt.Errorf("failed to find open PRs by reviewers: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	prs := []*domain.PullRequest{}
+	if err := cursor.All(ctx, &prs); err != nil {
+		r.logger.Error("failed to decode PRs", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PRs: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (r *PRRepository) CountOpenByReviewers(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"status":             domain.PRStatusOpen,
+			"assigned_reviewers": bson.M{"$in": userIDs},
+		}}},
+		{{Key: "$unwind", Value: "$assigned_reviewers"}},
+		{{Key: "$match", Value: bson.M{"assigned_reviewers": bson.M{"$in": userIDs}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$assigned_reviewers",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("failed to count open PRs by reviewers", zap.Error(err), zap.Int("reviewers", len(userIDs)))
+		return nil, fmt.Errorf("failed to count open PRs by reviewers: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		UserID string `bson:"_id"`
+		Count  int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		r.logger.Error("failed to decode reviewer counts", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode reviewer counts: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+
+	return counts, nil
+}
+
+func (r *PRRepository) ListUserStats(ctx context.Context, query repository.UserStatsQuery) ([]*domain.UserStats, error) {
+	countIf := func(status domain.PRStatus) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$status", status}}, 1, 0}}}
+	}
+
+	usersFilter := bson.M{}
+	if query.TeamName != "" {
+		usersFilter["team_name"] = query.TeamName
+	}
+
+	prFilter := windowFilter("created_at", query.Created)
+	// DRAFT and CLOSED PRs have no reviewers
+	prFilter["status"] = bson.M{"$in": bson.A{domain.PRStatusOpen, domain.PRStatusMerged}}
+
+	// the team members are resolved first so that only their PRs are read and grouped
+	var teamReviewers bson.M
+	if query.TeamName != "" {
+		values, err := r.collection.Database().Collection(usersCollection).Distinct(ctx, "user_id", usersFilter)
+		if err != nil {
+			r.logger.Error("failed to get team members", zap.Error(err), zap.String("team_name", query.TeamName))
+			return nil, fmt.Errorf("failed to get team members: %w", err)
+		}
+
+		teamReviewers = bson.M{"assigned_reviewers": bson.M{"$in": distinctStrings(values)}}
+		prFilter["assigned_reviewers"] = teamReviewers["assigned_reviewers"]
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: prFilter}},
+		{{Key: "$unwind", Value: "$assigned_reviewers"}},
+	}
+	// the team PRs also have reviewers from other teams
+	if teamReviewers != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: teamReviewers}})
+	}
+
+	pipeline = append(pipeline, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":             "$assigned_reviewers",
+			"assigned_count":  bson.M{"$sum": 1},
+			"open_pr_count":   countIf(domain.PRStatusOpen),
+			"merged_pr_count": countIf(domain.PRStatusMerged),
+		}}},
+		// users without assignments come with zero counters
+		{{Key: "$unionWith", Value: bson.M{
+			"coll": usersCollection,
+			"pipeline": bson.A{
+				bson.M{"$match": usersFilter},
+				bson.M{"$project": bson.M{
+					"_id":             "$user_id",
+					"assigned_count":  bson.M{"$literal": 0},
+					"open_pr_count":   bson.M{"$literal": 0},
+					"merged_pr_count": bson.M{"$literal": 0},
+				}},
+			},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             "$_id",
+			"assigned_count":  bson.M{"$sum": "$assigned_count"},
+			"open_pr_count":   bson.M{"$sum": "$open_pr_count"},
+			"merged_pr_count": bson.M{"$sum": "$merged_pr_count"},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         usersCollection,
+			"localField":   "_id",
+			"foreignField": "user_id",
+			"as":           "user",
+		}}},
+		// reviewers who are no longer registered users are dropped
+		{{Key: "$unwind", Value: "$user"}},
+		{{Key: "$project", Value: bson.M{
+			"_id":             0,
+			"user_id":         "$_id",
+			"username":        "$user.username",
+			"team_name":       "$user.team_name",
+			"assigned_count":  1,
+			"open_pr_count":   1,
+			"merged_pr_count": 1,
+		}}},
+	}...)
+
+	direction, after := 1, "$gt"
+	if query.Descending {
+		direction, after = -1, "$lt"
+	}
+
+	if query.AfterUserID != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{query.SortBy: bson.M{after: query.AfterValue}},
+			bson.M{query.SortBy: query.AfterValue, "user_id": bson.M{"$gt": query.AfterUserID}},
+		}}}})
+	}
+
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{
+		{Key: query.SortBy, Value: direction},
+		{Key: "user_id", Value: 1},
+	}}})
+
+	if query.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: query.Limit}})
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("failed to aggregate user stats", zap.Error(err), zap.String("team_name", query.TeamName))
+		return nil, fmt.Errorf("failed to aggregate user stats: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	stats := []*domain.UserStats{}
+	if err := cursor.All(ctx, &stats); err != nil {
+		r.logger.Error("failed to decode user stats", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode user stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *PRRepository) CountByStatus(ctx context.Context, query repository.PRCountQuery) (map[domain.PRStatus]int, error) {
+	counts := make(map[domain.PRStatus]int)
+	if len(query.AuthorIDs) == 0 {
+		return counts, nil
+	}
+
+	filter := windowFilter("created_at", query.Created)
+	filter["author_id"] = bson.M{"$in": query.AuthorIDs}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$status",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("failed to count PRs by status", zap.Error(err))
+		return nil, fmt.Errorf("failed to count PRs by status: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Status domain.PRStatus `bson:"_id"`
+		Count  int             `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		r.logger.Error("failed to decode PR counts", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PR counts: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+func (r *PRRepository) ListMergeTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	filter := windowFilter("merged_at", query.Window)
+	filter["status"] = domain.PRStatusMerged
+	filter["created_at"] = bson.M{"$ne": nil}
+
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: filter}}}, timingStages(query.TeamName)...)
+
+	return r.listTimings(ctx, pipeline)
+}
+
+func (r *PRRepository) ListReassignmentTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	filter := windowFilter("created_at", query.Window)
+	if _, ok := filter["created_at"]; !ok {
+		filter["created_at"] = bson.M{"$ne": nil}
+	}
+
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: filter}}}, timingStages(query.TeamName)...)
+	// the reassignment events, legacy unassignments included, see domain.PREvent.IsReassignment
+	pipeline = append(pipeline, firstEventStages("first_reassigned_at", bson.M{"$or": bson.A{
+		bson.M{"type": domain.PREventReviewerReplaced},
+		bson.M{"type": domain.PREventReviewerUnassigned, "reason": domain.PREventReasonLegacyTeamReassignment},
+	}})...)
+
+	return r.listTimings(ctx, pipeline)
+}
+
+func (r *PRRepository) ListReviewTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	filter := windowFilter("created_at", query.Window)
+	if _, ok := filter["created_at"]; !ok {
+		filter["created_at"] = bson.M{"$ne": nil}
+	}
+
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: filter}}}, timingStages(query.TeamName)...)
+	pipeline = append(pipeline, firstEventStages("first_reviewed_at", bson.M{"type": domain.PREventReviewSubmitted})...)
+
+	return r.listTimings(ctx, pipeline)
+}
+
+// firstEventStages sets field to the time of the earliest event of the PR matching the filter
+func firstEventStages(field string, match bson.M) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         prEventsCollection,
+			"localField":   "pull_request_id",
+			"foreignField": "pull_request_id",
+			"pipeline": bson.A{
+				bson.M{"$match": match},
+				bson.M{"$sort": bson.M{"at": 1}},
+				bson.M{"$limit": 1},
+			},
+			"as": "first_event",
+		}}},
+		{{Key: "$set", Value: bson.M{field: bson.M{"$first": "$first_event.at"}}}},
+		{{Key: "$unset", Value: "first_event"}},
+	}
+}
+
+// timingStages resolves the author's team and filters by it
+func timingStages(teamName string) mongo.Pipeline {
+	stages := mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         usersCollection,
+			"localField":   "author_id",
+			"foreignField": "user_id",
+			"as":           "author",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":             0,
+			"pull_request_id": 1,
+			"author_id":       1,
+			"created_at":      1,
+			"merged_at":       1,
+			"team_name":       bson.M{"$first": "$author.team_name"},
+		}}},
+	}
+
+	if teamName != "" {
+		stages = append(stages, bson.D{{Key: "$match", Value: bson.M{"team_name": teamName}}})
+	}
+
+	return stages
+}
+
+func (r *PRRepository) listTimings(ctx context.Context, pipeline mongo.Pipeline) ([]*domain.PRTiming, error) {
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("failed to aggregate PR timings", zap.Error(err))
+		return nil, fmt.Errorf("failed to aggregate PR timings: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	timings := []*domain.PRTiming{}
+	if err := cursor.All(ctx, &timings); err != nil {
+		r.logger.Error("failed to decode PR timings", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PR timings: %w", err)
+	}
+
+	return timings, nil
+}
+
+// windowFilter matches documents whose field is within the window, an unbounded window matches everything
+func windowFilter(field string, window repository.TimeWindow) bson.M {
+	filter := bson.M{}
+
+	bounds := bson.M{}
+	if window.From != nil {
+		bounds["$gte"] = *window.From
+	}
+	if window.To != nil {
+		bounds["$lt"] = *window.To
+	}
+	if len(bounds) > 0 {
+		filter[field] = bounds
+	}
+
+	return filter
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,7 +27,7 @@ func setupTestDB(t *testing.T) (*Client, func()) {
 	dbName := "test_assignment_service"
 	timeout := 5 * time.Second
 
-	client, err := NewClient(ctx, uri, dbName, timeout, logger)
+	client, err := NewClient(ctx, uri, dbName, timeout, true, logger)
 	if err != nil {
 		t.Skipf("Skipping integration test - MongoDB not available: %v", err)
 		return nil, nil
@@ -453,6 +454,418 @@ func TestPRRepositoryGetOpenByTeam(t *testing.T) {
 	})
 }
 
+func TestPRRepositoryGetOpenByReviewers(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewPRRepository(client, logger)
+
+	now := time.Now()
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-2", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"reviewer-1", "reviewer-2"}, CreatedAt: &now},
+		{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"reviewer-3"}, CreatedAt: &now},
+		{PullRequestID: "pr-3", AuthorID: "user-1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"reviewer-1"}, CreatedAt: &now, MergedAt: &now},
+		{PullRequestID: "pr-4", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"reviewer-4"}, CreatedAt: &now},
+	}
+	for _, pr := range prs {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+
+	t.Run("open PRs of any listed reviewer", func(t *testing.T) {
+		found, err := repo.GetOpenByReviewers(ctx, []string{"reviewer-1", "reviewer-2", "reviewer-3"})
+
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, "pr-1", found[0].PullRequestID)
+		assert.Equal(t, "pr-2", found[1].PullRequestID)
+	})
+
+	t.Run("empty reviewers list", func(t *testing.T) {
+		found, err := repo.GetOpenByReviewers(ctx, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewPRRepository(closedClient, logger)
+
+		found, err := badRepo.GetOpenByReviewers(ctx, []string{"reviewer-1"})
+
+		assert.Error(t, err)
+		assert.Nil(t, found)
+		assert.Contains(t, err.Error(), "failed to find open PRs by reviewers")
+	})
+}
+
+func TestPRRepositoryCountOpenByReviewers(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewPRRepository(client, logger)
+
+	now := time.Now()
+	prs := []*domain.PullRequest{
+		{
+			PullRequestID:     "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"reviewer-1", "reviewer-2"},
+			CreatedAt:         &now,
+		},
+		{
+			PullRequestID:     "pr-2",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"reviewer-1", "reviewer-3"},
+			CreatedAt:         &now,
+		},
+		{
+			PullRequestID:     "pr-3",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusMerged,
+			AssignedReviewers: []string{"reviewer-2"},
+			CreatedAt:         &now,
+			MergedAt:          &now,
+		},
+	}
+	for _, pr := range prs {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+
+	t.Run("counts only open PRs of requested reviewers", func(t *testing.T) {
+		counts, err := repo.CountOpenByReviewers(ctx, []string{"reviewer-1", "reviewer-2", "reviewer-4"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"reviewer-1": 2, "reviewer-2": 1}, counts)
+	})
+
+	t.Run("empty reviewers list", func(t *testing.T) {
+		counts, err := repo.CountOpenByReviewers(ctx, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("database error - covers error logging branch", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewPRRepository(closedClient, logger)
+
+		counts, err := badRepo.CountOpenByReviewers(ctx, []string{"reviewer-1"})
+
+		assert.Error(t, err)
+		assert.Nil(t, counts)
+		assert.Contains(t, err.Error(), "failed to count open PRs by reviewers")
+	})
+}
+
+func TestPRRepositoryListUserStats(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewPRRepository(client, logger)
+	userRepo := NewUserRepository(client, logger)
+
+	users := []*domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: false},
+		{UserID: "u4", Username: "Dave", TeamName: "frontend", IsActive: true},
+	}
+	for _, u := range users {
+		require.NoError(t, userRepo.CreateOrUpdate(ctx, u))
+	}
+
+	now := time.Now()
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u4", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1", "u2"}, CreatedAt: &now},
+		{PullRequestID: "pr-2", AuthorID: "u4", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u1"}, CreatedAt: &now, MergedAt: &now},
+		{PullRequestID: "pr-3", AuthorID: "u4", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1"}, CreatedAt: &now},
+		{PullRequestID: "pr-4", AuthorID: "u1", Status: domain.PRStatusClosed, AssignedReviewers: []string{"u4"}, CreatedAt: &now},
+	}
+	for _, pr := range prs {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+
+	t.Run("includes users without assignments", func(t *testing.T) {
+		stats, err := repo.ListUserStats(ctx, repository.UserStatsQuery{
+			SortBy:     domain.UserStatsSortAssigned,
+			Descending: true,
+			Limit:      10,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, stats, 4)
+		assert.Equal(t, &domain.UserStats{UserID: "u1", Username: "Alice", TeamName: "backend", AssignedCount: 3, OpenPRCount: 2, MergedPRCount: 1}, stats[0])
+		assert.Equal(t, "u2", stats[1].UserID)
+		assert.Equal(t, 1, stats[1].AssignedCount)
+		// closed PRs are not counted, ties are ordered by user_id
+		assert.Equal(t, "u3", stats[2].UserID)
+		assert.Zero(t, stats[2].AssignedCount)
+		assert.Equal(t, "u4", stats[3].UserID)
+		assert.Zero(t, stats[3].AssignedCount)
+	})
+
+	t.Run("filters by team", func(t *testing.T) {
+		stats, err := repo.ListUserStats(ctx, repository.UserStatsQuery{
+			TeamName: "frontend",
+			SortBy:   domain.UserStatsSortOpen,
+			Limit:    10,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.Equal(t, "u4", stats[0].UserID)
+	})
+
+	t.Run("continues after cursor", func(t *testing.T) {
+		stats, err := repo.ListUserStats(ctx, repository.UserStatsQuery{
+			SortBy:      domain.UserStatsSortAssigned,
+			Descending:  true,
+			AfterValue:  1,
+			AfterUserID: "u2",
+			Limit:       10,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		assert.Equal(t, "u3", stats[0].UserID)
+		assert.Equal(t, "u4", stats[1].UserID)
+	})
+
+	t.Run("ascending order with limit", func(t *testing.T) {
+		stats, err := repo.ListUserStats(ctx, repository.UserStatsQuery{
+			SortBy: domain.UserStatsSortMerged,
+			Limit:  2,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		assert.Equal(t, "u2", stats[0].UserID)
+		assert.Equal(t, "u3", stats[1].UserID)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewPRRepository(closedClient, logger)
+
+		stats, err := badRepo.ListUserStats(ctx, repository.UserStatsQuery{SortBy: domain.UserStatsSortAssigned, Limit: 1})
+
+		assert.Error(t, err)
+		assert.Nil(t, stats)
+		assert.Contains(t, err.Error(), "failed to aggregate user stats")
+	})
+}
+
+func TestPRRepositoryCountByStatus(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewPRRepository(client, logger)
+
+	day := func(d int) *time.Time {
+		t := time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC)
+		return &t
+	}
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}, CreatedAt: day(1)},
+		{PullRequestID: "pr-2", AuthorID: "u1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u2"}, CreatedAt: day(5), MergedAt: day(6)},
+		{PullRequestID: "pr-3", AuthorID: "u2", Status: domain.PRStatusDraft, AssignedReviewers: []string{}, CreatedAt: day(10)},
+		{PullRequestID: "pr-4", AuthorID: "u3", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1"}, CreatedAt: day(5)},
+	}
+	for _, pr := range prs {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+
+	t.Run("counts PRs of authors", func(t *testing.T) {
+		counts, err := repo.CountByStatus(ctx, repository.PRCountQuery{AuthorIDs: []string{"u1", "u2"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.PRStatus]int{
+			domain.PRStatusOpen:   1,
+			domain.PRStatusMerged: 1,
+			domain.PRStatusDraft:  1,
+		}, counts)
+	})
+
+	t.Run("window includes from and excludes to", func(t *testing.T) {
+		counts, err := repo.CountByStatus(ctx, repository.PRCountQuery{
+			AuthorIDs: []string{"u1", "u2"},
+			Created:   repository.TimeWindow{From: day(5), To: day(10)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.PRStatus]int{domain.PRStatusMerged: 1}, counts)
+	})
+
+	t.Run("window limits user stats", func(t *testing.T) {
+		userRepo := NewUserRepository(client, logger)
+		require.NoError(t, userRepo.CreateOrUpdate(ctx, &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}))
+		require.NoError(t, userRepo.CreateOrUpdate(ctx, &domain.User{UserID: "u2", TeamName: "backend", IsActive: true}))
+
+		stats, err := repo.ListUserStats(ctx, repository.UserStatsQuery{
+			SortBy:  domain.UserStatsSortAssigned,
+			Created: repository.TimeWindow{From: day(2)},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		// pr-1 of u2 is created before the window
+		assert.Equal(t, &domain.UserStats{UserID: "u1", TeamName: "backend", AssignedCount: 1, OpenPRCount: 1}, stats[0])
+		assert.Equal(t, &domain.UserStats{UserID: "u2", TeamName: "backend", AssignedCount: 1, MergedPRCount: 1}, stats[1])
+	})
+
+	t.Run("no authors", func(t *testing.T) {
+		counts, err := repo.CountByStatus(ctx, repository.PRCountQuery{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewPRRepository(closedClient, logger)
+
+		counts, err := badRepo.CountByStatus(ctx, repository.PRCountQuery{AuthorIDs: []string{"u1"}})
+
+		assert.Error(t, err)
+		assert.Nil(t, counts)
+		assert.Contains(t, err.Error(), "failed to count PRs by status")
+	})
+}
+
+func TestPRRepositoryTimings(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewPRRepository(client, logger)
+	userRepo := NewUserRepository(client, logger)
+	eventRepo := NewPREventRepository(client, logger)
+
+	require.NoError(t, userRepo.CreateOrUpdate(ctx, &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}))
+	require.NoError(t, userRepo.CreateOrUpdate(ctx, &domain.User{UserID: "u2", TeamName: "frontend", IsActive: true}))
+
+	day := func(d int) *time.Time {
+		t := time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC)
+		return &t
+	}
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"r1"}, CreatedAt: day(1), MergedAt: day(3)},
+		{PullRequestID: "pr-2", AuthorID: "u2", Status: domain.PRStatusMerged, AssignedReviewers: []string{"r1"}, CreatedAt: day(2), MergedAt: day(10)},
+		{PullRequestID: "pr-3", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"r2"}, CreatedAt: day(4)},
+	}
+	for _, pr := range prs {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+
+	require.NoError(t, eventRepo.Append(ctx,
+		&domain.PREvent{PullRequestID: "pr-3", Type: domain.PREventReviewerAssigned, At: *day(4), ReviewerID: "r1"},
+		&domain.PREvent{PullRequestID: "pr-3", Type: domain.PREventReviewerReplaced, At: *day(6), ReviewerID: "r2", ReplacedReviewerID: "r1"},
+		&domain.PREvent{PullRequestID: "pr-3", Type: domain.PREventReviewerUnassigned, At: *day(5), ReviewerID: "r0", Reason: domain.PREventReasonLegacyTeamReassignment},
+		&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerUnassigned, At: *day(2), ReviewerID: "r0", Reason: domain.PREventReasonClosed},
+	))
+
+	t.Run("merge timings within window", func(t *testing.T) {
+		timings, err := repo.ListMergeTimings(ctx, repository.PRTimingQuery{
+			Window: repository.TimeWindow{To: day(5)},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, timings, 1)
+		assert.Equal(t, "pr-1", timings[0].PullRequestID)
+		assert.Equal(t, "backend", timings[0].TeamName)
+		assert.True(t, day(1).Equal(timings[0].CreatedAt))
+		require.NotNil(t, timings[0].MergedAt)
+		assert.True(t, day(3).Equal(*timings[0].MergedAt))
+	})
+
+	t.Run("merge timings of a team", func(t *testing.T) {
+		timings, err := repo.ListMergeTimings(ctx, repository.PRTimingQuery{TeamName: "frontend"})
+
+		require.NoError(t, err)
+		require.Len(t, timings, 1)
+		assert.Equal(t, "pr-2", timings[0].PullRequestID)
+	})
+
+	t.Run("first reassignment of each PR", func(t *testing.T) {
+		timings, err := repo.ListReassignmentTimings(ctx, repository.PRTimingQuery{TeamName: "backend"})
+
+		require.NoError(t, err)
+		require.Len(t, timings, 2)
+
+		byID := map[string]*domain.PRTiming{}
+		for _, pr := range timings {
+			byID[pr.PullRequestID] = pr
+		}
+		// releasing reviewers of a closed PR is not a reassignment
+		assert.Nil(t, byID["pr-1"].FirstReassignedAt)
+		require.NotNil(t, byID["pr-3"].FirstReassignedAt)
+		assert.True(t, day(5).Equal(*byID["pr-3"].FirstReassignedAt))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewPRRepository(closedClient, logger)
+
+		timings, err := badRepo.ListMergeTimings(ctx, repository.PRTimingQuery{})
+
+		assert.Error(t, err)
+		assert.Nil(t, timings)
+		assert.Contains(t, err.Error(), "failed to aggregate PR timings")
+	})
+}
+
 func TestPRRepositoryIndexes(t *testing.T) {
 	client, cleanup := setupTestDB(t)
 	if client == nil {
@@ -524,3 +937,189 @@ func TestPRRepositoryDecodeErrors(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to decode PRs")
 	})
 }
+
+func TestPRRepositoryList(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewPRRepository(client, logger)
+
+	day := func(d int) *time.Time {
+		at := time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC)
+		return &at
+	}
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}, CreatedAt: day(1)},
+		{PullRequestID: "pr-2", AuthorID: "u2", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u1"}, CreatedAt: day(2)},
+		{PullRequestID: "pr-3", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u3"}, CreatedAt: day(2)},
+		{PullRequestID: "pr-4", AuthorID: "u3", Status: domain.PRStatusDraft, AssignedReviewers: []string{}, CreatedAt: day(3)},
+		// created before created_at was stored
+		{PullRequestID: "pr-0", AuthorID: "u1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u2"}},
+	}
+	for _, pr := range prs {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+
+	ids := func(prs []*domain.PullRequest) []string {
+		result := make([]string, 0, len(prs))
+		for _, pr := range prs {
+			result = append(result, pr.PullRequestID)
+		}
+		return result
+	}
+
+	// pages walks the whole list two PRs at a time
+	pages := func(t *testing.T, query repository.PRListQuery) []string {
+		query.Limit = 2
+		var all []string
+		for {
+			page, err := repo.List(ctx, query)
+			require.NoError(t, err)
+			all = append(all, ids(page)...)
+			if len(page) < query.Limit {
+				return all
+			}
+			last := page[len(page)-1]
+			query.AfterCreatedAt, query.AfterPRID = last.CreatedAt, last.PullRequestID
+		}
+	}
+
+	t.Run("newest first, missing created_at last", func(t *testing.T) {
+		got := pages(t, repository.PRListQuery{SortBy: domain.PRSortCreatedAt, Descending: true})
+
+		assert.Equal(t, []string{"pr-4", "pr-2", "pr-3", "pr-1", "pr-0"}, got)
+	})
+
+	t.Run("oldest first, missing created_at first", func(t *testing.T) {
+		got := pages(t, repository.PRListQuery{SortBy: domain.PRSortCreatedAt})
+
+		assert.Equal(t, []string{"pr-0", "pr-1", "pr-2", "pr-3", "pr-4"}, got)
+	})
+
+	t.Run("by id descending", func(t *testing.T) {
+		got := pages(t, repository.PRListQuery{SortBy: domain.PRSortID, Descending: true})
+
+		assert.Equal(t, []string{"pr-4", "pr-3", "pr-2", "pr-1", "pr-0"}, got)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		page, err := repo.List(ctx, repository.PRListQuery{
+			AuthorIDs: []string{"u1", "u2"},
+			Status:    domain.PRStatusOpen,
+			SortBy:    domain.PRSortID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1", "pr-3"}, ids(page))
+
+		page, err = repo.List(ctx, repository.PRListQuery{ReviewerID: "u2", SortBy: domain.PRSortID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-0", "pr-1"}, ids(page))
+
+		page, err = repo.List(ctx, repository.PRListQuery{
+			Created: repository.TimeWindow{From: day(2), To: day(3)},
+			SortBy:  domain.PRSortID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-2", "pr-3"}, ids(page))
+	})
+
+	t.Run("empty author list matches nothing", func(t *testing.T) {
+		page, err := repo.List(ctx, repository.PRListQuery{AuthorIDs: []string{}, SortBy: domain.PRSortID})
+
+		require.NoError(t, err)
+		assert.NotNil(t, page)
+		assert.Empty(t, page)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			return
+		}
+		closedClient.Close(ctx)
+
+		page, err := NewPRRepository(closedClient, logger).List(ctx, repository.PRListQuery{SortBy: domain.PRSortID})
+
+		assert.Error(t, err)
+		assert.Nil(t, page)
+		assert.Contains(t, err.Error(), "failed to list PRs")
+	})
+}
+
+func TestPRRepositorySearch(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewPRRepository(client, logger)
+
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-1", PullRequestName: "Add payment retry with backoff", AuthorID: "u1", Status: domain.PRStatusOpen},
+		{PullRequestID: "pr-2", PullRequestName: "Retry failed payments, retry webhooks", AuthorID: "u2", Status: domain.PRStatusMerged},
+		{PullRequestID: "pr-3", PullRequestName: "Fix typo in README", AuthorID: "u1", Status: domain.PRStatusOpen},
+		{PullRequestID: "pr-4", PullRequestName: "Payment page layout", AuthorID: "u3", Status: domain.PRStatusOpen},
+	}
+	for _, pr := range prs {
+		require.NoError(t, repo.Create(ctx, pr))
+	}
+
+	ids := func(hits []*domain.PRSearchHit) []string {
+		result := make([]string, 0, len(hits))
+		for _, hit := range hits {
+			result = append(result, hit.PullRequestID)
+		}
+		return result
+	}
+
+	t.Run("ranks by relevance", func(t *testing.T) {
+		hits, err := repo.Search(ctx, repository.PRSearchQuery{Text: "payment retry"})
+
+		require.NoError(t, err)
+		require.Len(t, hits, 3)
+		assert.ElementsMatch(t, []string{"pr-1", "pr-2", "pr-4"}, ids(hits))
+		// pr-4 matches one word only
+		assert.Equal(t, "pr-4", hits[2].PullRequestID)
+		assert.Greater(t, hits[0].Score, hits[2].Score)
+		assert.Equal(t, "u3", hits[2].AuthorID)
+		assert.Equal(t, domain.PRStatusOpen, hits[2].Status)
+	})
+
+	t.Run("pages continue after the last hit", func(t *testing.T) {
+		first, err := repo.Search(ctx, repository.PRSearchQuery{Text: "payment retry", Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+
+		last := first[1]
+		rest, err := repo.Search(ctx, repository.PRSearchQuery{
+			Text:       "payment retry",
+			AfterScore: last.Score,
+			AfterPRID:  last.PullRequestID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-4"}, ids(rest))
+	})
+
+	t.Run("filters by status", func(t *testing.T) {
+		hits, err := repo.Search(ctx, repository.PRSearchQuery{Text: "payment", Status: domain.PRStatusMerged})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-2"}, ids(hits))
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		hits, err := repo.Search(ctx, repository.PRSearchQuery{Text: "kubernetes"})
+
+		require.NoError(t, err)
+		assert.NotNil(t, hits)
+		assert.Empty(t, hits)
+	})
+}
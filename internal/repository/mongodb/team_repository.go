@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -73,3 +74,214 @@ func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, err
 
 	return count > 0, nil
 }
+
+// membersLookup fills "members" with the users of the team ordered by user_id
+func membersLookup() bson.D {
+	return bson.D{{Key: "$lookup", Value: bson.M{
+		"from":         usersCollection,
+		"localField":   "team_name",
+		"foreignField": "team_name",
+		"pipeline": bson.A{
+			bson.M{"$sort": bson.M{"user_id": 1}},
+			bson.M{"$project": bson.M{
+				"_id":              0,
+				"user_id":          1,
+				"username":         1,
+				"is_active":        1,
+				"max_open_reviews": 1,
+				"email":            1,
+			}},
+		},
+		"as": "members",
+	}}}
+}
+
+// teamWithMembers decodes a team document extended by membersLookup
+type teamWithMembers struct {
+	domain.Team `bson:",inline"`
+	Members     []domain.TeamMember `bson:"members"`
+}
+
+func (t *teamWithMembers) team() *domain.Team {
+	team := t.Team
+	team.Members = t.Members
+	return &team
+}
+
+func (r *TeamRepository) GetWithMembers(ctx context.Context, teamName string) (*domain.Team, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"team_name": teamName}}},
+		{{Key: "$limit", Value: 1}},
+		membersLookup(),
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("failed to get team with members", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get team with members: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	var rows []*teamWithMembers
+	if err := cursor.All(ctx, &rows); err != nil {
+		r.logger.Error("failed to decode team with members", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to decode team with members: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	return rows[0].team(), nil
+}
+
+func (r *TeamRepository) List(ctx context.Context, query repository.TeamListQuery) ([]*domain.Team, error) {
+	order := keyset{sortField: "team_name", idField: "team_name", descending: query.Descending}
+
+	filter := bson.M{}
+	if query.AfterTeamName != "" {
+		filter = order.after(query.AfterTeamName, query.AfterTeamName)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: order.sort()}},
+	}
+	if query.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: query.Limit}})
+	}
+	// members are looked up for the page only
+	pipeline = append(pipeline, membersLookup())
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("failed to list teams", zap.Error(err))
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	var rows []*teamWithMembers
+	if err := cursor.All(ctx, &rows); err != nil {
+		r.logger.Error("failed to decode teams", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode teams: %w", err)
+	}
+
+	teams := make([]*domain.Team, 0, len(rows))
+	for _, row := range rows {
+		teams = append(teams, row.team())
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) ListNames(ctx context.Context) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "team_name", bson.M{})
+	if err != nil {
+		r.logger.Error("failed to list team names", zap.Error(err))
+		return nil, fmt.Errorf("failed to list team names: %w", err)
+	}
+
+	return distinctStrings(values), nil
+}
+
+// Rename changes the team name and its mentions in fallback_teams. The two writes are kept together
+// by the unit of work of the caller.
+func (r *TeamRepository) Rename(ctx context.Context, oldName, newName string) error {
+	filter := bson.M{"team_name": oldName}
+	update := bson.M{"$set": bson.M{"team_name": newName}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrTeamExists
+		}
+
+		r.logger.Error("failed to rename team", zap.Error(err), zap.String("team_name", oldName))
+		return fmt.Errorf("failed to rename team: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	filter = bson.M{"fallback_teams": oldName}
+	update = bson.M{"$set": bson.M{"fallback_teams.$": newName}}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		r.logger.Error("failed to rename fallback team", zap.Error(err), zap.String("team_name", oldName))
+		return fmt.Errorf("failed to rename fallback team: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the team and its mentions in fallback_teams. The two writes are kept together
+// by the unit of work of the caller.
+func (r *TeamRepository) Delete(ctx context.Context, teamName string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"team_name": teamName})
+	if err != nil {
+		r.logger.Error("failed to delete team", zap.Error(err), zap.String("team_name", teamName))
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	filter := bson.M{"fallback_teams": teamName}
+	update := bson.M{"$pull": bson.M{"fallback_teams": teamName}}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		r.logger.Error("failed to drop deleted fallback team", zap.Error(err), zap.String("team_name", teamName))
+		return fmt.Errorf("failed to drop deleted fallback team: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) ListLegacyMembers(ctx context.Context) (map[string][]domain.TeamMember, error) {
+	filter := bson.M{"members": bson.M{"$exists": true}}
+	opts := options.Find().SetProjection(bson.M{"team_name": 1, "members": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to find legacy team members", zap.Error(err))
+		return nil, fmt.Errorf("failed to find legacy team members: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		TeamName string              `bson:"team_name"`
+		Members  []domain.TeamMember `bson:"members"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		r.logger.Error("failed to decode legacy team members", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode legacy team members: %w", err)
+	}
+
+	members := make(map[string][]domain.TeamMember, len(rows))
+	for _, row := range rows {
+		members[row.TeamName] = row.Members
+	}
+
+	return members, nil
+}
+
+func (r *TeamRepository) DropLegacyMembers(ctx context.Context, teamName string) error {
+	filter := bson.M{"team_name": teamName}
+	update := bson.M{"$unset": bson.M{"members": ""}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to drop legacy team members", zap.Error(err), zap.String("team_name", teamName))
+		return fmt.Errorf("failed to drop legacy team members: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	return nil
+}
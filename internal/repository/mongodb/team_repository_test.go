@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -116,7 +117,7 @@ func TestTeamRepositoryGetByName(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, "team-1", result.TeamName)
-		assert.Len(t, result.Members, 1)
+		assert.Empty(t, result.Members, "members are read from users, not stored in the team")
 	})
 
 	t.Run("team not found", func(t *testing.T) {
@@ -296,7 +297,7 @@ func TestTeamRepositoryEdgeCases(t *testing.T) {
 		result, err := repo.GetByName(ctx, "")
 		assert.NoError(t, err)
 		assert.Equal(t, "", result.TeamName)
-		assert.Len(t, result.Members, 1)
+		assert.Empty(t, result.Members)
 
 		exists, err := repo.Exists(ctx, "")
 		assert.NoError(t, err)
@@ -335,19 +336,22 @@ func TestTeamRepositoryEdgeCases(t *testing.T) {
 
 		repo := NewTeamRepository(client, logger)
 
-		team := &domain.Team{
-			TeamName: "inactive-team",
-			Members: []domain.TeamMember{
-				{UserID: "user-1", Username: "user1", IsActive: false},
-				{UserID: "user-2", Username: "user2", IsActive: true},
-				{UserID: "user-3", Username: "user3", IsActive: false},
-			},
-		}
+		userRepo := NewUserRepository(client, logger)
+
+		team := &domain.Team{TeamName: "inactive-team"}
 
 		err := repo.Create(ctx, team)
 		assert.NoError(t, err)
 
-		result, err := repo.GetByName(ctx, "inactive-team")
+		for _, user := range []*domain.User{
+			{UserID: "user-3", Username: "user3", TeamName: "inactive-team", IsActive: false},
+			{UserID: "user-1", Username: "user1", TeamName: "inactive-team", IsActive: false},
+			{UserID: "user-2", Username: "user2", TeamName: "inactive-team", IsActive: true},
+		} {
+			require.NoError(t, userRepo.CreateOrUpdate(ctx, user))
+		}
+
+		result, err := repo.GetWithMembers(ctx, "inactive-team")
 		assert.NoError(t, err)
 		assert.Equal(t, "inactive-team", result.TeamName)
 		assert.Len(t, result.Members, 3)
@@ -438,3 +442,327 @@ func TestTeamRepositoryContextErrors(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestTeamRepositoryGetWithMembers(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	t.Run("members come from users ordered by user_id", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+		userRepo := NewUserRepository(client, logger)
+
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-1", ReviewersCount: 3}))
+		require.NoError(t, userRepo.CreateOrUpdate(ctx, &domain.User{UserID: "user-2", Username: "user2", TeamName: "team-1", IsActive: true, Email: "b@example.com"}))
+		require.NoError(t, userRepo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", Username: "user1", TeamName: "team-1", MaxOpenReviews: 2}))
+		require.NoError(t, userRepo.CreateOrUpdate(ctx, &domain.User{UserID: "user-3", Username: "user3", TeamName: "team-2", IsActive: true}))
+
+		team, err := repo.GetWithMembers(ctx, "team-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, team.ReviewersCount)
+		assert.Equal(t, []domain.TeamMember{
+			{UserID: "user-1", Username: "user1", MaxOpenReviews: 2},
+			{UserID: "user-2", Username: "user2", IsActive: true, Email: "b@example.com"},
+		}, team.Members)
+	})
+
+	t.Run("team without users", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-1"}))
+
+		team, err := repo.GetWithMembers(ctx, "team-1")
+
+		require.NoError(t, err)
+		assert.NotNil(t, team.Members)
+		assert.Empty(t, team.Members)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+
+		team, err := repo.GetWithMembers(ctx, "missing")
+
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+		assert.Nil(t, team)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewTeamRepository(closedClient, logger)
+
+		team, err := badRepo.GetWithMembers(ctx, "team-1")
+
+		assert.Error(t, err)
+		assert.Nil(t, team)
+		assert.Contains(t, err.Error(), "failed to get team with members")
+	})
+}
+
+func TestTeamRepositoryListNames(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		t.Skip("MongoDB not available")
+	}
+	defer cleanup()
+
+	repo := NewTeamRepository(client, logger)
+	require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-1"}))
+	require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-2"}))
+
+	names, err := repo.ListNames(ctx)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"team-1", "team-2"}, names)
+}
+
+func TestTeamRepositoryLegacyMembers(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	t.Run("lists and drops members arrays", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-new"}))
+
+		// documents written before the roster moved to users
+		_, err := client.Database().Collection(teamsCollection).InsertOne(ctx, bson.M{
+			"team_name": "team-old",
+			"members":   bson.A{bson.M{"user_id": "user-1", "username": "user1", "is_active": true}},
+		})
+		require.NoError(t, err)
+
+		legacy, err := repo.ListLegacyMembers(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]domain.TeamMember{
+			"team-old": {{UserID: "user-1", Username: "user1", IsActive: true}},
+		}, legacy)
+
+		require.NoError(t, repo.DropLegacyMembers(ctx, "team-old"))
+
+		legacy, err = repo.ListLegacyMembers(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, legacy)
+
+		exists, err := repo.Exists(ctx, "team-old")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("drop on unknown team", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+
+		err := repo.DropLegacyMembers(ctx, "missing")
+
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+}
+
+func TestTeamRepositoryRename(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	t.Run("renames team and fallback references", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-1", Members: []domain.TeamMember{}}))
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-2", Members: []domain.TeamMember{}, FallbackTeams: []string{"team-3", "team-1"}}))
+
+		err := repo.Rename(ctx, "team-1", "team-renamed")
+		require.NoError(t, err)
+
+		exists, err := repo.Exists(ctx, "team-1")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		_, err = repo.GetByName(ctx, "team-renamed")
+		assert.NoError(t, err)
+
+		other, err := repo.GetByName(ctx, "team-2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-3", "team-renamed"}, other.FallbackTeams)
+	})
+
+	t.Run("new name taken", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-1", Members: []domain.TeamMember{}}))
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-2", Members: []domain.TeamMember{}}))
+
+		err := repo.Rename(ctx, "team-1", "team-2")
+
+		assert.Equal(t, domain.ErrTeamExists, err)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+
+		err := repo.Rename(ctx, "missing", "team-2")
+
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+}
+
+func TestTeamRepositoryDelete(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	t.Run("deletes team and fallback references", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-1", Members: []domain.TeamMember{}}))
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "team-2", Members: []domain.TeamMember{}, FallbackTeams: []string{"team-1", "team-3"}}))
+
+		err := repo.Delete(ctx, "team-1")
+		require.NoError(t, err)
+
+		_, err = repo.GetByName(ctx, "team-1")
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+
+		other, err := repo.GetByName(ctx, "team-2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-3"}, other.FallbackTeams)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewTeamRepository(client, logger)
+
+		err := repo.Delete(ctx, "missing")
+
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewTeamRepository(closedClient, logger)
+
+		err := badRepo.Delete(ctx, "team-1")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete team")
+	})
+}
+
+func TestTeamRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		t.Skip("MongoDB not available")
+	}
+	defer cleanup()
+
+	repo := NewTeamRepository(client, logger)
+	userRepo := NewUserRepository(client, logger)
+	for _, name := range []string{"backend", "frontend", "mobile"} {
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: name}))
+	}
+	require.NoError(t, userRepo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", Username: "alice", TeamName: "frontend", IsActive: true}))
+
+	t.Run("pages in name order with members", func(t *testing.T) {
+		first, err := repo.List(ctx, repository.TeamListQuery{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		assert.Equal(t, "backend", first[0].TeamName)
+		assert.Empty(t, first[0].Members)
+		assert.Equal(t, "frontend", first[1].TeamName)
+		assert.Equal(t, []domain.TeamMember{{UserID: "user-1", Username: "alice", IsActive: true}}, first[1].Members)
+
+		second, err := repo.List(ctx, repository.TeamListQuery{AfterTeamName: "frontend", Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.Equal(t, "mobile", second[0].TeamName)
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		teams, err := repo.List(ctx, repository.TeamListQuery{Descending: true, AfterTeamName: "mobile"})
+
+		require.NoError(t, err)
+		require.Len(t, teams, 2)
+		assert.Equal(t, "frontend", teams[0].TeamName)
+		assert.Equal(t, "backend", teams[1].TeamName)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		teams, err := NewTeamRepository(closedClient, logger).List(ctx, repository.TeamListQuery{})
+
+		assert.Error(t, err)
+		assert.Nil(t, teams)
+		assert.Contains(t, err.Error(), "failed to list teams")
+	})
+}
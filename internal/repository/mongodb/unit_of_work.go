@@ -0,0 +1,62 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// UnitOfWork runs the work in a multi-document transaction of a session, the repositories
+// take part in it through the session ctx. A standalone server has no transactions, the client
+// connects to it only when allowed explicitly, and there the work runs as is.
+type UnitOfWork struct {
+	client       *mongo.Client
+	transactions bool
+	logger       *zap.Logger
+}
+
+func NewUnitOfWork(client *Client, logger *zap.Logger) *UnitOfWork {
+	return &UnitOfWork{
+		client:       client.Database().Client(),
+		transactions: client.transactions,
+		logger:       logger,
+	}
+}
+
+// Do runs fn in a transaction. MongoDB has no nested transactions, a Do within fn is part of
+// the outer one and its failure discards the whole transaction once it reaches the outer Do.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !u.transactions || mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := u.client.StartSession()
+	if err != nil {
+		u.logger.Error("failed to start session", zap.Error(err))
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(context.WithoutCancel(ctx))
+
+	return mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
+		if err := session.StartTransaction(); err != nil {
+			u.logger.Error("failed to start transaction", zap.Error(err))
+			return fmt.Errorf("failed to start transaction: %w", err)
+		}
+
+		if err := fn(sc); err != nil {
+			// the transaction is gone for good once the ctx is done, abort it anyway
+			if abortErr := session.AbortTransaction(context.WithoutCancel(sc)); abortErr != nil {
+				u.logger.Warn("failed to abort transaction", zap.Error(abortErr))
+			}
+			return err
+		}
+
+		if err := session.CommitTransaction(sc); err != nil {
+			u.logger.Error("failed to commit transaction", zap.Error(err))
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	})
+}
@@ -3,8 +3,10 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -27,8 +29,23 @@ func NewUserRepository(client *Client, logger *zap.Logger) *UserRepository {
 		Options: options.Index().SetUnique(true),
 	})
 
+	// team rosters are read by team_name ordered by user_id
 	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
-		Keys: bson.D{{Key: "team_name", Value: 1}},
+		Keys: bson.D{{Key: "team_name", Value: 1}, {Key: "user_id", Value: 1}},
+	})
+
+	// user lists sorted by username
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "username", Value: 1}, {Key: "user_id", Value: 1}},
+	})
+
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "absences.from", Value: 1}},
+	})
+
+	_, _ = collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetSparse(true),
 	})
 
 	return &UserRepository{
@@ -67,6 +84,30 @@ func (r *UserRepository) GetByID(ctx context.Context, userID string) (*domain.Us
 	return &user, nil
 }
 
+func (r *UserRepository) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	if len(userIDs) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	filter := bson.M{"user_id": bson.M{"$in": userIDs}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		r.logger.Error("failed to find users by IDs", zap.Error(err), zap.Int("users", len(userIDs)))
+		return nil, fmt.Errorf("failed to find users by IDs: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	users := []*domain.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		r.logger.Error("failed to decode users", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
 func (r *UserRepository) GetActiveByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
 	filter := bson.M{
 		"team_name": teamName,
@@ -107,6 +148,22 @@ func (r *UserRepository) UpdateIsActive(ctx context.Context, userID string, isAc
 	return nil
 }
 
+func (r *UserRepository) UpdateIsActiveMany(ctx context.Context, userIDs []string, isActive bool) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	filter := bson.M{"user_id": bson.M{"$in": userIDs}}
+	update := bson.M{"$set": bson.M{"is_active": isActive}}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		r.logger.Error("failed to update users is_active", zap.Error(err), zap.Int("users", len(userIDs)))
+		return fmt.Errorf("failed to update users is_active: %w", err)
+	}
+
+	return nil
+}
+
 func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
 	filter := bson.M{"team_name": teamName}
 
@@ -126,3 +183,252 @@ func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*dom
 
 	return users, nil
 }
+
+func (r *UserRepository) List(ctx context.Context, query repository.UserListQuery) ([]*domain.User, error) {
+	filter := bson.M{}
+	if query.TeamName != "" {
+		filter["team_name"] = query.TeamName
+	}
+	if query.IsActive != nil {
+		filter["is_active"] = *query.IsActive
+	}
+
+	order := keyset{sortField: query.SortBy, idField: "user_id", descending: query.Descending}
+	if query.AfterUserID != "" {
+		filter = pageFilter(filter, order.after(query.AfterValue, query.AfterUserID))
+	}
+
+	opts := options.Find().SetSort(order.sort())
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to list users", zap.Error(err), zap.String("team_name", query.TeamName))
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	users := []*domain.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		r.logger.Error("failed to decode users", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, userID string, update repository.UserProfileUpdate) error {
+	set := bson.M{}
+	if update.Username != nil {
+		set["username"] = *update.Username
+	}
+	if update.MaxOpenReviews != nil {
+		set["max_open_reviews"] = *update.MaxOpenReviews
+	}
+
+	changes := bson.M{}
+	switch {
+	case update.Email == nil:
+	case *update.Email == "":
+		// the email index is sparse, users without an email are left out of it
+		changes["$unset"] = bson.M{"email": ""}
+	default:
+		set["email"] = *update.Email
+	}
+	if len(set) > 0 {
+		changes["$set"] = set
+	}
+	if len(changes) == 0 {
+		_, err := r.GetByID(ctx, userID)
+		return err
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"user_id": userID}, changes)
+	if err != nil {
+		r.logger.Error("failed to update user profile", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update user profile: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateTeam(ctx context.Context, userID, teamName string) error {
+	filter := bson.M{"user_id": userID}
+	update := bson.M{"$set": bson.M{"team_name": teamName}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to update user team", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update user team: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) RenameTeam(ctx context.Context, oldName, newName string) error {
+	filter := bson.M{"team_name": oldName}
+	update := bson.M{"$set": bson.M{"team_name": newName}}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		r.logger.Error("failed to rename users team", zap.Error(err), zap.String("team_name", oldName))
+		return fmt.Errorf("failed to rename users team: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) ListTeamNames(ctx context.Context) ([]string, error) {
+	filter := bson.M{"team_name": bson.M{"$nin": bson.A{"", nil}}}
+
+	values, err := r.collection.Distinct(ctx, "team_name", filter)
+	if err != nil {
+		r.logger.Error("failed to list users team names", zap.Error(err))
+		return nil, fmt.Errorf("failed to list users team names: %w", err)
+	}
+
+	return distinctStrings(values), nil
+}
+
+func (r *UserRepository) GetByEmails(ctx context.Context, emails []string) ([]*domain.User, error) {
+	if len(emails) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	filter := bson.M{"email": bson.M{"$in": emails}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		r.logger.Error("failed to find users by emails", zap.Error(err), zap.Int("emails", len(emails)))
+		return nil, fmt.Errorf("failed to find users by emails: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	users := []*domain.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		r.logger.Error("failed to decode users", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) AddAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	filter := bson.M{"user_id": userID}
+	update := bson.M{"$push": bson.M{"absences": absence}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to add absence", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to add absence: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) RemoveAbsence(ctx context.Context, userID, absenceID string) error {
+	filter := bson.M{"user_id": userID}
+	update := bson.M{"$pull": bson.M{"absences": bson.M{"absence_id": absenceID}}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to remove absence", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to remove absence: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+	if result.ModifiedCount == 0 {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) ListAbsenceHandoffs(ctx context.Context, at time.Time) ([]*domain.User, error) {
+	filter := bson.M{
+		"absences": bson.M{"$elemMatch": bson.M{
+			"handoff":       true,
+			"handed_off_at": bson.M{"$exists": false},
+			"from":          bson.M{"$lte": at},
+			"to":            bson.M{"$gt": at},
+		}},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		r.logger.Error("failed to find users with due absence handoffs", zap.Error(err))
+		return nil, fmt.Errorf("failed to find users with due absence handoffs: %w", err)
+	}
+	//nolint:errcheck
+	defer cursor.Close(ctx)
+
+	var users []*domain.User
+	if err := cursor.All(ctx, &users); err != nil {
+		r.logger.Error("failed to decode users", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	filter := bson.M{"user_id": userID, "absences.absence_id": absence.AbsenceID}
+	update := bson.M{"$set": bson.M{"absences.$": absence}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to update absence", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update absence: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) MarkAbsenceHandedOff(ctx context.Context, userID, absenceID string, at time.Time) error {
+	filter := bson.M{"user_id": userID, "absences.absence_id": absenceID}
+	update := bson.M{"$set": bson.M{"absences.$.handed_off_at": at}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to mark absence handed off", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to mark absence handed off: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+// distinctStrings keeps the string values of a Distinct result
+func distinctStrings(values []any) []string {
+	names := make([]string, 0, len(values))
+	for _, v := range values {
+		if name, ok := v.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
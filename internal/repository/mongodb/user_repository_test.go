@@ -3,8 +3,10 @@ package mongodb
 import (
 	"context"
 	"testing"
+	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -279,6 +281,52 @@ func TestUserRepositoryUpdateIsActive(t *testing.T) {
 	})
 }
 
+func TestUserRepositoryUpdateIsActiveMany(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewUserRepository(client, logger)
+
+	for _, id := range []string{"user-1", "user-2", "user-3"} {
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: id, TeamName: "team-1", IsActive: true}))
+	}
+
+	t.Run("updates only listed users", func(t *testing.T) {
+		err := repo.UpdateIsActiveMany(ctx, []string{"user-1", "user-2", "user-nonexistent"}, false)
+
+		require.NoError(t, err)
+
+		active, err := repo.GetActiveByTeam(ctx, "team-1")
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, "user-3", active[0].UserID)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		assert.NoError(t, repo.UpdateIsActiveMany(ctx, nil, false))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewUserRepository(closedClient, logger)
+
+		err := badRepo.UpdateIsActiveMany(ctx, []string{"user-1"}, true)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update users is_active")
+	})
+}
+
 func TestUserRepositoryGetByTeam(t *testing.T) {
 	ctx := context.Background()
 	logger := zaptest.NewLogger(t)
@@ -507,3 +555,412 @@ func TestUserRepositoryDecodeErrors(t *testing.T) {
 		assert.Nil(t, users)
 	})
 }
+
+func TestUserRepositoryGetByEmails(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewUserRepository(client, logger)
+
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", TeamName: "team-1", Email: "alice@example.com"}))
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-2", TeamName: "team-1", Email: "bob@example.com"}))
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-3", TeamName: "team-1"}))
+
+	t.Run("finds users by emails", func(t *testing.T) {
+		users, err := repo.GetByEmails(ctx, []string{"alice@example.com", "nobody@example.com"})
+
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "user-1", users[0].UserID)
+	})
+
+	t.Run("upsert without email keeps it", func(t *testing.T) {
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-2", Username: "bob", TeamName: "team-1"}))
+
+		user, err := repo.GetByID(ctx, "user-2")
+		require.NoError(t, err)
+		assert.Equal(t, "bob@example.com", user.Email)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		users, err := repo.GetByEmails(ctx, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewUserRepository(closedClient, logger)
+
+		_, err := badRepo.GetByEmails(ctx, []string{"alice@example.com"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to find users by emails")
+	})
+}
+
+func TestUserRepositoryAbsences(t *testing.T) {
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		return
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	repo := NewUserRepository(client, logger)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}))
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-2", TeamName: "team-1", IsActive: true}))
+
+	current := &domain.Absence{AbsenceID: "abs-1", From: now.Add(-time.Hour), To: now.Add(time.Hour), Handoff: true}
+	future := &domain.Absence{AbsenceID: "abs-2", From: now.Add(24 * time.Hour), To: now.Add(48 * time.Hour), Handoff: true}
+	noHandoff := &domain.Absence{AbsenceID: "abs-3", From: now.Add(-time.Hour), To: now.Add(time.Hour)}
+
+	t.Run("add absences", func(t *testing.T) {
+		require.NoError(t, repo.AddAbsence(ctx, "user-1", current))
+		require.NoError(t, repo.AddAbsence(ctx, "user-1", future))
+		require.NoError(t, repo.AddAbsence(ctx, "user-2", noHandoff))
+
+		user, err := repo.GetByID(ctx, "user-1")
+		require.NoError(t, err)
+		require.Len(t, user.Absences, 2)
+		assert.Equal(t, "abs-1", user.Absences[0].AbsenceID)
+		assert.True(t, user.Absences[0].From.Equal(current.From))
+	})
+
+	t.Run("add absence to unknown user", func(t *testing.T) {
+		err := repo.AddAbsence(ctx, "user-nonexistent", current)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("upsert keeps absences", func(t *testing.T) {
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", Username: "renamed", TeamName: "team-1", IsActive: true}))
+
+		user, err := repo.GetByID(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Len(t, user.Absences, 2)
+	})
+
+	t.Run("list and mark handoffs", func(t *testing.T) {
+		users, err := repo.ListAbsenceHandoffs(ctx, now)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "user-1", users[0].UserID)
+
+		require.NoError(t, repo.MarkAbsenceHandedOff(ctx, "user-1", "abs-1", now))
+
+		users, err = repo.ListAbsenceHandoffs(ctx, now)
+		require.NoError(t, err)
+		assert.Empty(t, users)
+
+		user, err := repo.GetByID(ctx, "user-1")
+		require.NoError(t, err)
+		require.NotNil(t, user.Absences[0].HandedOffAt)
+		assert.Nil(t, user.Absences[1].HandedOffAt)
+	})
+
+	t.Run("mark unknown absence", func(t *testing.T) {
+		err := repo.MarkAbsenceHandedOff(ctx, "user-1", "abs-nonexistent", now)
+
+		assert.ErrorIs(t, err, domain.ErrAbsenceNotFound)
+	})
+
+	t.Run("remove absence", func(t *testing.T) {
+		require.NoError(t, repo.RemoveAbsence(ctx, "user-1", "abs-2"))
+
+		user, err := repo.GetByID(ctx, "user-1")
+		require.NoError(t, err)
+		require.Len(t, user.Absences, 1)
+		assert.Equal(t, "abs-1", user.Absences[0].AbsenceID)
+
+		assert.ErrorIs(t, repo.RemoveAbsence(ctx, "user-1", "abs-2"), domain.ErrAbsenceNotFound)
+		assert.ErrorIs(t, repo.RemoveAbsence(ctx, "user-nonexistent", "abs-2"), domain.ErrUserNotFound)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewUserRepository(closedClient, logger)
+
+		_, err := badRepo.ListAbsenceHandoffs(ctx, now)
+		assert.Contains(t, err.Error(), "failed to find users with due absence handoffs")
+
+		err = badRepo.AddAbsence(ctx, "user-1", current)
+		assert.Contains(t, err.Error(), "failed to add absence")
+
+		err = badRepo.RemoveAbsence(ctx, "user-1", "abs-1")
+		assert.Contains(t, err.Error(), "failed to remove absence")
+
+		err = badRepo.MarkAbsenceHandedOff(ctx, "user-1", "abs-1", now)
+		assert.Contains(t, err.Error(), "failed to mark absence handed off")
+	})
+}
+
+func TestUserRepositoryUpdateTeam(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	t.Run("moves user and keeps other fields", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewUserRepository(client, logger)
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", Username: "user1", TeamName: "team-1", IsActive: true}))
+
+		err := repo.UpdateTeam(ctx, "user-1", "team-2")
+		require.NoError(t, err)
+
+		user, err := repo.GetByID(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, "team-2", user.TeamName)
+		assert.Equal(t, "user1", user.Username)
+		assert.True(t, user.IsActive)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewUserRepository(client, logger)
+
+		err := repo.UpdateTeam(ctx, "missing", "team-1")
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewUserRepository(closedClient, logger)
+
+		err := badRepo.UpdateTeam(ctx, "user-1", "team-1")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update user team")
+	})
+}
+
+func TestUserRepositoryRenameTeam(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	t.Run("renames only users of the team", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewUserRepository(client, logger)
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", TeamName: "team-1"}))
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-2", TeamName: "team-1"}))
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-3", TeamName: "team-2"}))
+
+		err := repo.RenameTeam(ctx, "team-1", "team-renamed")
+		require.NoError(t, err)
+
+		renamed, err := repo.GetByTeam(ctx, "team-renamed")
+		require.NoError(t, err)
+		assert.Len(t, renamed, 2)
+
+		other, err := repo.GetByTeam(ctx, "team-2")
+		require.NoError(t, err)
+		assert.Len(t, other, 1)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewUserRepository(closedClient, logger)
+
+		err := badRepo.RenameTeam(ctx, "team-1", "team-2")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to rename users team")
+	})
+}
+
+func TestUserRepositoryGetByIDs(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	t.Run("returns existing users only", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewUserRepository(client, logger)
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", TeamName: "team-1"}))
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-2", TeamName: "team-2"}))
+
+		users, err := repo.GetByIDs(ctx, []string{"user-1", "user-2", "missing"})
+
+		require.NoError(t, err)
+		assert.Len(t, users, 2)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		client, cleanup := setupTestDB(t)
+		if client == nil {
+			t.Skip("MongoDB not available")
+		}
+		defer cleanup()
+
+		repo := NewUserRepository(client, logger)
+
+		users, err := repo.GetByIDs(ctx, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		badRepo := NewUserRepository(closedClient, logger)
+
+		users, err := badRepo.GetByIDs(ctx, []string{"user-1"})
+
+		assert.Error(t, err)
+		assert.Nil(t, users)
+		assert.Contains(t, err.Error(), "failed to find users by IDs")
+	})
+}
+
+func TestUserRepositoryListTeamNames(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		t.Skip("MongoDB not available")
+	}
+	defer cleanup()
+
+	repo := NewUserRepository(client, logger)
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", TeamName: "team-1"}))
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-2", TeamName: "team-1"}))
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-3", TeamName: "team-2"}))
+	require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "user-4"}))
+
+	names, err := repo.ListTeamNames(ctx)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"team-1", "team-2"}, names)
+}
+
+func TestUserRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	client, cleanup := setupTestDB(t)
+	if client == nil {
+		t.Skip("MongoDB not available")
+	}
+	defer cleanup()
+
+	repo := NewUserRepository(client, logger)
+	for _, user := range []*domain.User{
+		{UserID: "user-1", Username: "carol", TeamName: "team-1", IsActive: true},
+		{UserID: "user-2", Username: "alice", TeamName: "team-1", IsActive: false},
+		{UserID: "user-3", Username: "bob", TeamName: "team-2", IsActive: true},
+		{UserID: "user-4", Username: "alice", TeamName: "team-1", IsActive: true},
+	} {
+		require.NoError(t, repo.CreateOrUpdate(ctx, user))
+	}
+
+	ids := func(users []*domain.User) []string {
+		result := make([]string, 0, len(users))
+		for _, user := range users {
+			result = append(result, user.UserID)
+		}
+		return result
+	}
+
+	t.Run("by username with ties by user_id", func(t *testing.T) {
+		first, err := repo.List(ctx, repository.UserListQuery{SortBy: domain.UserSortUsername, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-2", "user-4"}, ids(first))
+
+		second, err := repo.List(ctx, repository.UserListQuery{
+			SortBy:      domain.UserSortUsername,
+			AfterValue:  "alice",
+			AfterUserID: "user-4",
+			Limit:       2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-3", "user-1"}, ids(second))
+	})
+
+	t.Run("by user_id descending", func(t *testing.T) {
+		users, err := repo.List(ctx, repository.UserListQuery{
+			SortBy:      domain.UserSortID,
+			Descending:  true,
+			AfterValue:  "user-3",
+			AfterUserID: "user-3",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-2", "user-1"}, ids(users))
+	})
+
+	t.Run("filters by team and activity", func(t *testing.T) {
+		active := true
+		users, err := repo.List(ctx, repository.UserListQuery{TeamName: "team-1", IsActive: &active, SortBy: domain.UserSortID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-1", "user-4"}, ids(users))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		closedClient, _ := setupTestDB(t)
+		if closedClient == nil {
+			t.Skip("MongoDB not available")
+		}
+		closedClient.Close(ctx)
+
+		users, err := NewUserRepository(closedClient, logger).List(ctx, repository.UserListQuery{SortBy: domain.UserSortID})
+
+		assert.Error(t, err)
+		assert.Nil(t, users)
+		assert.Contains(t, err.Error(), "failed to list users")
+	})
+}
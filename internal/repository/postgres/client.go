@@ -0,0 +1,57 @@
+// Package postgres keeps the repositories data in PostgreSQL.
+// The schema is created and upgraded by the migrations in migrations/ when the client connects.
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type Client struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func NewClient(ctx context.Context, uri string, connectTimeout time.Duration, logger *zap.Logger) (*Client, error) {
+	pool, err := pgxpool.New(ctx, uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	if err := pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	}
+
+	version, err := migrate(ctx, pool, logger)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to migrate PostgreSQL schema: %w", err)
+	}
+
+	logger.Info("Connected to PostgreSQL successfully",
+		zap.String("database", pool.Config().ConnConfig.Database),
+		zap.Int("schema_version", version),
+		zap.Duration("connect_timeout", connectTimeout))
+
+	return &Client{
+		pool:   pool,
+		logger: logger,
+	}, nil
+}
+
+func (c *Client) Pool() *pgxpool.Pool {
+	return c.pool
+}
+
+func (c *Client) Close(_ context.Context) error {
+	c.pool.Close()
+	return nil
+}
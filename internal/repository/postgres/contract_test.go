@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"testing"
+
+	"assignment-service/internal/repository"
+	"assignment-service/internal/repository/repotest"
+
+	"go.uber.org/zap"
+)
+
+func TestContract(t *testing.T) {
+	// every test case gets its own schema so that data never leaks between cases
+	repotest.Run(t, func(t *testing.T) repository.Repositories {
+		client := setupTestDB(t)
+		logger := zap.NewNop()
+
+		return repository.Repositories{
+			Users:      NewUserRepository(client, logger),
+			Teams:      NewTeamRepository(client, logger),
+			PRs:        NewPRRepository(client, logger),
+			PREvents:   NewPREventRepository(client, logger),
+			UnitOfWork: NewUnitOfWork(client),
+		}
+	})
+}
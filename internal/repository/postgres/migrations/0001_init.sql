@@ -0,0 +1,78 @@
+CREATE TABLE users (
+    user_id          text PRIMARY KEY,
+    username         text NOT NULL DEFAULT '',
+    team_name        text NOT NULL DEFAULT '',
+    is_active        boolean NOT NULL DEFAULT false,
+    max_open_reviews integer NOT NULL DEFAULT 0,
+    email            text NOT NULL DEFAULT ''
+);
+
+-- team rosters are read by team_name ordered by user_id
+CREATE INDEX users_team_name_idx ON users (team_name, user_id);
+-- user lists sorted by username
+CREATE INDEX users_username_idx ON users (username, user_id);
+CREATE INDEX users_email_idx ON users (email) WHERE email <> '';
+
+CREATE TABLE absences (
+    user_id       text NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    absence_id    text NOT NULL,
+    -- keeps the order absences were added in
+    position      bigserial,
+    starts_at     timestamptz NOT NULL,
+    ends_at       timestamptz NOT NULL,
+    reason        text NOT NULL DEFAULT '',
+    handoff       boolean NOT NULL DEFAULT false,
+    handed_off_at timestamptz,
+    PRIMARY KEY (user_id, absence_id)
+);
+
+CREATE INDEX absences_due_handoff_idx ON absences (starts_at) WHERE handoff AND handed_off_at IS NULL;
+
+CREATE TABLE teams (
+    team_name          text PRIMARY KEY,
+    reviewers_count    integer NOT NULL DEFAULT 0,
+    min_reviewers      integer NOT NULL DEFAULT 0,
+    max_reviewers      integer NOT NULL DEFAULT 0,
+    fallback_teams     text[] NOT NULL DEFAULT '{}',
+    required_approvals integer NOT NULL DEFAULT 0
+);
+
+CREATE TABLE pull_requests (
+    pull_request_id    text PRIMARY KEY,
+    pull_request_name  text NOT NULL DEFAULT '',
+    author_id          text NOT NULL DEFAULT '',
+    status             text NOT NULL CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED')),
+    assigned_reviewers text[] NOT NULL DEFAULT '{}',
+    created_at         timestamptz,
+    merged_at          timestamptz,
+    closed_at          timestamptz,
+    required_reviewers integer NOT NULL DEFAULT 0,
+    under_staffed      boolean NOT NULL DEFAULT false,
+    fallback_team      text NOT NULL DEFAULT '',
+    overflow_team      text NOT NULL DEFAULT '',
+    reviews            jsonb
+);
+
+CREATE INDEX pull_requests_reviewers_idx ON pull_requests USING gin (assigned_reviewers);
+CREATE INDEX pull_requests_status_idx ON pull_requests (status);
+CREATE INDEX pull_requests_author_idx ON pull_requests (author_id, created_at);
+-- PR lists sorted by created_at
+CREATE INDEX pull_requests_created_at_idx ON pull_requests (created_at, pull_request_id);
+CREATE INDEX pull_requests_merged_at_idx ON pull_requests (merged_at) WHERE status = 'MERGED';
+-- text search on PR names, queries use the same expression
+CREATE INDEX pull_requests_name_search_idx ON pull_requests USING gin (to_tsvector('english', pull_request_name));
+
+CREATE TABLE pr_events (
+    id                   bigserial PRIMARY KEY,
+    pull_request_id      text NOT NULL,
+    type                 text NOT NULL,
+    at                   timestamptz NOT NULL,
+    reviewer_id          text NOT NULL DEFAULT '',
+    replaced_reviewer_id text NOT NULL DEFAULT '',
+    team_name            text NOT NULL DEFAULT '',
+    reason               text NOT NULL DEFAULT '',
+    review_state         text NOT NULL DEFAULT ''
+);
+
+-- PR history is read in the order events happened, the id keeps the append order for equal timestamps
+CREATE INDEX pr_events_pr_idx ON pr_events (pull_request_id, at, id);
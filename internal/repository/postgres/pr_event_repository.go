@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"assignment-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const prEventColumns = "pull_request_id, type, at, reviewer_id, replaced_reviewer_id, team_name, reason, review_state"
+
+type PREventRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func NewPREventRepository(client *Client, logger *zap.Logger) *PREventRepository {
+	return &PREventRepository{
+		pool:   client.Pool(),
+		logger: logger,
+	}
+}
+
+func (r *PREventRepository) Append(ctx context.Context, events ...*domain.PREvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	// one statement inserts the rows in order, the id keeps that order for equal timestamps
+	var p params
+	values := make([]string, 0, len(events))
+	for _, e := range events {
+		values = append(values, "("+strings.Join([]string{
+			p.add(e.PullRequestID), p.add(e.Type), p.add(e.At), p.add(e.ReviewerID),
+			p.add(e.ReplacedReviewerID), p.add(e.TeamName), p.add(e.Reason), p.add(e.ReviewState),
+		}, ", ")+")")
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, "INSERT INTO pr_events ("+prEventColumns+") VALUES "+strings.Join(values, ", "), p...)
+	if err != nil {
+		r.logger.Error("failed to append PR events", zap.Error(err), zap.String("pr_id", events[0].PullRequestID))
+		return fmt.Errorf("failed to append PR events: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PREventRepository) ListByPR(ctx context.Context, prID string) ([]*domain.PREvent, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, "SELECT "+prEventColumns+" FROM pr_events WHERE pull_request_id = $1 ORDER BY at, id", prID)
+	if err != nil {
+		r.logger.Error("failed to find PR events", zap.Error(err), zap.String("pr_id", prID))
+		return nil, fmt.Errorf("failed to find PR events: %w", err)
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.PREvent, error) {
+		var e domain.PREvent
+		err := row.Scan(&e.PullRequestID, &e.Type, &e.At, &e.ReviewerID, &e.ReplacedReviewerID, &e.TeamName, &e.Reason, &e.ReviewState)
+		e.At = e.At.UTC()
+		return &e, err
+	})
+	if err != nil {
+		r.logger.Error("failed to decode PR events", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PR events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,503 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const prColumns = `pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at, merged_at, closed_at,
+	required_reviewers, under_staffed, fallback_team, overflow_team, reviews`
+
+// prNameVector is the text search document of a PR, it matches the expression of pull_requests_name_search_idx
+const prNameVector = "to_tsvector('english', pull_request_name)"
+
+type PRRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func NewPRRepository(client *Client, logger *zap.Logger) *PRRepository {
+	return &PRRepository{
+		pool:   client.Pool(),
+		logger: logger,
+	}
+}
+
+func (r *PRRepository) Create(ctx context.Context, pr *domain.PullRequest) error {
+	args, err := prArgs(pr)
+	if err != nil {
+		return fmt.Errorf("failed to encode PR: %w", err)
+	}
+
+	_, err = conn(ctx, r.pool).Exec(ctx, "INSERT INTO pull_requests ("+prColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)", args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrPRExists
+		}
+
+		r.logger.Error("failed to create PR", zap.Error(err), zap.String("pr_id", pr.PullRequestID))
+		return fmt.Errorf("failed to create PR: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PRRepository) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := scanPR(conn(ctx, r.pool).QueryRow(ctx, "SELECT "+prColumns+" FROM pull_requests WHERE pull_request_id = $1", prID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrPRNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, fmt.Errorf("failed to get PR: %w", err)
+	}
+
+	return pr, nil
+}
+
+// Update replaces the stored PR, a PR that does not exist is ignored as in the mongodb repository
+func (r *PRRepository) Update(ctx context.Context, pr *domain.PullRequest) error {
+	args, err := prArgs(pr)
+	if err != nil {
+		return fmt.Errorf("failed to encode PR: %w", err)
+	}
+
+	_, err = conn(ctx, r.pool).Exec(ctx, `UPDATE pull_requests SET
+			pull_request_name = $2, author_id = $3, status = $4, assigned_reviewers = $5,
+			created_at = $6, merged_at = $7, closed_at = $8,
+			required_reviewers = $9, under_staffed = $10, fallback_team = $11, overflow_team = $12, reviews = $13
+		WHERE pull_request_id = $1`, args...)
+	if err != nil {
+		r.logger.Error("failed to update PR", zap.Error(err), zap.String("pr_id", pr.PullRequestID))
+		return fmt.Errorf("failed to update PR: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PRRepository) Exists(ctx context.Context, prID string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.pool).QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pull_requests WHERE pull_request_id = $1)", prID).Scan(&exists)
+	if err != nil {
+		r.logger.Error("failed to check PR existence", zap.Error(err), zap.String("pr_id", prID))
+		return false, fmt.Errorf("failed to check PR existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *PRRepository) GetByReviewer(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
+	prs, err := r.find(ctx, "SELECT "+prColumns+" FROM pull_requests WHERE $1 = ANY(assigned_reviewers) ORDER BY pull_request_id", userID)
+	if err != nil {
+		r.logger.Error("failed to get PRs by reviewer", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get PRs by reviewer: %w", err)
+	}
+
+	return prs, nil
+}
+
+// GetOpenByTeam returns all OPEN PRs, the team is resolved by the caller as in the mongodb repository
+func (r *PRRepository) GetOpenByTeam(ctx context.Context, _ string) ([]*domain.PullRequest, error) {
+	prs, err := r.find(ctx, "SELECT "+prColumns+" FROM pull_requests WHERE status = $1 ORDER BY pull_request_id", domain.PRStatusOpen)
+	if err != nil {
+		r.logger.Error("failed to find open PRs", zap.Error(err))
+		return nil, fmt.Errorf("failed to find open PRs: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (r *PRRepository) List(ctx context.Context, query repository.PRListQuery) ([]*domain.PullRequest, error) {
+	if query.AuthorIDs != nil && len(query.AuthorIDs) == 0 {
+		return []*domain.PullRequest{}, nil
+	}
+
+	var p params
+	conditions := window(&p, "created_at", query.Created)
+	if query.AuthorIDs != nil {
+		conditions = append(conditions, "author_id = ANY("+p.add(query.AuthorIDs)+")")
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = "+p.add(query.Status))
+	}
+	if query.ReviewerID != "" {
+		conditions = append(conditions, p.add(query.ReviewerID)+" = ANY(assigned_reviewers)")
+	}
+
+	order := keyset{sortColumn: "pull_request_id", idColumn: "pull_request_id", descending: query.Descending}
+	if query.SortBy != domain.PRSortID {
+		order.sortColumn = "created_at"
+		order.nullable = true
+	}
+	if query.AfterPRID != "" {
+		var after any
+		if query.AfterCreatedAt != nil {
+			after = *query.AfterCreatedAt
+		}
+		conditions = append(conditions, order.after(&p, after, query.AfterPRID))
+	}
+
+	prs, err := r.find(ctx, "SELECT "+prColumns+" FROM pull_requests"+where(conditions)+order.orderBy()+limitClause(query.Limit), p...)
+	if err != nil {
+		r.logger.Error("failed to list PRs", zap.Error(err))
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (r *PRRepository) Search(ctx context.Context, query repository.PRSearchQuery) ([]*domain.PRSearchHit, error) {
+	var p params
+	tsQuery, ok := textSearchQuery(&p, query.Text)
+	if !ok {
+		return []*domain.PRSearchHit{}, nil
+	}
+
+	conditions := []string{prNameVector + " @@ q"}
+	if query.Status != "" {
+		conditions = append(conditions, "status = "+p.add(query.Status))
+	}
+
+	order := keyset{sortColumn: "score", idColumn: "pull_request_id", descending: true}
+	var after []string
+	if query.AfterPRID != "" {
+		after = append(after, order.after(&p, query.AfterScore, query.AfterPRID))
+	}
+
+	// the score is float8 in both the result and the keyset condition so that a page cursor compares exactly
+	sql := `SELECT pull_request_id, pull_request_name, author_id, status, score FROM (
+			SELECT pull_request_id, pull_request_name, author_id, status, ts_rank(` + prNameVector + `, q)::float8 AS score
+			FROM pull_requests, (SELECT ` + tsQuery + ` AS q) search` + where(conditions) + `
+		) hits` + where(after) + order.orderBy() + limitClause(query.Limit)
+
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, p...)
+	if err != nil {
+		r.logger.Error("failed to search PRs", zap.Error(err))
+		return nil, fmt.Errorf("failed to search PRs: %w", err)
+	}
+
+	hits, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.PRSearchHit, error) {
+		var hit domain.PRSearchHit
+		err := row.Scan(&hit.PullRequestID, &hit.PullRequestName, &hit.AuthorID, &hit.Status, &hit.Score)
+		return &hit, err
+	})
+	if err != nil {
+		r.logger.Error("failed to decode PR search hits", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PR search hits: %w", err)
+	}
+
+	return hits, nil
+}
+
+func (r *PRRepository) GetOpenByReviewers(ctx context.Context, userIDs []string) ([]*domain.PullRequest, error) {
+	if len(userIDs) == 0 {
+		return []*domain.PullRequest{}, nil
+	}
+
+	prs, err := r.find(ctx, "SELECT "+prColumns+" FROM pull_requests WHERE status = $1 AND assigned_reviewers && $2 ORDER BY pull_request_id",
+		domain.PRStatusOpen, userIDs)
+	if err != nil {
+		r.logger.Error("failed to get open PRs by reviewers", zap.Error(err))
+		return nil, fmt.Errorf("failed to get open PRs by reviewers: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (r *PRRepository) CountOpenByReviewers(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT reviewer_id, count(*) FROM pull_requests, unnest(assigned_reviewers) AS reviewer_id
+		WHERE status = $1 AND reviewer_id = ANY($2)
+		GROUP BY reviewer_id`, domain.PRStatusOpen, userIDs)
+	if err != nil {
+		r.logger.Error("failed to count open PRs by reviewers", zap.Error(err))
+		return nil, fmt.Errorf("failed to count open PRs by reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reviewerID string
+		var count int
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			r.logger.Error("failed to decode open PR counts", zap.Error(err))
+			return nil, fmt.Errorf("failed to decode open PR counts: %w", err)
+		}
+		counts[reviewerID] = count
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to decode open PR counts", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode open PR counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *PRRepository) ListUserStats(ctx context.Context, query repository.UserStatsQuery) ([]*domain.UserStats, error) {
+	var p params
+
+	// users without assignments come with zero counters, reviewers who are no longer registered users are dropped.
+	// DRAFT and CLOSED PRs have no reviewers.
+	join := []string{
+		"u.user_id = ANY(pr.assigned_reviewers)",
+		"pr.status IN ('" + string(domain.PRStatusOpen) + "', '" + string(domain.PRStatusMerged) + "')",
+	}
+	join = append(join, window(&p, "pr.created_at", query.Created)...)
+
+	var conditions []string
+	if query.TeamName != "" {
+		conditions = append(conditions, "u.team_name = "+p.add(query.TeamName))
+	}
+
+	sortColumn := domain.UserStatsSortAssigned
+	switch query.SortBy {
+	case domain.UserStatsSortOpen, domain.UserStatsSortMerged:
+		sortColumn = query.SortBy
+	}
+	order := keyset{sortColumn: sortColumn, idColumn: "user_id", descending: query.Descending}
+	var after []string
+	if query.AfterUserID != "" {
+		after = append(after, order.after(&p, query.AfterValue, query.AfterUserID))
+	}
+
+	sql := `SELECT user_id, username, team_name, assigned_count, open_pr_count, merged_pr_count FROM (
+			SELECT u.user_id, u.username, u.team_name,
+				count(pr.pull_request_id) AS assigned_count,
+				count(*) FILTER (WHERE pr.status = '` + string(domain.PRStatusOpen) + `') AS open_pr_count,
+				count(*) FILTER (WHERE pr.status = '` + string(domain.PRStatusMerged) + `') AS merged_pr_count
+			FROM users u LEFT JOIN pull_requests pr ON ` + strings.Join(join, " AND ") + where(conditions) + `
+			GROUP BY u.user_id
+		) stats` + where(after) + order.orderBy() + limitClause(query.Limit)
+
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, p...)
+	if err != nil {
+		r.logger.Error("failed to list user stats", zap.Error(err))
+		return nil, fmt.Errorf("failed to list user stats: %w", err)
+	}
+
+	stats, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.UserStats, error) {
+		var s domain.UserStats
+		err := row.Scan(&s.UserID, &s.Username, &s.TeamName, &s.AssignedCount, &s.OpenPRCount, &s.MergedPRCount)
+		return &s, err
+	})
+	if err != nil {
+		r.logger.Error("failed to decode user stats", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode user stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *PRRepository) CountByStatus(ctx context.Context, query repository.PRCountQuery) (map[domain.PRStatus]int, error) {
+	counts := make(map[domain.PRStatus]int)
+	if len(query.AuthorIDs) == 0 {
+		return counts, nil
+	}
+
+	var p params
+	conditions := append([]string{"author_id = ANY(" + p.add(query.AuthorIDs) + ")"}, window(&p, "created_at", query.Created)...)
+
+	rows, err := conn(ctx, r.pool).Query(ctx, "SELECT status, count(*) FROM pull_requests"+where(conditions)+" GROUP BY status", p...)
+	if err != nil {
+		r.logger.Error("failed to count PRs by status", zap.Error(err))
+		return nil, fmt.Errorf("failed to count PRs by status: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status domain.PRStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			r.logger.Error("failed to decode PR counts", zap.Error(err))
+			return nil, fmt.Errorf("failed to decode PR counts: %w", err)
+		}
+		counts[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to decode PR counts", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PR counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *PRRepository) ListMergeTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	var p params
+	conditions := append([]string{"pr.status = " + p.add(domain.PRStatusMerged)}, window(&p, "pr.merged_at", query.Window)...)
+
+	return r.listTimings(ctx, "NULL::timestamptz", "NULL::timestamptz", query.TeamName, conditions, p)
+}
+
+func (r *PRRepository) ListReassignmentTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	var p params
+	conditions := window(&p, "pr.created_at", query.Window)
+
+	// the reassignment events, legacy unassignments included, see domain.PREvent.IsReassignment
+	firstReassigned := `(SELECT min(e.at) FROM pr_events e
+		WHERE e.pull_request_id = pr.pull_request_id AND (
+			e.type = ` + p.add(domain.PREventReviewerReplaced) + ` OR
+			(e.type = ` + p.add(domain.PREventReviewerUnassigned) + ` AND e.reason = ` + p.add(domain.PREventReasonLegacyTeamReassignment) + `)
+		))`
+
+	return r.listTimings(ctx, firstReassigned, "NULL::timestamptz", query.TeamName, conditions, p)
+}
+
+func (r *PRRepository) ListReviewTimings(ctx context.Context, query repository.PRTimingQuery) ([]*domain.PRTiming, error) {
+	var p params
+	conditions := window(&p, "pr.created_at", query.Window)
+
+	firstReviewed := `(SELECT min(e.at) FROM pr_events e
+		WHERE e.pull_request_id = pr.pull_request_id AND e.type = ` + p.add(domain.PREventReviewSubmitted) + `)`
+
+	return r.listTimings(ctx, "NULL::timestamptz", firstReviewed, query.TeamName, conditions, p)
+}
+
+// listTimings selects PRs having created_at with the current team of the author, teamName filters by that team.
+// firstReassigned and firstReviewed are the SQL expressions of the event times.
+func (r *PRRepository) listTimings(ctx context.Context, firstReassigned, firstReviewed, teamName string, conditions []string, p params) ([]*domain.PRTiming, error) {
+	conditions = append(conditions, "pr.created_at IS NOT NULL")
+	if teamName != "" {
+		conditions = append(conditions, "u.team_name = "+p.add(teamName))
+	}
+
+	sql := `SELECT pr.pull_request_id, pr.author_id, COALESCE(u.team_name, ''), pr.created_at, pr.merged_at, ` + firstReassigned + `, ` + firstReviewed + `
+		FROM pull_requests pr LEFT JOIN users u ON u.user_id = pr.author_id` + where(conditions) + `
+		ORDER BY pr.pull_request_id`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, p...)
+	if err != nil {
+		r.logger.Error("failed to list PR timings", zap.Error(err))
+		return nil, fmt.Errorf("failed to list PR timings: %w", err)
+	}
+
+	timings, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.PRTiming, error) {
+		var t domain.PRTiming
+		if err := row.Scan(&t.PullRequestID, &t.AuthorID, &t.TeamName, &t.CreatedAt, &t.MergedAt, &t.FirstReassignedAt, &t.FirstReviewedAt); err != nil {
+			return nil, err
+		}
+		t.CreatedAt = t.CreatedAt.UTC()
+		t.MergedAt = utc(t.MergedAt)
+		t.FirstReassignedAt = utc(t.FirstReassignedAt)
+		t.FirstReviewedAt = utc(t.FirstReviewedAt)
+		return &t, nil
+	})
+	if err != nil {
+		r.logger.Error("failed to decode PR timings", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PR timings: %w", err)
+	}
+
+	return timings, nil
+}
+
+// find runs a query selecting prColumns, no rows give an empty slice
+func (r *PRRepository) find(ctx context.Context, sql string, args ...any) ([]*domain.PullRequest, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.PullRequest, error) {
+		return scanPR(row)
+	})
+}
+
+func scanPR(row pgx.Row) (*domain.PullRequest, error) {
+	var pr domain.PullRequest
+	var reviews []byte
+	err := row.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.AssignedReviewers,
+		&pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt,
+		&pr.RequiredReviewers, &pr.UnderStaffed, &pr.FallbackTeam, &pr.OverflowTeam, &reviews)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.CreatedAt = utc(pr.CreatedAt)
+	pr.MergedAt = utc(pr.MergedAt)
+	pr.ClosedAt = utc(pr.ClosedAt)
+
+	if reviews != nil {
+		if err := json.Unmarshal(reviews, &pr.Reviews); err != nil {
+			return nil, err
+		}
+		for i := range pr.Reviews {
+			pr.Reviews[i].UpdatedAt = utc(pr.Reviews[i].UpdatedAt)
+		}
+	}
+
+	return &pr, nil
+}
+
+// prArgs returns the values of prColumns
+func prArgs(pr *domain.PullRequest) ([]any, error) {
+	reviewers := pr.AssignedReviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+
+	// no reviews are stored as NULL and read back as nil
+	var reviews []byte
+	if len(pr.Reviews) > 0 {
+		var err error
+		if reviews, err = json.Marshal(pr.Reviews); err != nil {
+			return nil, err
+		}
+	}
+
+	return []any{
+		pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, reviewers, pr.CreatedAt, pr.MergedAt, pr.ClosedAt,
+		pr.RequiredReviewers, pr.UnderStaffed, pr.FallbackTeam, pr.OverflowTeam, reviews,
+	}, nil
+}
+
+// textSearchQuery turns a MongoDB $text style query into a tsquery expression:
+// any of the words, all the "quoted phrases" and none of the -excluded words.
+// It reports false when the query has nothing to look for.
+func textSearchQuery(p *params, text string) (string, bool) {
+	var terms, phrases, excluded []string
+
+	parts := strings.Split(text, `"`)
+	for i, part := range parts {
+		if i%2 == 1 {
+			if phrase := strings.TrimSpace(part); phrase != "" {
+				phrases = append(phrases, "phraseto_tsquery('english', "+p.add(phrase)+")")
+			}
+			continue
+		}
+
+		for _, word := range strings.Fields(part) {
+			if excludedWord, ok := strings.CutPrefix(word, "-"); ok {
+				if excludedWord != "" {
+					excluded = append(excluded, "!!plainto_tsquery('english', "+p.add(excludedWord)+")")
+				}
+				continue
+			}
+			terms = append(terms, "plainto_tsquery('english', "+p.add(word)+")")
+		}
+	}
+
+	if len(terms) == 0 && len(phrases) == 0 {
+		return "", false
+	}
+
+	var query []string
+	if len(terms) > 0 {
+		query = append(query, "("+strings.Join(terms, " || ")+")")
+	}
+	query = append(query, phrases...)
+	query = append(query, excluded...)
+
+	return strings.Join(query, " && "), true
+}
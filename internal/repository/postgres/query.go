@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"assignment-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SQLSTATE codes of constraint violations
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation
+}
+
+// querier runs statements on the pool or within a transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// beginner is a querier that starts transactions: the pool, or a transaction starting savepoints
+type beginner interface {
+	querier
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// conn returns the transaction of the unit of work ctx belongs to, or the pool outside of one
+func conn(ctx context.Context, pool *pgxpool.Pool) beginner {
+	if tx, ok := ctx.Value(unitOfWorkKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+// params collects the arguments of a query built piece by piece
+type params []any
+
+// add appends the argument and returns its placeholder
+func (p *params) add(value any) string {
+	*p = append(*p, value)
+	return "$" + strconv.Itoa(len(*p))
+}
+
+// where joins the conditions with AND, no conditions give an empty string
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+func limitClause(limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return " LIMIT " + strconv.Itoa(limit)
+}
+
+// keyset describes a list order: sortColumn in the requested direction, ties broken by idColumn ascending.
+// When sortColumn is idColumn the list is ordered by the id alone.
+type keyset struct {
+	sortColumn string
+	idColumn   string
+	descending bool
+	// the sort column may be NULL, NULLs go before any value as in MongoDB
+	nullable bool
+}
+
+func (k keyset) orderBy() string {
+	direction := " ASC"
+	nulls := ""
+	if k.descending {
+		direction = " DESC"
+	}
+	if k.nullable {
+		nulls = " NULLS FIRST"
+		if k.descending {
+			nulls = " NULLS LAST"
+		}
+	}
+	if k.sortColumn == k.idColumn {
+		return " ORDER BY " + k.idColumn + direction
+	}
+	return " ORDER BY " + k.sortColumn + direction + nulls + ", " + k.idColumn + " ASC"
+}
+
+// after matches rows following the one with the given sort value and id, a nil value stands for NULL
+func (k keyset) after(p *params, value any, id string) string {
+	next := " > "
+	if k.descending {
+		next = " < "
+	}
+	if k.sortColumn == k.idColumn {
+		return k.idColumn + next + p.add(id)
+	}
+
+	if value == nil {
+		sameValue := "(" + k.sortColumn + " IS NULL AND " + k.idColumn + " > " + p.add(id) + ")"
+		if k.descending {
+			return sameValue
+		}
+		return "(" + k.sortColumn + " IS NOT NULL OR " + sameValue + ")"
+	}
+
+	v := p.add(value)
+	sameValue := "(" + k.sortColumn + " = " + v + " AND " + k.idColumn + " > " + p.add(id) + ")"
+	if k.descending && k.nullable {
+		return "(" + k.sortColumn + next + v + " OR " + k.sortColumn + " IS NULL OR " + sameValue + ")"
+	}
+	return "(" + k.sortColumn + next + v + " OR " + sameValue + ")"
+}
+
+// window returns the conditions matching rows whose column is within the window.
+// NULL never matches a bounded window.
+func window(p *params, column string, w repository.TimeWindow) []string {
+	var conditions []string
+	if w.From != nil {
+		conditions = append(conditions, column+" >= "+p.add(*w.From))
+	}
+	if w.To != nil {
+		conditions = append(conditions, column+" < "+p.add(*w.To))
+	}
+	return conditions
+}
+
+// utc converts a time read from the database to UTC, the location the other backends return times in
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
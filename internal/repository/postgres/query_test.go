@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysetAfter(t *testing.T) {
+	at := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		order keyset
+		value any
+		want  string
+		args  []any
+	}{
+		{
+			"id only",
+			keyset{sortColumn: "team_name", idColumn: "team_name"},
+			nil,
+			"team_name > $1",
+			[]any{"pr-1"},
+		},
+		{
+			"id only descending",
+			keyset{sortColumn: "team_name", idColumn: "team_name", descending: true},
+			nil,
+			"team_name < $1",
+			[]any{"pr-1"},
+		},
+		{
+			"value",
+			keyset{sortColumn: "created_at", idColumn: "pull_request_id", nullable: true},
+			at,
+			"(created_at > $1 OR (created_at = $1 AND pull_request_id > $2))",
+			[]any{at, "pr-1"},
+		},
+		{
+			"value descending goes on to NULLs",
+			keyset{sortColumn: "created_at", idColumn: "pull_request_id", nullable: true, descending: true},
+			at,
+			"(created_at < $1 OR created_at IS NULL OR (created_at = $1 AND pull_request_id > $2))",
+			[]any{at, "pr-1"},
+		},
+		{
+			"NULL goes on to values",
+			keyset{sortColumn: "created_at", idColumn: "pull_request_id", nullable: true},
+			nil,
+			"(created_at IS NOT NULL OR (created_at IS NULL AND pull_request_id > $1))",
+			[]any{"pr-1"},
+		},
+		{
+			"NULL descending is the end of the list",
+			keyset{sortColumn: "created_at", idColumn: "pull_request_id", nullable: true, descending: true},
+			nil,
+			"(created_at IS NULL AND pull_request_id > $1)",
+			[]any{"pr-1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var p params
+			assert.Equal(t, tc.want, tc.order.after(&p, tc.value, "pr-1"))
+			assert.Equal(t, tc.args, []any(p))
+		})
+	}
+}
+
+func TestKeysetOrderBy(t *testing.T) {
+	assert.Equal(t, " ORDER BY user_id ASC", keyset{sortColumn: "user_id", idColumn: "user_id"}.orderBy())
+	assert.Equal(t, " ORDER BY created_at DESC NULLS LAST, pull_request_id ASC",
+		keyset{sortColumn: "created_at", idColumn: "pull_request_id", nullable: true, descending: true}.orderBy())
+	assert.Equal(t, " ORDER BY username ASC, user_id ASC", keyset{sortColumn: "username", idColumn: "user_id"}.orderBy())
+}
+
+func TestWindow(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	var p params
+	assert.Empty(t, window(&p, "created_at", repository.TimeWindow{}))
+	assert.Equal(t, []string{"created_at >= $1", "created_at < $2"}, window(&p, "created_at", repository.TimeWindow{From: &from, To: &to}))
+	assert.Equal(t, []any{from, to}, []any(p))
+}
+
+func TestTextSearchQuery(t *testing.T) {
+	var p params
+	query, ok := textSearchQuery(&p, `login "fix page" -redirect audit`)
+	assert.True(t, ok)
+	assert.Equal(t, "(plainto_tsquery('english', $1) || plainto_tsquery('english', $4))"+
+		" && phraseto_tsquery('english', $2) && !!plainto_tsquery('english', $3)", query)
+	assert.Equal(t, []any{"login", "fix page", "redirect", "audit"}, []any(p))
+
+	_, ok = textSearchQuery(&params{}, "-redirect")
+	assert.False(t, ok)
+	_, ok = textSearchQuery(&params{}, `"" -`)
+	assert.False(t, ok)
+}
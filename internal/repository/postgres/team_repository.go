@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const teamColumns = "team_name, reviewers_count, min_reviewers, max_reviewers, fallback_teams, required_approvals"
+
+type TeamRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func NewTeamRepository(client *Client, logger *zap.Logger) *TeamRepository {
+	return &TeamRepository{
+		pool:   client.Pool(),
+		logger: logger,
+	}
+}
+
+func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	fallbackTeams := team.FallbackTeams
+	if fallbackTeams == nil {
+		fallbackTeams = []string{}
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, "INSERT INTO teams ("+teamColumns+") VALUES ($1, $2, $3, $4, $5, $6)",
+		team.TeamName, team.ReviewersCount, team.MinReviewers, team.MaxReviewers, fallbackTeams, team.RequiredApprovals)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrTeamExists
+		}
+
+		r.logger.Error("failed to create team", zap.Error(err), zap.String("team_name", team.TeamName))
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	team, err := scanTeam(conn(ctx, r.pool).QueryRow(ctx, "SELECT "+teamColumns+" FROM teams WHERE team_name = $1", teamName))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrTeamNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get team", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	return team, nil
+}
+
+func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.pool).QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		r.logger.Error("failed to check team existence", zap.Error(err), zap.String("team_name", teamName))
+		return false, fmt.Errorf("failed to check team existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *TeamRepository) GetWithMembers(ctx context.Context, teamName string) (*domain.Team, error) {
+	team, err := r.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.fillMembers(ctx, []*domain.Team{team}); err != nil {
+		r.logger.Error("failed to get team members", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	return team, nil
+}
+
+func (r *TeamRepository) List(ctx context.Context, query repository.TeamListQuery) ([]*domain.Team, error) {
+	var p params
+	var conditions []string
+	order := keyset{sortColumn: "team_name", idColumn: "team_name", descending: query.Descending}
+	if query.AfterTeamName != "" {
+		conditions = append(conditions, order.after(&p, nil, query.AfterTeamName))
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, "SELECT "+teamColumns+" FROM teams"+where(conditions)+order.orderBy()+limitClause(query.Limit), p...)
+	if err != nil {
+		r.logger.Error("failed to list teams", zap.Error(err))
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	teams, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Team, error) {
+		return scanTeam(row)
+	})
+	if err != nil {
+		r.logger.Error("failed to decode teams", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode teams: %w", err)
+	}
+
+	// members are looked up for the page only
+	if err := r.fillMembers(ctx, teams); err != nil {
+		r.logger.Error("failed to get team members", zap.Error(err))
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) ListNames(ctx context.Context) ([]string, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, "SELECT team_name FROM teams ORDER BY team_name")
+	if err != nil {
+		r.logger.Error("failed to list team names", zap.Error(err))
+		return nil, fmt.Errorf("failed to list team names: %w", err)
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		r.logger.Error("failed to decode team names", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode team names: %w", err)
+	}
+
+	return names, nil
+}
+
+func (r *TeamRepository) Rename(ctx context.Context, oldName, newName string) error {
+	err := pgx.BeginFunc(ctx, conn(ctx, r.pool), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "UPDATE teams SET team_name = $2 WHERE team_name = $1", oldName, newName)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrTeamNotFound
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE teams SET fallback_teams = array_replace(fallback_teams, $1, $2) WHERE $1 = ANY(fallback_teams)", oldName, newName)
+		return err
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrTeamNotFound):
+		return err
+	case isUniqueViolation(err):
+		return domain.ErrTeamExists
+	default:
+		r.logger.Error("failed to rename team", zap.Error(err), zap.String("team_name", oldName))
+		return fmt.Errorf("failed to rename team: %w", err)
+	}
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, teamName string) error {
+	err := pgx.BeginFunc(ctx, conn(ctx, r.pool), func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "DELETE FROM teams WHERE team_name = $1", teamName)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrTeamNotFound
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE teams SET fallback_teams = array_remove(fallback_teams, $1) WHERE $1 = ANY(fallback_teams)", teamName)
+		return err
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrTeamNotFound):
+		return err
+	default:
+		r.logger.Error("failed to delete team", zap.Error(err), zap.String("team_name", teamName))
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+}
+
+// ListLegacyMembers returns nothing: the postgres schema has never kept rosters in teams
+func (r *TeamRepository) ListLegacyMembers(_ context.Context) (map[string][]domain.TeamMember, error) {
+	return map[string][]domain.TeamMember{}, nil
+}
+
+func (r *TeamRepository) DropLegacyMembers(ctx context.Context, teamName string) error {
+	exists, err := r.Exists(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	return nil
+}
+
+// fillMembers sets Members of the teams from their users ordered by user_id
+func (r *TeamRepository) fillMembers(ctx context.Context, teams []*domain.Team) error {
+	if len(teams) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]*domain.Team, len(teams))
+	names := make([]string, 0, len(teams))
+	for _, team := range teams {
+		team.Members = []domain.TeamMember{}
+		byName[team.TeamName] = team
+		names = append(names, team.TeamName)
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT team_name, user_id, username, is_active, max_open_reviews, email
+		FROM users WHERE team_name = ANY($1) ORDER BY user_id`, names)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamName string
+		var member domain.TeamMember
+		if err := rows.Scan(&teamName, &member.UserID, &member.Username, &member.IsActive, &member.MaxOpenReviews, &member.Email); err != nil {
+			return err
+		}
+		byName[teamName].Members = append(byName[teamName].Members, member)
+	}
+
+	return rows.Err()
+}
+
+func scanTeam(row pgx.Row) (*domain.Team, error) {
+	var team domain.Team
+	err := row.Scan(&team.TeamName, &team.ReviewersCount, &team.MinReviewers, &team.MaxReviewers, &team.FallbackTeams, &team.RequiredApprovals)
+	if err != nil {
+		return nil, err
+	}
+
+	// an empty list reads back as nil like an omitted field of the other backends
+	if len(team.FallbackTeams) == 0 {
+		team.FallbackTeams = nil
+	}
+
+	return &team, nil
+}
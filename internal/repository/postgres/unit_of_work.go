@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type unitOfWorkKey struct{}
+
+// UnitOfWork runs the work in a transaction, the repositories use it through the ctx
+type UnitOfWork struct {
+	pool *pgxpool.Pool
+}
+
+func NewUnitOfWork(client *Client) *UnitOfWork {
+	return &UnitOfWork{
+		pool: client.Pool(),
+	}
+}
+
+// Do runs fn in a transaction, a Do within fn runs in a savepoint of it
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return pgx.BeginFunc(ctx, conn(ctx, u.pool), func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, unitOfWorkKey{}, tx))
+	})
+}
@@ -0,0 +1,367 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// userColumns selects a user of "users u" with its absences in the order they were added
+const userColumns = `u.user_id, u.username, u.team_name, u.is_active, u.max_open_reviews, u.email,
+	(SELECT jsonb_agg(jsonb_build_object(
+		'absence_id', a.absence_id,
+		'from', a.starts_at,
+		'to', a.ends_at,
+		'reason', a.reason,
+		'handoff', a.handoff,
+		'handed_off_at', a.handed_off_at) ORDER BY a.position)
+	FROM absences a WHERE a.user_id = u.user_id) AS absences`
+
+type UserRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func NewUserRepository(client *Client, logger *zap.Logger) *UserRepository {
+	return &UserRepository{
+		pool:   client.Pool(),
+		logger: logger,
+	}
+}
+
+// CreateOrUpdate upserts the user. Like the mongodb repository it keeps the stored email when the new one is empty
+// and the stored absences when Absences is nil.
+func (r *UserRepository) CreateOrUpdate(ctx context.Context, user *domain.User) error {
+	err := pgx.BeginFunc(ctx, conn(ctx, r.pool), func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `INSERT INTO users (user_id, username, team_name, is_active, max_open_reviews, email)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (user_id) DO UPDATE SET
+				username = EXCLUDED.username,
+				team_name = EXCLUDED.team_name,
+				is_active = EXCLUDED.is_active,
+				max_open_reviews = EXCLUDED.max_open_reviews,
+				email = COALESCE(NULLIF(EXCLUDED.email, ''), users.email)`,
+			user.UserID, user.Username, user.TeamName, user.IsActive, user.MaxOpenReviews, user.Email)
+		if err != nil || user.Absences == nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, "DELETE FROM absences WHERE user_id = $1", user.UserID); err != nil {
+			return err
+		}
+		for i := range user.Absences {
+			if err := insertAbsence(ctx, tx, user.UserID, &user.Absences[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed to create or update user", zap.Error(err), zap.String("user_id", user.UserID))
+		return fmt.Errorf("failed to create or update user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE u.user_id = $1", userID)
+	if err != nil {
+		r.logger.Error("failed to get user by ID", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return users[0], nil
+}
+
+func (r *UserRepository) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	if len(userIDs) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE u.user_id = ANY($1) ORDER BY u.user_id", userIDs)
+	if err != nil {
+		r.logger.Error("failed to get users by IDs", zap.Error(err))
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) GetActiveByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE u.team_name = $1 AND u.is_active ORDER BY u.user_id", teamName)
+	if err != nil {
+		r.logger.Error("failed to get active users by team", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get active users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateIsActive(ctx context.Context, userID string, isActive bool) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, "UPDATE users SET is_active = $2 WHERE user_id = $1", userID, isActive)
+	if err != nil {
+		r.logger.Error("failed to update user is_active", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateIsActiveMany(ctx context.Context, userIDs []string, isActive bool) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, "UPDATE users SET is_active = $2 WHERE user_id = ANY($1)", userIDs, isActive); err != nil {
+		r.logger.Error("failed to update users is_active", zap.Error(err), zap.Int("count", len(userIDs)))
+		return fmt.Errorf("failed to update users: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE u.team_name = $1 ORDER BY u.user_id", teamName)
+	if err != nil {
+		r.logger.Error("failed to get users by team", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) List(ctx context.Context, query repository.UserListQuery) ([]*domain.User, error) {
+	var p params
+	var conditions []string
+	if query.TeamName != "" {
+		conditions = append(conditions, "u.team_name = "+p.add(query.TeamName))
+	}
+	if query.IsActive != nil {
+		conditions = append(conditions, "u.is_active = "+p.add(*query.IsActive))
+	}
+
+	sortColumn := "u.user_id"
+	if query.SortBy == domain.UserSortUsername {
+		sortColumn = "u.username"
+	}
+	order := keyset{sortColumn: sortColumn, idColumn: "u.user_id", descending: query.Descending}
+	if query.AfterUserID != "" {
+		conditions = append(conditions, order.after(&p, query.AfterValue, query.AfterUserID))
+	}
+
+	sql := "SELECT " + userColumns + " FROM users u" + where(conditions) + order.orderBy() + limitClause(query.Limit)
+	users, err := r.find(ctx, sql, p...)
+	if err != nil {
+		r.logger.Error("failed to list users", zap.Error(err))
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, userID string, update repository.UserProfileUpdate) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE users SET
+			username = COALESCE($2::text, username),
+			email = COALESCE($3::text, email),
+			max_open_reviews = COALESCE($4::integer, max_open_reviews)
+		WHERE user_id = $1`,
+		userID, update.Username, update.Email, update.MaxOpenReviews)
+	if err != nil {
+		r.logger.Error("failed to update user profile", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update user profile: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateTeam(ctx context.Context, userID, teamName string) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, "UPDATE users SET team_name = $2 WHERE user_id = $1", userID, teamName)
+	if err != nil {
+		r.logger.Error("failed to update user team", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update user team: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) RenameTeam(ctx context.Context, oldName, newName string) error {
+	if _, err := conn(ctx, r.pool).Exec(ctx, "UPDATE users SET team_name = $2 WHERE team_name = $1", oldName, newName); err != nil {
+		r.logger.Error("failed to rename team of users", zap.Error(err), zap.String("team_name", oldName))
+		return fmt.Errorf("failed to rename team of users: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) ListTeamNames(ctx context.Context) ([]string, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, "SELECT DISTINCT team_name FROM users WHERE team_name <> '' ORDER BY team_name")
+	if err != nil {
+		r.logger.Error("failed to list team names of users", zap.Error(err))
+		return nil, fmt.Errorf("failed to list team names: %w", err)
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		r.logger.Error("failed to decode team names", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode team names: %w", err)
+	}
+
+	return names, nil
+}
+
+func (r *UserRepository) GetByEmails(ctx context.Context, emails []string) ([]*domain.User, error) {
+	if len(emails) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE u.email <> '' AND u.email = ANY($1) ORDER BY u.user_id", emails)
+	if err != nil {
+		r.logger.Error("failed to get users by emails", zap.Error(err))
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) AddAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	err := insertAbsence(ctx, conn(ctx, r.pool), userID, absence)
+	if isForeignKeyViolation(err) {
+		return domain.ErrUserNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to add absence", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to add absence: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) RemoveAbsence(ctx context.Context, userID, absenceID string) error {
+	var userExists, removed bool
+	err := conn(ctx, r.pool).QueryRow(ctx, `WITH removed AS (
+			DELETE FROM absences WHERE user_id = $1 AND absence_id = $2 RETURNING 1
+		)
+		SELECT EXISTS (SELECT 1 FROM users WHERE user_id = $1), EXISTS (SELECT 1 FROM removed)`,
+		userID, absenceID).Scan(&userExists, &removed)
+	if err != nil {
+		r.logger.Error("failed to remove absence", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to remove absence: %w", err)
+	}
+
+	if !userExists {
+		return domain.ErrUserNotFound
+	}
+	if !removed {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) ListAbsenceHandoffs(ctx context.Context, at time.Time) ([]*domain.User, error) {
+	users, err := r.find(ctx, `SELECT `+userColumns+` FROM users u
+		WHERE EXISTS (
+			SELECT 1 FROM absences a
+			WHERE a.user_id = u.user_id AND a.handoff AND a.handed_off_at IS NULL AND a.starts_at <= $1 AND a.ends_at > $1
+		)
+		ORDER BY u.user_id`, at)
+	if err != nil {
+		r.logger.Error("failed to find users with due absence handoffs", zap.Error(err))
+		return nil, fmt.Errorf("failed to find users with due absence handoffs: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE absences
+		SET starts_at = $3, ends_at = $4, reason = $5, handoff = $6, handed_off_at = $7
+		WHERE user_id = $1 AND absence_id = $2`,
+		userID, absence.AbsenceID, absence.From, absence.To, absence.Reason, absence.Handoff, absence.HandedOffAt)
+	if err != nil {
+		r.logger.Error("failed to update absence", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update absence: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) MarkAbsenceHandedOff(ctx context.Context, userID, absenceID string, at time.Time) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, "UPDATE absences SET handed_off_at = $3 WHERE user_id = $1 AND absence_id = $2", userID, absenceID, at)
+	if err != nil {
+		r.logger.Error("failed to mark absence handed off", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to mark absence handed off: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+// find runs a query selecting userColumns, no rows give an empty slice
+func (r *UserRepository) find(ctx context.Context, sql string, args ...any) ([]*domain.User, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.User, error) {
+		var user domain.User
+		var absences []byte
+		err := row.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews, &user.Email, &absences)
+		if err != nil {
+			return nil, err
+		}
+
+		if absences != nil {
+			if err := json.Unmarshal(absences, &user.Absences); err != nil {
+				return nil, err
+			}
+			for i := range user.Absences {
+				a := &user.Absences[i]
+				a.From = a.From.UTC()
+				a.To = a.To.UTC()
+				a.HandedOffAt = utc(a.HandedOffAt)
+			}
+		}
+
+		return &user, nil
+	})
+}
+
+func insertAbsence(ctx context.Context, db querier, userID string, absence *domain.Absence) error {
+	_, err := db.Exec(ctx, `INSERT INTO absences (user_id, absence_id, starts_at, ends_at, reason, handoff, handed_off_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		userID, absence.AbsenceID, absence.From, absence.To, absence.Reason, absence.Handoff, absence.HandedOffAt)
+	return err
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"assignment-service/internal/domain"
+)
+
+type PREventRepository interface {
+	Append(ctx context.Context, events ...*domain.PREvent) error
+
+	// ListByPR returns the PR events in the order they happened
+	ListByPR(ctx context.Context, prID string) ([]*domain.PREvent, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"assignment-service/internal/domain"
 )
@@ -17,5 +18,105 @@ type PRRepository interface {
 
 	GetByReviewer(ctx context.Context, userID string) ([]*domain.PullRequest, error)
 
+	// GetOpenByTeam returns all OPEN PRs, the team is resolved by the caller
 	GetOpenByTeam(ctx context.Context, teamName string) ([]*domain.PullRequest, error)
+
+	// List returns a page of PRs
+	List(ctx context.Context, query PRListQuery) ([]*domain.PullRequest, error)
+
+	// Search returns a page of PRs matching the text query, best matches first
+	Search(ctx context.Context, query PRSearchQuery) ([]*domain.PRSearchHit, error)
+
+	// GetOpenByReviewers returns OPEN PRs that have at least one of the reviewers assigned
+	GetOpenByReviewers(ctx context.Context, userIDs []string) ([]*domain.PullRequest, error)
+
+	// CountOpenByReviewers returns number of OPEN PRs per reviewer, reviewers without open PRs are omitted
+	CountOpenByReviewers(ctx context.Context, userIDs []string) (map[string]int, error)
+
+	// ListUserStats returns review counters of users, including users without assignments
+	ListUserStats(ctx context.Context, query UserStatsQuery) ([]*domain.UserStats, error)
+
+	// CountByStatus returns number of PRs per status, statuses without PRs are omitted
+	CountByStatus(ctx context.Context, query PRCountQuery) (map[domain.PRStatus]int, error)
+
+	// ListMergeTimings returns merged PRs with merged_at within the window
+	ListMergeTimings(ctx context.Context, query PRTimingQuery) ([]*domain.PRTiming, error)
+
+	// ListReassignmentTimings returns PRs created within the window with the time of their first reviewer reassignment, if any
+	ListReassignmentTimings(ctx context.Context, query PRTimingQuery) ([]*domain.PRTiming, error)
+
+	// ListReviewTimings returns PRs created within the window with the time of their first submitted review, if any
+	ListReviewTimings(ctx context.Context, query PRTimingQuery) ([]*domain.PRTiming, error)
+}
+
+// TimeWindow limits PRs by one of their timestamps: From is inclusive, To is exclusive, nil means unbounded
+type TimeWindow struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// PRCountQuery selects PRs of the given authors created within the window
+type PRCountQuery struct {
+	AuthorIDs []string
+	Created   TimeWindow
+}
+
+// PRTimingQuery selects PRs for latency stats, TeamName filters by the current team of the author
+type PRTimingQuery struct {
+	TeamName string
+	Window   TimeWindow
+}
+
+// UserStatsQuery selects a page of user stats ordered by SortBy and then by user_id
+type UserStatsQuery struct {
+	TeamName   string
+	SortBy     string
+	Descending bool
+
+	// only PRs created within the window are counted
+	Created TimeWindow
+
+	// sort value and user_id of the last row of the previous page, empty AfterUserID means the first page
+	AfterValue  int
+	AfterUserID string
+
+	// 0 means no limit
+	Limit int
+}
+
+// PRListQuery selects a page of PRs ordered by SortBy and then by pull_request_id
+type PRListQuery struct {
+	// nil means any author, an empty list matches no PRs
+	AuthorIDs []string
+	// empty means any status
+	Status     domain.PRStatus
+	ReviewerID string
+	Created    TimeWindow
+
+	// domain.PRSortCreatedAt or domain.PRSortID
+	SortBy     string
+	Descending bool
+
+	// created_at and pull_request_id of the last PR of the previous page, empty AfterPRID means the first page.
+	// AfterCreatedAt is nil when that PR has no created_at.
+	AfterCreatedAt *time.Time
+	AfterPRID      string
+
+	// 0 means no limit
+	Limit int
+}
+
+// PRSearchQuery selects a page of text search results ordered by score and then by pull_request_id
+type PRSearchQuery struct {
+	// words, "quoted phrases" and -excluded words, as in MongoDB $text
+	Text string
+	// empty means any status
+	Status domain.PRStatus
+
+	// score and pull_request_id of the last hit of the previous page, empty AfterPRID means the first page
+	AfterScore float64
+	AfterPRID  string
+
+	// 0 means no limit
+	Limit int
 }
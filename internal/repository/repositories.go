@@ -0,0 +1,11 @@
+package repository
+
+// Repositories are the repositories of one storage backend
+type Repositories struct {
+	Users    UserRepository
+	Teams    TeamRepository
+	PRs      PRRepository
+	PREvents PREventRepository
+	// UnitOfWork groups writes of the repositories above
+	UnitOfWork UnitOfWork
+}
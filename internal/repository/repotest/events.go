@@ -0,0 +1,51 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunPREvents checks the PREventRepository contract
+func RunPREvents(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("ListByPR returns events in time order", func(t *testing.T) {
+		repo := newRepos(t).PREvents
+
+		require.NoError(t, repo.Append(ctx,
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerAssigned, At: base.Add(time.Minute), ReviewerID: "u2", TeamName: "backend", Reason: domain.PREventReasonAssignment},
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventCreated, At: base},
+			&domain.PREvent{PullRequestID: "pr-2", Type: domain.PREventCreated, At: base},
+		))
+		require.NoError(t, repo.Append(ctx,
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewSubmitted, At: base.Add(time.Hour), ReviewerID: "u2", ReviewState: domain.ReviewStateApproved},
+		))
+
+		events, err := repo.ListByPR(ctx, "pr-1")
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		assert.Equal(t, domain.PREventCreated, events[0].Type)
+		assert.Equal(t, &domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerAssigned, At: base.Add(time.Minute), ReviewerID: "u2", TeamName: "backend", Reason: domain.PREventReasonAssignment}, events[1])
+		assert.Equal(t, domain.ReviewStateApproved, events[2].ReviewState)
+	})
+
+	t.Run("ListByPR of a PR without events", func(t *testing.T) {
+		repo := newRepos(t).PREvents
+
+		events, err := repo.ListByPR(ctx, "missing")
+		require.NoError(t, err)
+		assert.NotNil(t, events)
+		assert.Empty(t, events)
+	})
+
+	t.Run("Append without events", func(t *testing.T) {
+		repo := newRepos(t).PREvents
+		require.NoError(t, repo.Append(ctx))
+	})
+}
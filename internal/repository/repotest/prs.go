@@ -0,0 +1,251 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunPRs checks the PRRepository contract except stats, see RunPRStats
+func RunPRs(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Create rejects duplicates", func(t *testing.T) {
+		repo := newRepos(t).PRs
+
+		require.NoError(t, repo.Create(ctx, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "first", Status: domain.PRStatusOpen, AssignedReviewers: []string{}}))
+		assert.ErrorIs(t, repo.Create(ctx, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "second", Status: domain.PRStatusOpen, AssignedReviewers: []string{}}), domain.ErrPRExists)
+
+		pr, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, "first", pr.PullRequestName)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := newRepos(t).PRs
+
+		pr, err := repo.GetByID(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrPRNotFound)
+		assert.Nil(t, pr)
+
+		exists, err := repo.Exists(ctx, "missing")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		repo := newRepos(t).PRs
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add search",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusMerged,
+			AssignedReviewers: []string{"u2", "u3"},
+			CreatedAt:         at(0),
+			MergedAt:          at(time.Hour),
+			RequiredReviewers: 3,
+			UnderStaffed:      true,
+			FallbackTeam:      "platform",
+			OverflowTeam:      "mobile",
+			Reviews: []domain.Review{
+				{ReviewerID: "u2", State: domain.ReviewStateApproved, UpdatedAt: at(30 * time.Minute)},
+				{ReviewerID: "u3", State: domain.ReviewStatePending},
+			},
+		}
+		createPRs(t, repo, pr)
+
+		stored, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, pr, stored)
+
+		exists, err := repo.Exists(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Update replaces the PR", func(t *testing.T) {
+		repo := newRepos(t).PRs
+		createPRs(t, repo, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "first", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}, CreatedAt: at(0)})
+
+		require.NoError(t, repo.Update(ctx, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "renamed", AuthorID: "u1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u3"}, CreatedAt: at(0), MergedAt: at(time.Hour)}))
+
+		pr, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", pr.PullRequestName)
+		assert.Equal(t, domain.PRStatusMerged, pr.Status)
+		assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+		require.NotNil(t, pr.MergedAt)
+		assert.True(t, at(time.Hour).Equal(*pr.MergedAt))
+
+		// updating a PR that does not exist is not an error and creates nothing
+		require.NoError(t, repo.Update(ctx, &domain.PullRequest{PullRequestID: "missing", Status: domain.PRStatusOpen, AssignedReviewers: []string{}}))
+		exists, err := repo.Exists(ctx, "missing")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Update clears emptied fields", func(t *testing.T) {
+		repo := newRepos(t).PRs
+		createPRs(t, repo, &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusClosed,
+			AssignedReviewers: []string{"u2"},
+			CreatedAt:         at(0),
+			ClosedAt:          at(time.Hour),
+			FallbackTeam:      "platform",
+			OverflowTeam:      "mobile",
+			Reviews:           []domain.Review{{ReviewerID: "u2", State: domain.ReviewStateApproved, UpdatedAt: at(30 * time.Minute)}},
+		})
+
+		// a reopened PR: no closed_at, no reviews and no borrowed reviewers any more
+		reopened := &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{}, CreatedAt: at(0)}
+		require.NoError(t, repo.Update(ctx, reopened))
+
+		pr, err := repo.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, reopened, pr)
+	})
+
+	t.Run("reviewer queries", func(t *testing.T) {
+		repo := newRepos(t).PRs
+		createPRs(t, repo,
+			&domain.PullRequest{PullRequestID: "pr-3", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1", "u2"}},
+			&domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1"}},
+			&domain.PullRequest{PullRequestID: "pr-2", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u1", "u3"}},
+			&domain.PullRequest{PullRequestID: "pr-4", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u3"}},
+			&domain.PullRequest{PullRequestID: "pr-5", Status: domain.PRStatusClosed, AssignedReviewers: []string{}},
+		)
+
+		prs, err := repo.GetByReviewer(ctx, "u1")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pr-1", "pr-2", "pr-3"}, prIDs(prs))
+
+		prs, err = repo.GetByReviewer(ctx, "nobody")
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+
+		// the team is resolved by the caller, every OPEN PR is returned
+		prs, err = repo.GetOpenByTeam(ctx, "backend")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pr-1", "pr-3", "pr-4"}, prIDs(prs))
+
+		prs, err = repo.GetOpenByReviewers(ctx, []string{"u2", "u3"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-3", "pr-4"}, prIDs(prs))
+
+		prs, err = repo.GetOpenByReviewers(ctx, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, prs)
+		assert.Empty(t, prs)
+
+		counts, err := repo.CountOpenByReviewers(ctx, []string{"u1", "u3", "nobody"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"u1": 2, "u3": 1}, counts)
+
+		counts, err = repo.CountOpenByReviewers(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("List filters", func(t *testing.T) {
+		repo := newRepos(t).PRs
+		createPRs(t, repo,
+			&domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}, CreatedAt: at(0)},
+			&domain.PullRequest{PullRequestID: "pr-2", AuthorID: "u2", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u1"}, CreatedAt: at(time.Hour)},
+			&domain.PullRequest{PullRequestID: "pr-3", AuthorID: "u1", Status: domain.PRStatusDraft, AssignedReviewers: []string{}, CreatedAt: at(2 * time.Hour)},
+			&domain.PullRequest{PullRequestID: "pr-4", AuthorID: "u3", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1"}},
+		)
+		list := func(query repository.PRListQuery) []string {
+			t.Helper()
+			query.SortBy = domain.PRSortID
+			prs, err := repo.List(ctx, query)
+			require.NoError(t, err)
+			return prIDs(prs)
+		}
+
+		assert.Equal(t, []string{"pr-1", "pr-2", "pr-3", "pr-4"}, list(repository.PRListQuery{}))
+		assert.Equal(t, []string{"pr-1", "pr-3"}, list(repository.PRListQuery{AuthorIDs: []string{"u1"}}))
+		assert.Equal(t, []string{}, list(repository.PRListQuery{AuthorIDs: []string{}}))
+		assert.Equal(t, []string{"pr-1", "pr-4"}, list(repository.PRListQuery{Status: domain.PRStatusOpen}))
+		assert.Equal(t, []string{"pr-2", "pr-4"}, list(repository.PRListQuery{ReviewerID: "u1"}))
+		// PRs without created_at only match an unbounded window
+		assert.Equal(t, []string{"pr-2", "pr-3"}, list(repository.PRListQuery{Created: repository.TimeWindow{From: at(time.Hour)}}))
+		assert.Equal(t, []string{"pr-1", "pr-2"}, list(repository.PRListQuery{Created: repository.TimeWindow{From: at(0), To: at(2 * time.Hour)}}))
+	})
+
+	t.Run("List sorts and pages", func(t *testing.T) {
+		repo := newRepos(t).PRs
+		createPRs(t, repo,
+			&domain.PullRequest{PullRequestID: "pr-b", Status: domain.PRStatusOpen, AssignedReviewers: []string{}, CreatedAt: at(time.Hour)},
+			&domain.PullRequest{PullRequestID: "pr-c", Status: domain.PRStatusOpen, AssignedReviewers: []string{}},
+			&domain.PullRequest{PullRequestID: "pr-a", Status: domain.PRStatusOpen, AssignedReviewers: []string{}, CreatedAt: at(time.Hour)},
+			&domain.PullRequest{PullRequestID: "pr-d", Status: domain.PRStatusOpen, AssignedReviewers: []string{}, CreatedAt: at(0)},
+		)
+		list := func(query repository.PRListQuery) []string {
+			t.Helper()
+			prs, err := repo.List(ctx, query)
+			require.NoError(t, err)
+			return prIDs(prs)
+		}
+
+		// a missing created_at goes first, ties are ordered by pull_request_id ascending in both directions
+		assert.Equal(t, []string{"pr-c", "pr-d", "pr-a", "pr-b"}, list(repository.PRListQuery{SortBy: domain.PRSortCreatedAt}))
+		assert.Equal(t, []string{"pr-a", "pr-b", "pr-d", "pr-c"}, list(repository.PRListQuery{SortBy: domain.PRSortCreatedAt, Descending: true}))
+		assert.Equal(t, []string{"pr-d", "pr-c", "pr-b", "pr-a"}, list(repository.PRListQuery{SortBy: domain.PRSortID, Descending: true}))
+
+		assert.Equal(t, []string{"pr-c", "pr-d"}, list(repository.PRListQuery{SortBy: domain.PRSortCreatedAt, Limit: 2}))
+		assert.Equal(t, []string{"pr-a", "pr-b"}, list(repository.PRListQuery{SortBy: domain.PRSortCreatedAt, AfterCreatedAt: at(0), AfterPRID: "pr-d"}))
+		assert.Equal(t, []string{"pr-d", "pr-a", "pr-b"}, list(repository.PRListQuery{SortBy: domain.PRSortCreatedAt, AfterPRID: "pr-c"}))
+		assert.Equal(t, []string{"pr-b", "pr-d", "pr-c"}, list(repository.PRListQuery{SortBy: domain.PRSortCreatedAt, Descending: true, AfterCreatedAt: at(time.Hour), AfterPRID: "pr-a"}))
+		assert.Equal(t, []string{"pr-c", "pr-d"}, list(repository.PRListQuery{SortBy: domain.PRSortID, AfterPRID: "pr-b"}))
+	})
+
+	t.Run("Search ranks and pages", func(t *testing.T) {
+		repo := newRepos(t).PRs
+		createPRs(t, repo,
+			&domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Fix login page", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{}},
+			&domain.PullRequest{PullRequestID: "pr-2", PullRequestName: "Fix login redirect after login", AuthorID: "u1", Status: domain.PRStatusMerged, AssignedReviewers: []string{}},
+			&domain.PullRequest{PullRequestID: "pr-3", PullRequestName: "Update dependencies", AuthorID: "u2", Status: domain.PRStatusOpen, AssignedReviewers: []string{}},
+			&domain.PullRequest{PullRequestID: "pr-4", PullRequestName: "Add logins audit", AuthorID: "u2", Status: domain.PRStatusOpen, AssignedReviewers: []string{}},
+		)
+
+		hits, err := repo.Search(ctx, repository.PRSearchQuery{Text: "login"})
+		require.NoError(t, err)
+		require.Len(t, hits, 3)
+		// the word appears twice in pr-2, other names are ordered by pull_request_id on equal scores
+		assert.Equal(t, []string{"pr-2", "pr-1", "pr-4"}, hitIDs(hits))
+		assert.Greater(t, hits[0].Score, hits[1].Score)
+		assert.Equal(t, domain.PullRequestShort{PullRequestID: "pr-2", PullRequestName: "Fix login redirect after login", AuthorID: "u1", Status: domain.PRStatusMerged}, hits[0].PullRequestShort)
+
+		hits, err = repo.Search(ctx, repository.PRSearchQuery{Text: "login", Status: domain.PRStatusOpen})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pr-1", "pr-4"}, hitIDs(hits))
+
+		first, err := repo.Search(ctx, repository.PRSearchQuery{Text: "login", Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+		rest, err := repo.Search(ctx, repository.PRSearchQuery{Text: "login", AfterScore: first[0].Score, AfterPRID: first[0].PullRequestID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1", "pr-4"}, hitIDs(rest))
+
+		hits, err = repo.Search(ctx, repository.PRSearchQuery{Text: "login -redirect"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pr-1", "pr-4"}, hitIDs(hits))
+
+		hits, err = repo.Search(ctx, repository.PRSearchQuery{Text: `"login page"`})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1"}, hitIDs(hits))
+
+		hits, err = repo.Search(ctx, repository.PRSearchQuery{Text: "kubernetes"})
+		require.NoError(t, err)
+		assert.NotNil(t, hits)
+		assert.Empty(t, hits)
+	})
+}
@@ -0,0 +1,80 @@
+// Package repotest is the contract of the repository interfaces.
+// Every storage backend runs it from its tests, so all backends meet the same expectations:
+//
+//	func TestContract(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) repository.Repositories {
+//			return NewRepositories()
+//		})
+//	}
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns repositories over an empty storage, it is called once per test case.
+// Resources are released with t.Cleanup.
+type Factory func(t *testing.T) repository.Repositories
+
+// Run checks the repositories made by newRepos against the whole contract
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("Users", func(t *testing.T) { RunUsers(t, newRepos) })
+	t.Run("Teams", func(t *testing.T) { RunTeams(t, newRepos) })
+	t.Run("PRs", func(t *testing.T) { RunPRs(t, newRepos) })
+	t.Run("PRStats", func(t *testing.T) { RunPRStats(t, newRepos) })
+	t.Run("PREvents", func(t *testing.T) { RunPREvents(t, newRepos) })
+	t.Run("UnitOfWork", func(t *testing.T) { RunUnitOfWork(t, newRepos) })
+}
+
+// base is a time every backend stores as is: UTC with whole milliseconds
+var base = time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+func at(d time.Duration) *time.Time {
+	t := base.Add(d)
+	return &t
+}
+
+func userIDs(users []*domain.User) []string {
+	ids := []string{}
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	return ids
+}
+
+func prIDs(prs []*domain.PullRequest) []string {
+	ids := []string{}
+	for _, pr := range prs {
+		ids = append(ids, pr.PullRequestID)
+	}
+	return ids
+}
+
+func hitIDs(hits []*domain.PRSearchHit) []string {
+	ids := []string{}
+	for _, hit := range hits {
+		ids = append(ids, hit.PullRequestID)
+	}
+	return ids
+}
+
+func createUsers(t *testing.T, repo repository.UserRepository, users ...*domain.User) {
+	t.Helper()
+	for _, u := range users {
+		require.NoError(t, repo.CreateOrUpdate(context.Background(), u), u.UserID)
+	}
+}
+
+func createPRs(t *testing.T, repo repository.PRRepository, prs ...*domain.PullRequest) {
+	t.Helper()
+	for _, pr := range prs {
+		require.NoError(t, repo.Create(context.Background(), pr), pr.PullRequestID)
+	}
+}
@@ -0,0 +1,195 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunPRStats checks the stats queries of PRRepository, they read users and PR events too
+func RunPRStats(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	// seed stores two teams with PRs created an hour apart
+	seed := func(t *testing.T) repository.Repositories {
+		repos := newRepos(t)
+		createUsers(t, repos.Users,
+			&domain.User{UserID: "u1", Username: "alice", TeamName: "backend", IsActive: true},
+			&domain.User{UserID: "u2", Username: "bob", TeamName: "backend", IsActive: true},
+			&domain.User{UserID: "u3", Username: "carol", TeamName: "backend", IsActive: true},
+			&domain.User{UserID: "u4", Username: "dave", TeamName: "frontend", IsActive: true},
+		)
+		createPRs(t, repos.PRs,
+			&domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2", "u3"}, CreatedAt: at(0)},
+			&domain.PullRequest{PullRequestID: "pr-2", AuthorID: "u1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u2", "gone"}, CreatedAt: at(time.Hour), MergedAt: at(3 * time.Hour)},
+			&domain.PullRequest{PullRequestID: "pr-3", AuthorID: "u2", Status: domain.PRStatusDraft, AssignedReviewers: []string{}, CreatedAt: at(2 * time.Hour)},
+			&domain.PullRequest{PullRequestID: "pr-4", AuthorID: "u4", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u2"}, CreatedAt: at(3 * time.Hour), MergedAt: at(4 * time.Hour)},
+			&domain.PullRequest{PullRequestID: "pr-5", AuthorID: "u2", Status: domain.PRStatusClosed, AssignedReviewers: []string{}, CreatedAt: at(4 * time.Hour), ClosedAt: at(5 * time.Hour)},
+			&domain.PullRequest{PullRequestID: "pr-6", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u3"}},
+		)
+		return repos
+	}
+
+	t.Run("ListUserStats", func(t *testing.T) {
+		repo := seed(t).PRs
+		stats := func(query repository.UserStatsQuery) []domain.UserStats {
+			t.Helper()
+			rows, err := repo.ListUserStats(ctx, query)
+			require.NoError(t, err)
+			result := []domain.UserStats{}
+			for _, row := range rows {
+				result = append(result, *row)
+			}
+			return result
+		}
+
+		// reviewers that are not users are dropped, users without assignments have zero counters
+		assert.Equal(t, []domain.UserStats{
+			{UserID: "u2", Username: "bob", TeamName: "backend", AssignedCount: 3, OpenPRCount: 1, MergedPRCount: 2},
+			{UserID: "u3", Username: "carol", TeamName: "backend", AssignedCount: 2, OpenPRCount: 2},
+			{UserID: "u1", Username: "alice", TeamName: "backend"},
+			{UserID: "u4", Username: "dave", TeamName: "frontend"},
+		}, stats(repository.UserStatsQuery{SortBy: domain.UserStatsSortAssigned, Descending: true}))
+
+		rows := stats(repository.UserStatsQuery{TeamName: "backend", SortBy: domain.UserStatsSortMerged, Limit: 2})
+		assert.Equal(t, []string{"u1", "u3"}, statsIDs(rows))
+
+		rows = stats(repository.UserStatsQuery{TeamName: "backend", SortBy: domain.UserStatsSortMerged, AfterValue: 0, AfterUserID: "u3"})
+		assert.Equal(t, []string{"u2"}, statsIDs(rows))
+
+		// only PRs created within the window are counted
+		rows = stats(repository.UserStatsQuery{SortBy: domain.UserStatsSortMerged, Descending: true, Created: repository.TimeWindow{From: at(time.Hour), To: at(4 * time.Hour)}})
+		require.Len(t, rows, 4)
+		assert.Equal(t, domain.UserStats{UserID: "u2", Username: "bob", TeamName: "backend", AssignedCount: 2, MergedPRCount: 2}, rows[0])
+		assert.Equal(t, []string{"u2", "u1", "u3", "u4"}, statsIDs(rows))
+
+		assert.Empty(t, stats(repository.UserStatsQuery{TeamName: "missing", SortBy: domain.UserStatsSortAssigned}))
+	})
+
+	t.Run("CountByStatus", func(t *testing.T) {
+		repo := seed(t).PRs
+
+		counts, err := repo.CountByStatus(ctx, repository.PRCountQuery{AuthorIDs: []string{"u1", "u2"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.PRStatus]int{
+			domain.PRStatusOpen:   2,
+			domain.PRStatusMerged: 1,
+			domain.PRStatusDraft:  1,
+			domain.PRStatusClosed: 1,
+		}, counts)
+
+		counts, err = repo.CountByStatus(ctx, repository.PRCountQuery{AuthorIDs: []string{"u1", "u2"}, Created: repository.TimeWindow{To: at(2 * time.Hour)}})
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.PRStatus]int{domain.PRStatusOpen: 1, domain.PRStatusMerged: 1}, counts)
+
+		counts, err = repo.CountByStatus(ctx, repository.PRCountQuery{AuthorIDs: []string{}})
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("ListMergeTimings", func(t *testing.T) {
+		repo := seed(t).PRs
+
+		timings, err := repo.ListMergeTimings(ctx, repository.PRTimingQuery{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pr-2", "pr-4"}, timingIDs(timings))
+
+		timings, err = repo.ListMergeTimings(ctx, repository.PRTimingQuery{TeamName: "backend"})
+		require.NoError(t, err)
+		require.Len(t, timings, 1)
+		timing := timings[0]
+		assert.Equal(t, "pr-2", timing.PullRequestID)
+		assert.Equal(t, "u1", timing.AuthorID)
+		assert.Equal(t, "backend", timing.TeamName)
+		assert.True(t, at(time.Hour).Equal(timing.CreatedAt))
+		require.NotNil(t, timing.MergedAt)
+		assert.True(t, at(3*time.Hour).Equal(*timing.MergedAt))
+		assert.Nil(t, timing.FirstReassignedAt)
+
+		// the window applies to merged_at
+		timings, err = repo.ListMergeTimings(ctx, repository.PRTimingQuery{Window: repository.TimeWindow{From: at(4 * time.Hour)}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-4"}, timingIDs(timings))
+	})
+
+	t.Run("ListReassignmentTimings", func(t *testing.T) {
+		repos := seed(t)
+		require.NoError(t, repos.PREvents.Append(ctx,
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerAssigned, At: base.Add(time.Minute), ReviewerID: "u2"},
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerReplaced, At: base.Add(30 * time.Minute), ReviewerID: "u3", ReplacedReviewerID: "u4"},
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerUnassigned, At: base.Add(20 * time.Minute), ReviewerID: "u4", Reason: domain.PREventReasonLegacyTeamReassignment},
+			&domain.PREvent{PullRequestID: "pr-2", Type: domain.PREventReviewerUnassigned, At: base.Add(90 * time.Minute), ReviewerID: "u3", Reason: domain.PREventReasonClosed},
+		))
+
+		timings, err := repos.PRs.ListReassignmentTimings(ctx, repository.PRTimingQuery{TeamName: "backend", Window: repository.TimeWindow{To: at(2 * time.Hour)}})
+		require.NoError(t, err)
+		require.Len(t, timings, 2)
+
+		byID := map[string]*domain.PRTiming{}
+		for _, timing := range timings {
+			byID[timing.PullRequestID] = timing
+		}
+		require.Contains(t, byID, "pr-1")
+		require.NotNil(t, byID["pr-1"].FirstReassignedAt)
+		assert.True(t, base.Add(20*time.Minute).Equal(*byID["pr-1"].FirstReassignedAt))
+		require.Contains(t, byID, "pr-2")
+		assert.Nil(t, byID["pr-2"].FirstReassignedAt)
+
+		timings, err = repos.PRs.ListReassignmentTimings(ctx, repository.PRTimingQuery{TeamName: "frontend"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-4"}, timingIDs(timings))
+	})
+
+	t.Run("ListReviewTimings", func(t *testing.T) {
+		repos := seed(t)
+		require.NoError(t, repos.PREvents.Append(ctx,
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerAssigned, At: base.Add(5 * time.Minute), ReviewerID: "u2"},
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewSubmitted, At: base.Add(40 * time.Minute), ReviewerID: "u2", ReviewState: domain.ReviewStateApproved},
+			&domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewSubmitted, At: base.Add(25 * time.Minute), ReviewerID: "u3", ReviewState: domain.ReviewStateCommented},
+			&domain.PREvent{PullRequestID: "pr-4", Type: domain.PREventReviewSubmitted, At: base.Add(200 * time.Minute), ReviewerID: "u2", ReviewState: domain.ReviewStateApproved},
+		))
+
+		timings, err := repos.PRs.ListReviewTimings(ctx, repository.PRTimingQuery{TeamName: "backend", Window: repository.TimeWindow{To: at(2 * time.Hour)}})
+		require.NoError(t, err)
+		require.Len(t, timings, 2)
+
+		byID := map[string]*domain.PRTiming{}
+		for _, timing := range timings {
+			byID[timing.PullRequestID] = timing
+		}
+		require.Contains(t, byID, "pr-1")
+		require.NotNil(t, byID["pr-1"].FirstReviewedAt)
+		assert.True(t, base.Add(25*time.Minute).Equal(*byID["pr-1"].FirstReviewedAt))
+		assert.Nil(t, byID["pr-1"].FirstReassignedAt)
+		require.Contains(t, byID, "pr-2")
+		assert.Nil(t, byID["pr-2"].FirstReviewedAt)
+
+		timings, err = repos.PRs.ListReviewTimings(ctx, repository.PRTimingQuery{TeamName: "frontend"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"pr-4"}, timingIDs(timings))
+		require.NotNil(t, timings[0].FirstReviewedAt)
+		assert.True(t, base.Add(200*time.Minute).Equal(*timings[0].FirstReviewedAt))
+	})
+}
+
+func statsIDs(rows []domain.UserStats) []string {
+	ids := []string{}
+	for _, row := range rows {
+		ids = append(ids, row.UserID)
+	}
+	return ids
+}
+
+func timingIDs(timings []*domain.PRTiming) []string {
+	ids := []string{}
+	for _, timing := range timings {
+		ids = append(ids, timing.PullRequestID)
+	}
+	return ids
+}
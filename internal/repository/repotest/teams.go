@@ -0,0 +1,180 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunTeams checks the TeamRepository contract
+func RunTeams(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Create rejects duplicates", func(t *testing.T) {
+		repo := newRepos(t).Teams
+
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "backend", ReviewersCount: 3}))
+		assert.ErrorIs(t, repo.Create(ctx, &domain.Team{TeamName: "backend"}), domain.ErrTeamExists)
+
+		team, err := repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, 3, team.ReviewersCount)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := newRepos(t).Teams
+
+		team, err := repo.GetByName(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+		assert.Nil(t, team)
+
+		team, err = repo.GetWithMembers(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+		assert.Nil(t, team)
+
+		exists, err := repo.Exists(ctx, "missing")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		assert.ErrorIs(t, repo.Rename(ctx, "missing", "other"), domain.ErrTeamNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, "missing"), domain.ErrTeamNotFound)
+		assert.ErrorIs(t, repo.DropLegacyMembers(ctx, "missing"), domain.ErrTeamNotFound)
+	})
+
+	t.Run("stores the reviewers policy", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		team := &domain.Team{
+			TeamName:          "backend",
+			ReviewersCount:    3,
+			MinReviewers:      1,
+			MaxReviewers:      4,
+			FallbackTeams:     []string{"platform", "frontend"},
+			RequiredApprovals: 2,
+		}
+		require.NoError(t, repo.Create(ctx, team))
+
+		stored, err := repo.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, team, stored)
+
+		exists, err := repo.Exists(ctx, "backend")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("members are read from users", func(t *testing.T) {
+		repos := newRepos(t)
+		require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+		require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "empty"}))
+		createUsers(t, repos.Users,
+			&domain.User{UserID: "u2", Username: "bob", TeamName: "backend", MaxOpenReviews: 2},
+			&domain.User{UserID: "u1", Username: "alice", TeamName: "backend", IsActive: true, Email: "alice@example.com"},
+			&domain.User{UserID: "u3", Username: "carol", TeamName: "frontend"},
+		)
+
+		team, err := repos.Teams.GetWithMembers(ctx, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TeamMember{
+			{UserID: "u1", Username: "alice", IsActive: true, Email: "alice@example.com"},
+			{UserID: "u2", Username: "bob", MaxOpenReviews: 2},
+		}, team.Members)
+
+		team, err = repos.Teams.GetWithMembers(ctx, "empty")
+		require.NoError(t, err)
+		assert.NotNil(t, team.Members)
+		assert.Empty(t, team.Members)
+	})
+
+	t.Run("List pages by team_name", func(t *testing.T) {
+		repos := newRepos(t)
+		for _, name := range []string{"frontend", "backend", "platform", "mobile"} {
+			require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: name}))
+		}
+		createUsers(t, repos.Users, &domain.User{UserID: "u1", TeamName: "backend"})
+
+		teams, err := repos.Teams.List(ctx, repository.TeamListQuery{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, teams, 2)
+		assert.Equal(t, "backend", teams[0].TeamName)
+		assert.Equal(t, "frontend", teams[1].TeamName)
+		assert.Len(t, teams[0].Members, 1)
+		assert.NotNil(t, teams[1].Members)
+		assert.Empty(t, teams[1].Members)
+
+		teams, err = repos.Teams.List(ctx, repository.TeamListQuery{AfterTeamName: "frontend"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mobile", "platform"}, teamNames(teams))
+
+		teams, err = repos.Teams.List(ctx, repository.TeamListQuery{Descending: true, AfterTeamName: "mobile"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"frontend", "backend"}, teamNames(teams))
+
+		teams, err = repos.Teams.List(ctx, repository.TeamListQuery{AfterTeamName: "platform"})
+		require.NoError(t, err)
+		assert.NotNil(t, teams)
+		assert.Empty(t, teams)
+
+		names, err := repos.Teams.ListNames(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"backend", "frontend", "mobile", "platform"}, names)
+	})
+
+	t.Run("Rename updates fallback teams", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "backend"}))
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "frontend", FallbackTeams: []string{"mobile", "backend"}}))
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "mobile"}))
+
+		assert.ErrorIs(t, repo.Rename(ctx, "backend", "mobile"), domain.ErrTeamExists)
+		require.NoError(t, repo.Rename(ctx, "backend", "platform"))
+
+		_, err := repo.GetByName(ctx, "backend")
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+		_, err = repo.GetByName(ctx, "platform")
+		require.NoError(t, err)
+
+		team, err := repo.GetByName(ctx, "frontend")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mobile", "platform"}, team.FallbackTeams)
+	})
+
+	t.Run("Delete drops the team from fallback teams", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "backend"}))
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "frontend", FallbackTeams: []string{"backend", "mobile"}}))
+
+		require.NoError(t, repo.Delete(ctx, "backend"))
+
+		exists, err := repo.Exists(ctx, "backend")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		team, err := repo.GetByName(ctx, "frontend")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mobile"}, team.FallbackTeams)
+	})
+
+	t.Run("teams created by the repository have no legacy members", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		require.NoError(t, repo.Create(ctx, &domain.Team{TeamName: "backend", Members: []domain.TeamMember{{UserID: "u1"}}}))
+
+		legacy, err := repo.ListLegacyMembers(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, legacy)
+
+		require.NoError(t, repo.DropLegacyMembers(ctx, "backend"))
+	})
+}
+
+func teamNames(teams []*domain.Team) []string {
+	names := []string{}
+	for _, team := range teams {
+		names = append(names, team.TeamName)
+	}
+	return names
+}
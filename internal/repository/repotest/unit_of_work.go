@@ -0,0 +1,120 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunUnitOfWork checks the UnitOfWork contract. The cases are skipped for repositories without a UnitOfWork,
+// which is how a backend tells that its storage has no transactions.
+func RunUnitOfWork(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+	errWork := errors.New("work failed")
+
+	withUnitOfWork := func(t *testing.T) repository.Repositories {
+		repos := newRepos(t)
+		if repos.UnitOfWork == nil {
+			t.Skip("the storage has no transactions")
+		}
+		return repos
+	}
+
+	t.Run("Do keeps the writes of a successful work", func(t *testing.T) {
+		repos := withUnitOfWork(t)
+
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+			if err := repos.Teams.Create(ctx, &domain.Team{TeamName: "backend"}); err != nil {
+				return err
+			}
+			if err := repos.Users.CreateOrUpdate(ctx, &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}); err != nil {
+				return err
+			}
+
+			// the work reads its own writes
+			team, err := repos.Teams.GetWithMembers(ctx, "backend")
+			if err != nil {
+				return err
+			}
+			assert.Len(t, team.Members, 1)
+			return nil
+		})
+		require.NoError(t, err)
+
+		team, err := repos.Teams.GetWithMembers(ctx, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}, team.Members)
+	})
+
+	t.Run("Do discards the writes of a failed work", func(t *testing.T) {
+		repos := withUnitOfWork(t)
+		require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "backend", ReviewersCount: 2}))
+		createUsers(t, repos.Users, &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true})
+		createPRs(t, repos.PRs, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "first", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}})
+
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+			if err := repos.Teams.Create(ctx, &domain.Team{TeamName: "frontend"}); err != nil {
+				return err
+			}
+			if err := repos.Teams.Rename(ctx, "backend", "platform"); err != nil {
+				return err
+			}
+			if err := repos.Users.CreateOrUpdate(ctx, &domain.User{UserID: "u2", Username: "Bob", TeamName: "frontend", IsActive: true}); err != nil {
+				return err
+			}
+			if err := repos.Users.UpdateIsActive(ctx, "u1", false); err != nil {
+				return err
+			}
+			if err := repos.PRs.Update(ctx, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "first", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u3"}}); err != nil {
+				return err
+			}
+			if err := repos.PREvents.Append(ctx, &domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventReviewerReplaced, At: base, ReviewerID: "u3", ReplacedReviewerID: "u2"}); err != nil {
+				return err
+			}
+			return errWork
+		})
+		assert.ErrorIs(t, err, errWork)
+
+		names, err := repos.Teams.ListNames(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend"}, names)
+
+		_, err = repos.Users.GetByID(ctx, "u2")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		user, err := repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.True(t, user.IsActive)
+		assert.Equal(t, "backend", user.TeamName)
+
+		pr, err := repos.PRs.GetByID(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+
+		events, err := repos.PREvents.ListByPR(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("Do returns repository errors as is", func(t *testing.T) {
+		repos := withUnitOfWork(t)
+		require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+			if err := repos.Teams.Create(ctx, &domain.Team{TeamName: "frontend"}); err != nil {
+				return err
+			}
+			return repos.Teams.Create(ctx, &domain.Team{TeamName: "backend"})
+		})
+		assert.ErrorIs(t, err, domain.ErrTeamExists)
+
+		exists, err := repos.Teams.Exists(ctx, "frontend")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
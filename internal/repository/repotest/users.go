@@ -0,0 +1,270 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunUsers checks the UserRepository contract
+func RunUsers(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CreateOrUpdate upserts by user_id", func(t *testing.T) {
+		repo := newRepos(t).Users
+
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "u1", Username: "alice", TeamName: "backend", IsActive: true, MaxOpenReviews: 3}))
+		require.NoError(t, repo.CreateOrUpdate(ctx, &domain.User{UserID: "u1", Username: "alice2", TeamName: "frontend", IsActive: false, MaxOpenReviews: 5}))
+
+		user, err := repo.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, &domain.User{UserID: "u1", Username: "alice2", TeamName: "frontend", MaxOpenReviews: 5}, user)
+
+		users, err := repo.GetByIDs(ctx, []string{"u1"})
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+	})
+
+	t.Run("CreateOrUpdate keeps absences", func(t *testing.T) {
+		repo := newRepos(t).Users
+
+		createUsers(t, repo, &domain.User{UserID: "u1", Username: "alice"})
+		require.NoError(t, repo.AddAbsence(ctx, "u1", &domain.Absence{AbsenceID: "abs-1", From: base, To: base.Add(time.Hour)}))
+		createUsers(t, repo, &domain.User{UserID: "u1", Username: "alice2"})
+
+		user, err := repo.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "alice2", user.Username)
+		require.Len(t, user.Absences, 1)
+		assert.Equal(t, "abs-1", user.Absences[0].AbsenceID)
+		assert.True(t, base.Equal(user.Absences[0].From))
+	})
+
+	t.Run("UpdateProfile sets only the given fields", func(t *testing.T) {
+		repo := newRepos(t).Users
+
+		createUsers(t, repo, &domain.User{UserID: "u1", Username: "alice", Email: "alice@example.com", TeamName: "backend", IsActive: true, MaxOpenReviews: 3})
+		require.NoError(t, repo.AddAbsence(ctx, "u1", &domain.Absence{AbsenceID: "abs-1", From: base, To: base.Add(time.Hour)}))
+
+		username, limit := "alice2", 0
+		require.NoError(t, repo.UpdateProfile(ctx, "u1", repository.UserProfileUpdate{Username: &username, MaxOpenReviews: &limit}))
+
+		user, err := repo.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "alice2", user.Username)
+		assert.Equal(t, 0, user.MaxOpenReviews)
+		assert.Equal(t, "alice@example.com", user.Email)
+		assert.Equal(t, "backend", user.TeamName)
+		assert.True(t, user.IsActive)
+		require.Len(t, user.Absences, 1)
+
+		email := ""
+		require.NoError(t, repo.UpdateProfile(ctx, "u1", repository.UserProfileUpdate{Email: &email}))
+		require.NoError(t, repo.UpdateProfile(ctx, "u1", repository.UserProfileUpdate{}))
+
+		user, err = repo.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.Empty(t, user.Email)
+		assert.Equal(t, "alice2", user.Username)
+		require.Len(t, user.Absences, 1)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := newRepos(t).Users
+
+		user, err := repo.GetByID(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Nil(t, user)
+
+		assert.ErrorIs(t, repo.UpdateIsActive(ctx, "missing", true), domain.ErrUserNotFound)
+		assert.ErrorIs(t, repo.UpdateTeam(ctx, "missing", "backend"), domain.ErrUserNotFound)
+		assert.ErrorIs(t, repo.UpdateProfile(ctx, "missing", repository.UserProfileUpdate{}), domain.ErrUserNotFound)
+		assert.ErrorIs(t, repo.AddAbsence(ctx, "missing", &domain.Absence{AbsenceID: "abs-1", From: base, To: base.Add(time.Hour)}), domain.ErrUserNotFound)
+		assert.ErrorIs(t, repo.RemoveAbsence(ctx, "missing", "abs-1"), domain.ErrUserNotFound)
+		assert.ErrorIs(t, repo.MarkAbsenceHandedOff(ctx, "missing", "abs-1", base), domain.ErrAbsenceNotFound)
+	})
+
+	t.Run("GetByIDs skips unknown ids", func(t *testing.T) {
+		repo := newRepos(t).Users
+		createUsers(t, repo, &domain.User{UserID: "u1"}, &domain.User{UserID: "u2"}, &domain.User{UserID: "u3"})
+
+		users, err := repo.GetByIDs(ctx, []string{"u3", "missing", "u1"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u1", "u3"}, userIDs(users))
+
+		users, err = repo.GetByIDs(ctx, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, users)
+		assert.Empty(t, users)
+	})
+
+	t.Run("team queries", func(t *testing.T) {
+		repo := newRepos(t).Users
+		createUsers(t, repo,
+			&domain.User{UserID: "u1", TeamName: "backend", IsActive: true},
+			&domain.User{UserID: "u2", TeamName: "backend", IsActive: false},
+			&domain.User{UserID: "u3", TeamName: "frontend", IsActive: true},
+			&domain.User{UserID: "u4"},
+		)
+
+		users, err := repo.GetByTeam(ctx, "backend")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u1", "u2"}, userIDs(users))
+
+		users, err = repo.GetActiveByTeam(ctx, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1"}, userIDs(users))
+
+		users, err = repo.GetByTeam(ctx, "missing")
+		require.NoError(t, err)
+		assert.Empty(t, users)
+
+		names, err := repo.ListTeamNames(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"backend", "frontend"}, names)
+
+		require.NoError(t, repo.RenameTeam(ctx, "backend", "platform"))
+		users, err = repo.GetByTeam(ctx, "platform")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u1", "u2"}, userIDs(users))
+
+		require.NoError(t, repo.UpdateTeam(ctx, "u1", ""))
+		users, err = repo.GetByTeam(ctx, "platform")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, userIDs(users))
+	})
+
+	t.Run("is_active updates", func(t *testing.T) {
+		repo := newRepos(t).Users
+		createUsers(t, repo, &domain.User{UserID: "u1"}, &domain.User{UserID: "u2"}, &domain.User{UserID: "u3"})
+
+		require.NoError(t, repo.UpdateIsActive(ctx, "u1", true))
+		require.NoError(t, repo.UpdateIsActiveMany(ctx, []string{"u2", "missing"}, true))
+		require.NoError(t, repo.UpdateIsActiveMany(ctx, nil, false))
+
+		for id, active := range map[string]bool{"u1": true, "u2": true, "u3": false} {
+			user, err := repo.GetByID(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, active, user.IsActive, id)
+		}
+	})
+
+	t.Run("List sorts, filters and pages", func(t *testing.T) {
+		repo := newRepos(t).Users
+		createUsers(t, repo,
+			&domain.User{UserID: "u3", Username: "bob", TeamName: "backend", IsActive: true},
+			&domain.User{UserID: "u1", Username: "carol", TeamName: "backend"},
+			&domain.User{UserID: "u2", Username: "bob", TeamName: "frontend", IsActive: true},
+			&domain.User{UserID: "u4", Username: "alice", TeamName: "backend", IsActive: true},
+		)
+
+		users, err := repo.List(ctx, repository.UserListQuery{SortBy: domain.UserSortID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u2", "u3", "u4"}, userIDs(users))
+
+		// ties of username are ordered by user_id ascending in both directions
+		users, err = repo.List(ctx, repository.UserListQuery{SortBy: domain.UserSortUsername, Descending: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u2", "u3", "u4"}, userIDs(users))
+
+		users, err = repo.List(ctx, repository.UserListQuery{SortBy: domain.UserSortUsername, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4", "u2"}, userIDs(users))
+
+		users, err = repo.List(ctx, repository.UserListQuery{SortBy: domain.UserSortUsername, AfterValue: "bob", AfterUserID: "u2"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3", "u1"}, userIDs(users))
+
+		active := true
+		users, err = repo.List(ctx, repository.UserListQuery{TeamName: "backend", IsActive: &active, SortBy: domain.UserSortID, Descending: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4", "u3"}, userIDs(users))
+
+		users, err = repo.List(ctx, repository.UserListQuery{TeamName: "missing", SortBy: domain.UserSortID})
+		require.NoError(t, err)
+		assert.NotNil(t, users)
+		assert.Empty(t, users)
+	})
+
+	t.Run("GetByEmails", func(t *testing.T) {
+		repo := newRepos(t).Users
+		createUsers(t, repo,
+			&domain.User{UserID: "u1", Email: "alice@example.com"},
+			&domain.User{UserID: "u2", Email: "bob@example.com"},
+			&domain.User{UserID: "u3"},
+		)
+
+		users, err := repo.GetByEmails(ctx, []string{"bob@example.com", "nobody@example.com", ""})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, userIDs(users))
+
+		users, err = repo.GetByEmails(ctx, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, users)
+		assert.Empty(t, users)
+	})
+
+	t.Run("absences", func(t *testing.T) {
+		repo := newRepos(t).Users
+		createUsers(t, repo, &domain.User{UserID: "u1"}, &domain.User{UserID: "u2"})
+
+		require.NoError(t, repo.AddAbsence(ctx, "u1", &domain.Absence{AbsenceID: "due", From: base.Add(-time.Hour), To: base.Add(time.Hour), Handoff: true, Reason: "vacation"}))
+		require.NoError(t, repo.AddAbsence(ctx, "u1", &domain.Absence{AbsenceID: "later", From: base.Add(time.Hour), To: base.Add(2 * time.Hour), Handoff: true}))
+		require.NoError(t, repo.AddAbsence(ctx, "u2", &domain.Absence{AbsenceID: "no-handoff", From: base.Add(-time.Hour), To: base.Add(time.Hour)}))
+
+		user, err := repo.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		require.Len(t, user.Absences, 2)
+		assert.Equal(t, "vacation", user.Absences[0].Reason)
+
+		users, err := repo.ListAbsenceHandoffs(ctx, base)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1"}, userIDs(users))
+
+		require.NoError(t, repo.MarkAbsenceHandedOff(ctx, "u1", "due", base))
+		assert.ErrorIs(t, repo.MarkAbsenceHandedOff(ctx, "u1", "missing", base), domain.ErrAbsenceNotFound)
+
+		users, err = repo.ListAbsenceHandoffs(ctx, base)
+		require.NoError(t, err)
+		assert.Empty(t, users)
+
+		user, err = repo.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		require.NotNil(t, user.Absences[0].HandedOffAt)
+		assert.True(t, base.Equal(*user.Absences[0].HandedOffAt))
+
+		handedOffAt := *user.Absences[0].HandedOffAt
+		require.NoError(t, repo.UpdateAbsence(ctx, "u1", &domain.Absence{AbsenceID: "due", From: base.Add(-time.Hour), To: base.Add(time.Hour), Handoff: true, Reason: "trip", HandedOffAt: &handedOffAt}))
+		assert.ErrorIs(t, repo.UpdateAbsence(ctx, "u1", &domain.Absence{AbsenceID: "missing", From: base, To: base.Add(time.Hour)}), domain.ErrAbsenceNotFound)
+		assert.ErrorIs(t, repo.UpdateAbsence(ctx, "missing", &domain.Absence{AbsenceID: "due", From: base, To: base.Add(time.Hour)}), domain.ErrAbsenceNotFound)
+
+		user, err = repo.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		require.Len(t, user.Absences, 2)
+		assert.Equal(t, "due", user.Absences[0].AbsenceID)
+		assert.Equal(t, "trip", user.Absences[0].Reason)
+		require.NotNil(t, user.Absences[0].HandedOffAt)
+		assert.True(t, base.Equal(*user.Absences[0].HandedOffAt))
+
+		require.NoError(t, repo.UpdateAbsence(ctx, "u1", &domain.Absence{AbsenceID: "due", From: base, To: base.Add(3 * time.Hour), Handoff: true}))
+
+		user, err = repo.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.True(t, base.Add(3*time.Hour).Equal(user.Absences[0].To))
+		assert.Nil(t, user.Absences[0].HandedOffAt)
+
+		assert.ErrorIs(t, repo.RemoveAbsence(ctx, "u1", "missing"), domain.ErrAbsenceNotFound)
+		require.NoError(t, repo.RemoveAbsence(ctx, "u1", "due"))
+
+		user, err = repo.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		require.Len(t, user.Absences, 1)
+		assert.Equal(t, "later", user.Absences[0].AbsenceID)
+	})
+}
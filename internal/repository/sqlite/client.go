@@ -0,0 +1,67 @@
+// Package sqlite keeps the repositories data in an SQLite file, so the service runs without a database server.
+// The schema in schema.sql is created when the client opens the file.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"net/url"
+
+	"go.uber.org/zap"
+	// pure-Go driver, the binary needs no cgo
+	_ "modernc.org/sqlite"
+)
+
+//go:embed schema.sql
+var schema string
+
+// busyTimeoutMs is how long a write waits for the write lock held by another connection
+const busyTimeoutMs = 5000
+
+type Client struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewClient opens the database file, creating it and its schema if needed
+func NewClient(ctx context.Context, path string, logger *zap.Logger) (*Client, error) {
+	db, err := sql.Open("sqlite", dsn(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		//nolint:errcheck
+		db.Close()
+		return nil, fmt.Errorf("failed to create SQLite schema: %w", err)
+	}
+
+	logger.Info("Opened SQLite database successfully", zap.String("path", path))
+
+	return &Client{
+		db:     db,
+		logger: logger,
+	}, nil
+}
+
+// dsn enables foreign keys and WAL on every connection of the pool. Transactions take the write lock
+// when they begin, so two of them never deadlock upgrading a read lock.
+func dsn(path string) string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeoutMs))
+	params.Set("_txlock", "immediate")
+
+	return "file:" + path + "?" + params.Encode()
+}
+
+func (c *Client) DB() *sql.DB {
+	return c.db
+}
+
+func (c *Client) Close(_ context.Context) error {
+	return c.db.Close()
+}
@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// setupTestDB opens a new database file in the test's temporary directory
+func setupTestDB(t *testing.T) *Client {
+	client, err := NewClient(context.Background(), filepath.Join(t.TempDir(), "test.db"), zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close(context.Background()) })
+
+	return client
+}
+
+func TestNewClientKeepsData(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "service.db")
+
+	client, err := NewClient(ctx, path, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, NewTeamRepository(client, zap.NewNop()).Create(ctx, &domain.Team{TeamName: "backend"}))
+	require.NoError(t, client.Close(ctx))
+
+	// opening an existing file runs the schema again without touching the data
+	client, err = NewClient(ctx, path, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close(ctx) })
+
+	exists, err := NewTeamRepository(client, zap.NewNop()).Exists(ctx, "backend")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestNewClientInvalidPath(t *testing.T) {
+	_, err := NewClient(context.Background(), filepath.Join(t.TempDir(), "missing", "service.db"), zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SQLite")
+}
+
+func TestSearchIndexFollowsRenames(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPRRepository(setupTestDB(t), zap.NewNop())
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Fix login", Status: domain.PRStatusOpen, AssignedReviewers: []string{}}
+	require.NoError(t, repo.Create(ctx, pr))
+	pr.PullRequestName = "Update dependencies"
+	require.NoError(t, repo.Update(ctx, pr))
+
+	hits, err := repo.Search(ctx, repository.PRSearchQuery{Text: "login"})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	hits, err = repo.Search(ctx, repository.PRSearchQuery{Text: "dependencies"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "pr-1", hits[0].PullRequestID)
+}
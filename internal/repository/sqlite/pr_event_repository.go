@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"assignment-service/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+const prEventColumns = "pull_request_id, type, at, reviewer_id, replaced_reviewer_id, team_name, reason, review_state"
+
+type PREventRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+func NewPREventRepository(client *Client, logger *zap.Logger) *PREventRepository {
+	return &PREventRepository{
+		db:     client.DB(),
+		logger: logger,
+	}
+}
+
+func (r *PREventRepository) Append(ctx context.Context, events ...*domain.PREvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	// one statement inserts the rows in order, the id keeps that order for equal timestamps
+	var p params
+	values := make([]string, 0, len(events))
+	for _, e := range events {
+		values = append(values, "("+strings.Join([]string{
+			p.add(e.PullRequestID), p.add(e.Type), p.add(e.At.UnixNano()), p.add(e.ReviewerID),
+			p.add(e.ReplacedReviewerID), p.add(e.TeamName), p.add(e.Reason), p.add(e.ReviewState),
+		}, ", ")+")")
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, "INSERT INTO pr_events ("+prEventColumns+") VALUES "+strings.Join(values, ", "), p...)
+	if err != nil {
+		r.logger.Error("failed to append PR events", zap.Error(err), zap.String("pr_id", events[0].PullRequestID))
+		return fmt.Errorf("failed to append PR events: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PREventRepository) ListByPR(ctx context.Context, prID string) ([]*domain.PREvent, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, "SELECT "+prEventColumns+" FROM pr_events WHERE pull_request_id = ?1 ORDER BY at, id", prID)
+	if err != nil {
+		r.logger.Error("failed to find PR events", zap.Error(err), zap.String("pr_id", prID))
+		return nil, fmt.Errorf("failed to find PR events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.PREvent{}
+	for rows.Next() {
+		var e domain.PREvent
+		var at int64
+		if err := rows.Scan(&e.PullRequestID, &e.Type, &at, &e.ReviewerID, &e.ReplacedReviewerID, &e.TeamName, &e.Reason, &e.ReviewState); err != nil {
+			r.logger.Error("failed to decode PR events", zap.Error(err))
+			return nil, fmt.Errorf("failed to decode PR events: %w", err)
+		}
+		e.At = time.Unix(0, at).UTC()
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("failed to decode PR events", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode PR events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,216 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"assignment-service/internal/repository"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) &&
+		(sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY)
+}
+
+func isForeignKeyViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+}
+
+// querier runs statements on the database or within a transaction
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction of the unit of work ctx belongs to, or the database outside of one
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(unitOfWorkKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// inTx runs fn in a transaction, committed when fn returns nil and rolled back otherwise.
+// Within a unit of work fn runs in a savepoint of its transaction.
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	if tx, ok := ctx.Value(unitOfWorkKey{}).(*sql.Tx); ok {
+		return inSavepoint(ctx, tx, fn)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		//nolint:errcheck
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// inSavepoint runs fn in a savepoint of tx, released when fn returns nil and rolled back to otherwise
+func inSavepoint(ctx context.Context, tx *sql.Tx, fn func(tx *sql.Tx) error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT nested"); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		//nolint:errcheck
+		tx.ExecContext(ctx, "ROLLBACK TO nested")
+		//nolint:errcheck
+		tx.ExecContext(ctx, "RELEASE nested")
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, "RELEASE nested")
+	return err
+}
+
+// params collects the arguments of a query built piece by piece
+type params []any
+
+// add appends the argument and returns its placeholder
+func (p *params) add(value any) string {
+	*p = append(*p, value)
+	return "?" + strconv.Itoa(len(*p))
+}
+
+// where joins the conditions with AND, no conditions give an empty string
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+func limitClause(limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return " LIMIT " + strconv.Itoa(limit)
+}
+
+// keyset describes a list order: sortColumn in the requested direction, ties broken by idColumn ascending.
+// When sortColumn is idColumn the list is ordered by the id alone.
+type keyset struct {
+	sortColumn string
+	idColumn   string
+	descending bool
+	// the sort column may be NULL, NULLs go before any value as in MongoDB
+	nullable bool
+}
+
+func (k keyset) orderBy() string {
+	direction := " ASC"
+	nulls := ""
+	if k.descending {
+		direction = " DESC"
+	}
+	if k.nullable {
+		nulls = " NULLS FIRST"
+		if k.descending {
+			nulls = " NULLS LAST"
+		}
+	}
+	if k.sortColumn == k.idColumn {
+		return " ORDER BY " + k.idColumn + direction
+	}
+	return " ORDER BY " + k.sortColumn + direction + nulls + ", " + k.idColumn + " ASC"
+}
+
+// after matches rows following the one with the given sort value and id, a nil value stands for NULL
+func (k keyset) after(p *params, value any, id string) string {
+	next := " > "
+	if k.descending {
+		next = " < "
+	}
+	if k.sortColumn == k.idColumn {
+		return k.idColumn + next + p.add(id)
+	}
+
+	if value == nil {
+		sameValue := "(" + k.sortColumn + " IS NULL AND " + k.idColumn + " > " + p.add(id) + ")"
+		if k.descending {
+			return sameValue
+		}
+		return "(" + k.sortColumn + " IS NOT NULL OR " + sameValue + ")"
+	}
+
+	v := p.add(value)
+	sameValue := "(" + k.sortColumn + " = " + v + " AND " + k.idColumn + " > " + p.add(id) + ")"
+	if k.descending && k.nullable {
+		return "(" + k.sortColumn + next + v + " OR " + k.sortColumn + " IS NULL OR " + sameValue + ")"
+	}
+	return "(" + k.sortColumn + next + v + " OR " + sameValue + ")"
+}
+
+// window returns the conditions matching rows whose column is within the window.
+// NULL never matches a bounded window.
+func window(p *params, column string, w repository.TimeWindow) []string {
+	var conditions []string
+	if w.From != nil {
+		conditions = append(conditions, column+" >= "+p.add(w.From.UnixNano()))
+	}
+	if w.To != nil {
+		conditions = append(conditions, column+" < "+p.add(w.To.UnixNano()))
+	}
+	return conditions
+}
+
+// inList matches rows whose column is one of the values
+func inList(p *params, column string, values []string) string {
+	return column + " IN (SELECT value FROM json_each(" + p.add(jsonList(values)) + "))"
+}
+
+// contains matches rows whose JSON array column has the value
+func contains(p *params, column, value string) string {
+	return "EXISTS (SELECT 1 FROM json_each(" + column + ") WHERE value = " + p.add(value) + ")"
+}
+
+// jsonList encodes a list column, nil is stored as an empty array
+func jsonList(values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+	b, _ := json.Marshal(values)
+	return string(b)
+}
+
+func parseList(data string) ([]string, error) {
+	var values []string
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// timeArg stores a time as unix nanoseconds, nil as NULL
+func timeArg(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UnixNano()
+}
+
+// timeValue reads a time stored by timeArg in UTC, the location the other backends return times in
+func timeValue(v sql.NullInt64) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := time.Unix(0, v.Int64).UTC()
+	return &t
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"assignment-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysetAfter(t *testing.T) {
+	order := keyset{sortColumn: "created_at", idColumn: "pull_request_id", nullable: true, descending: true}
+
+	var p params
+	assert.Equal(t, "(created_at < ?1 OR created_at IS NULL OR (created_at = ?1 AND pull_request_id > ?2))", order.after(&p, int64(10), "pr-1"))
+	assert.Equal(t, []any{int64(10), "pr-1"}, []any(p))
+
+	p = nil
+	assert.Equal(t, "team_name > ?1", keyset{sortColumn: "team_name", idColumn: "team_name"}.after(&p, nil, "backend"))
+}
+
+func TestWindow(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	var p params
+	assert.Empty(t, window(&p, "created_at", repository.TimeWindow{}))
+	assert.Equal(t, []string{"created_at >= ?1", "created_at < ?2"}, window(&p, "created_at", repository.TimeWindow{From: &from, To: &to}))
+	assert.Equal(t, []any{from.UnixNano(), to.UnixNano()}, []any(p))
+}
+
+func TestTextSearchQuery(t *testing.T) {
+	query, ok := textSearchQuery(`login "fix page" -redirect audit -"`)
+	assert.True(t, ok)
+	assert.Equal(t, `(("login" OR "audit") AND "fix page") NOT "redirect"`, query)
+
+	query, ok = textSearchQuery(`say"hi"`)
+	assert.True(t, ok)
+	assert.Equal(t, `("say") AND "hi"`, query)
+
+	_, ok = textSearchQuery("-redirect")
+	assert.False(t, ok)
+	_, ok = textSearchQuery(`"" -`)
+	assert.False(t, ok)
+}
+
+func TestJSONList(t *testing.T) {
+	assert.Equal(t, "[]", jsonList(nil))
+	assert.Equal(t, `["a","b"]`, jsonList([]string{"a", "b"}))
+
+	values, err := parseList(`["a","b"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, values)
+}
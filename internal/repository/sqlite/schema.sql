@@ -0,0 +1,101 @@
+-- Times are unix nanoseconds, lists are JSON arrays of strings.
+-- Every statement is safe to run again on an existing database.
+
+CREATE TABLE IF NOT EXISTS users (
+    user_id          TEXT PRIMARY KEY,
+    username         TEXT NOT NULL DEFAULT '',
+    team_name        TEXT NOT NULL DEFAULT '',
+    is_active        INTEGER NOT NULL DEFAULT 0,
+    max_open_reviews INTEGER NOT NULL DEFAULT 0,
+    email            TEXT NOT NULL DEFAULT ''
+);
+
+-- team rosters are read by team_name ordered by user_id
+CREATE INDEX IF NOT EXISTS users_team_name_idx ON users (team_name, user_id);
+-- user lists sorted by username
+CREATE INDEX IF NOT EXISTS users_username_idx ON users (username, user_id);
+CREATE INDEX IF NOT EXISTS users_email_idx ON users (email) WHERE email <> '';
+
+CREATE TABLE IF NOT EXISTS absences (
+    -- keeps the order absences were added in
+    position      INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id       TEXT NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    absence_id    TEXT NOT NULL,
+    starts_at     INTEGER NOT NULL,
+    ends_at       INTEGER NOT NULL,
+    reason        TEXT NOT NULL DEFAULT '',
+    handoff       INTEGER NOT NULL DEFAULT 0,
+    handed_off_at INTEGER,
+    UNIQUE (user_id, absence_id)
+);
+
+CREATE INDEX IF NOT EXISTS absences_due_handoff_idx ON absences (starts_at) WHERE handoff AND handed_off_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS teams (
+    team_name          TEXT PRIMARY KEY,
+    reviewers_count    INTEGER NOT NULL DEFAULT 0,
+    min_reviewers      INTEGER NOT NULL DEFAULT 0,
+    max_reviewers      INTEGER NOT NULL DEFAULT 0,
+    fallback_teams     TEXT NOT NULL DEFAULT '[]',
+    required_approvals INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS pull_requests (
+    -- stable row id of the search index, VACUUM may renumber implicit rowids
+    id                 INTEGER PRIMARY KEY,
+    pull_request_id    TEXT NOT NULL UNIQUE,
+    pull_request_name  TEXT NOT NULL DEFAULT '',
+    author_id          TEXT NOT NULL DEFAULT '',
+    status             TEXT NOT NULL CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED')),
+    assigned_reviewers TEXT NOT NULL DEFAULT '[]',
+    created_at         INTEGER,
+    merged_at          INTEGER,
+    closed_at          INTEGER,
+    required_reviewers INTEGER NOT NULL DEFAULT 0,
+    under_staffed      INTEGER NOT NULL DEFAULT 0,
+    fallback_team      TEXT NOT NULL DEFAULT '',
+    overflow_team      TEXT NOT NULL DEFAULT '',
+    reviews            TEXT
+);
+
+CREATE INDEX IF NOT EXISTS pull_requests_status_idx ON pull_requests (status);
+CREATE INDEX IF NOT EXISTS pull_requests_author_idx ON pull_requests (author_id, created_at);
+-- PR lists sorted by created_at
+CREATE INDEX IF NOT EXISTS pull_requests_created_at_idx ON pull_requests (created_at, pull_request_id);
+CREATE INDEX IF NOT EXISTS pull_requests_merged_at_idx ON pull_requests (merged_at) WHERE status = 'MERGED';
+
+-- text search on PR names, kept in sync with pull_requests by the triggers below
+CREATE VIRTUAL TABLE IF NOT EXISTS pull_requests_search USING fts5 (
+    pull_request_name,
+    content = 'pull_requests',
+    content_rowid = 'id',
+    tokenize = 'porter unicode61'
+);
+
+CREATE TRIGGER IF NOT EXISTS pull_requests_search_insert AFTER INSERT ON pull_requests BEGIN
+    INSERT INTO pull_requests_search (rowid, pull_request_name) VALUES (new.id, new.pull_request_name);
+END;
+
+CREATE TRIGGER IF NOT EXISTS pull_requests_search_delete AFTER DELETE ON pull_requests BEGIN
+    INSERT INTO pull_requests_search (pull_requests_search, rowid, pull_request_name) VALUES ('delete', old.id, old.pull_request_name);
+END;
+
+CREATE TRIGGER IF NOT EXISTS pull_requests_search_update AFTER UPDATE OF pull_request_name ON pull_requests BEGIN
+    INSERT INTO pull_requests_search (pull_requests_search, rowid, pull_request_name) VALUES ('delete', old.id, old.pull_request_name);
+    INSERT INTO pull_requests_search (rowid, pull_request_name) VALUES (new.id, new.pull_request_name);
+END;
+
+CREATE TABLE IF NOT EXISTS pr_events (
+    id                   INTEGER PRIMARY KEY AUTOINCREMENT,
+    pull_request_id      TEXT NOT NULL,
+    type                 TEXT NOT NULL,
+    at                   INTEGER NOT NULL,
+    reviewer_id          TEXT NOT NULL DEFAULT '',
+    replaced_reviewer_id TEXT NOT NULL DEFAULT '',
+    team_name            TEXT NOT NULL DEFAULT '',
+    reason               TEXT NOT NULL DEFAULT '',
+    review_state         TEXT NOT NULL DEFAULT ''
+);
+
+-- PR history is read in the order events happened, the id keeps the append order for equal timestamps
+CREATE INDEX IF NOT EXISTS pr_events_pr_idx ON pr_events (pull_request_id, at, id);
@@ -0,0 +1,288 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+const teamColumns = "team_name, reviewers_count, min_reviewers, max_reviewers, fallback_teams, required_approvals"
+
+type TeamRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+func NewTeamRepository(client *Client, logger *zap.Logger) *TeamRepository {
+	return &TeamRepository{
+		db:     client.DB(),
+		logger: logger,
+	}
+}
+
+func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "INSERT INTO teams ("+teamColumns+") VALUES (?1, ?2, ?3, ?4, ?5, ?6)",
+		team.TeamName, team.ReviewersCount, team.MinReviewers, team.MaxReviewers, jsonList(team.FallbackTeams), team.RequiredApprovals)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrTeamExists
+		}
+
+		r.logger.Error("failed to create team", zap.Error(err), zap.String("team_name", team.TeamName))
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	team, err := scanTeam(conn(ctx, r.db).QueryRowContext(ctx, "SELECT "+teamColumns+" FROM teams WHERE team_name = ?1", teamName))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTeamNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to get team", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	return team, nil
+}
+
+func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM teams WHERE team_name = ?1)", teamName).Scan(&exists)
+	if err != nil {
+		r.logger.Error("failed to check team existence", zap.Error(err), zap.String("team_name", teamName))
+		return false, fmt.Errorf("failed to check team existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *TeamRepository) GetWithMembers(ctx context.Context, teamName string) (*domain.Team, error) {
+	team, err := r.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.fillMembers(ctx, []*domain.Team{team}); err != nil {
+		r.logger.Error("failed to get team members", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	return team, nil
+}
+
+func (r *TeamRepository) List(ctx context.Context, query repository.TeamListQuery) ([]*domain.Team, error) {
+	var p params
+	var conditions []string
+	order := keyset{sortColumn: "team_name", idColumn: "team_name", descending: query.Descending}
+	if query.AfterTeamName != "" {
+		conditions = append(conditions, order.after(&p, nil, query.AfterTeamName))
+	}
+
+	teams, err := r.find(ctx, "SELECT "+teamColumns+" FROM teams"+where(conditions)+order.orderBy()+limitClause(query.Limit), p...)
+	if err != nil {
+		r.logger.Error("failed to list teams", zap.Error(err))
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	// members are looked up for the page only
+	if err := r.fillMembers(ctx, teams); err != nil {
+		r.logger.Error("failed to get team members", zap.Error(err))
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) ListNames(ctx context.Context) ([]string, error) {
+	names, err := listStrings(ctx, conn(ctx, r.db), "SELECT team_name FROM teams ORDER BY team_name")
+	if err != nil {
+		r.logger.Error("failed to list team names", zap.Error(err))
+		return nil, fmt.Errorf("failed to list team names: %w", err)
+	}
+
+	return names, nil
+}
+
+func (r *TeamRepository) Rename(ctx context.Context, oldName, newName string) error {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "UPDATE teams SET team_name = ?2 WHERE team_name = ?1", oldName, newName)
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return domain.ErrTeamNotFound
+		}
+
+		return r.updateFallbackTeams(ctx, tx, oldName, func(teams []string) []string {
+			for i, name := range teams {
+				if name == oldName {
+					teams[i] = newName
+				}
+			}
+			return teams
+		})
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrTeamNotFound):
+		return err
+	case isUniqueViolation(err):
+		return domain.ErrTeamExists
+	default:
+		r.logger.Error("failed to rename team", zap.Error(err), zap.String("team_name", oldName))
+		return fmt.Errorf("failed to rename team: %w", err)
+	}
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, teamName string) error {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM teams WHERE team_name = ?1", teamName)
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return domain.ErrTeamNotFound
+		}
+
+		return r.updateFallbackTeams(ctx, tx, teamName, func(teams []string) []string {
+			return slices.DeleteFunc(teams, func(name string) bool { return name == teamName })
+		})
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrTeamNotFound):
+		return err
+	default:
+		r.logger.Error("failed to delete team", zap.Error(err), zap.String("team_name", teamName))
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+}
+
+// ListLegacyMembers returns nothing: the sqlite schema has never kept rosters in teams
+func (r *TeamRepository) ListLegacyMembers(_ context.Context) (map[string][]domain.TeamMember, error) {
+	return map[string][]domain.TeamMember{}, nil
+}
+
+func (r *TeamRepository) DropLegacyMembers(ctx context.Context, teamName string) error {
+	exists, err := r.Exists(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	return nil
+}
+
+// updateFallbackTeams rewrites fallback_teams of the teams that fall back to teamName
+func (r *TeamRepository) updateFallbackTeams(ctx context.Context, tx *sql.Tx, teamName string, update func([]string) []string) error {
+	var p params
+	teams, err := r.findIn(ctx, tx, "SELECT "+teamColumns+" FROM teams WHERE "+contains(&p, "fallback_teams", teamName), p...)
+	if err != nil {
+		return err
+	}
+
+	for _, team := range teams {
+		_, err := tx.ExecContext(ctx, "UPDATE teams SET fallback_teams = ?2 WHERE team_name = ?1", team.TeamName, jsonList(update(team.FallbackTeams)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fillMembers sets Members of the teams from their users ordered by user_id
+func (r *TeamRepository) fillMembers(ctx context.Context, teams []*domain.Team) error {
+	if len(teams) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]*domain.Team, len(teams))
+	names := make([]string, 0, len(teams))
+	for _, team := range teams {
+		team.Members = []domain.TeamMember{}
+		byName[team.TeamName] = team
+		names = append(names, team.TeamName)
+	}
+
+	var p params
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT team_name, user_id, username, is_active, max_open_reviews, email
+		FROM users WHERE `+inList(&p, "team_name", names)+` ORDER BY user_id`, p...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamName string
+		var member domain.TeamMember
+		if err := rows.Scan(&teamName, &member.UserID, &member.Username, &member.IsActive, &member.MaxOpenReviews, &member.Email); err != nil {
+			return err
+		}
+		byName[teamName].Members = append(byName[teamName].Members, member)
+	}
+
+	return rows.Err()
+}
+
+func (r *TeamRepository) find(ctx context.Context, query string, args ...any) ([]*domain.Team, error) {
+	return r.findIn(ctx, conn(ctx, r.db), query, args...)
+}
+
+// findIn runs a query selecting teamColumns, no rows give an empty slice
+func (r *TeamRepository) findIn(ctx context.Context, db querier, query string, args ...any) ([]*domain.Team, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := []*domain.Team{}
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, rows.Err()
+}
+
+// scanner is a *sql.Row or *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTeam(row scanner) (*domain.Team, error) {
+	var team domain.Team
+	var fallbackTeams string
+	err := row.Scan(&team.TeamName, &team.ReviewersCount, &team.MinReviewers, &team.MaxReviewers, &fallbackTeams, &team.RequiredApprovals)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.FallbackTeams, err = parseList(fallbackTeams); err != nil {
+		return nil, err
+	}
+	// an empty list reads back as nil like an omitted field of the other backends
+	if len(team.FallbackTeams) == 0 {
+		team.FallbackTeams = nil
+	}
+
+	return &team, nil
+}
@@ -0,0 +1,27 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+type unitOfWorkKey struct{}
+
+// UnitOfWork runs the work in a transaction, the repositories use it through the ctx.
+// The transaction holds the write lock of the file until it ends.
+type UnitOfWork struct {
+	db *sql.DB
+}
+
+func NewUnitOfWork(client *Client) *UnitOfWork {
+	return &UnitOfWork{
+		db: client.DB(),
+	}
+}
+
+// Do runs fn in a transaction, a Do within fn runs in a savepoint of it
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return inTx(ctx, u.db, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, unitOfWorkKey{}, tx))
+	})
+}
@@ -0,0 +1,406 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+const userColumns = "u.user_id, u.username, u.team_name, u.is_active, u.max_open_reviews, u.email"
+
+type UserRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+func NewUserRepository(client *Client, logger *zap.Logger) *UserRepository {
+	return &UserRepository{
+		db:     client.DB(),
+		logger: logger,
+	}
+}
+
+// CreateOrUpdate upserts the user. Like the mongodb repository it keeps the stored email when the new one is empty
+// and the stored absences when Absences is nil.
+func (r *UserRepository) CreateOrUpdate(ctx context.Context, user *domain.User) error {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO users (user_id, username, team_name, is_active, max_open_reviews, email)
+			VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+			ON CONFLICT (user_id) DO UPDATE SET
+				username = excluded.username,
+				team_name = excluded.team_name,
+				is_active = excluded.is_active,
+				max_open_reviews = excluded.max_open_reviews,
+				email = COALESCE(NULLIF(excluded.email, ''), users.email)`,
+			user.UserID, user.Username, user.TeamName, user.IsActive, user.MaxOpenReviews, user.Email)
+		if err != nil || user.Absences == nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM absences WHERE user_id = ?1", user.UserID); err != nil {
+			return err
+		}
+		for i := range user.Absences {
+			if err := insertAbsence(ctx, tx, user.UserID, &user.Absences[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed to create or update user", zap.Error(err), zap.String("user_id", user.UserID))
+		return fmt.Errorf("failed to create or update user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE u.user_id = ?1", userID)
+	if err != nil {
+		r.logger.Error("failed to get user by ID", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return users[0], nil
+}
+
+func (r *UserRepository) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	if len(userIDs) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	var p params
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE "+inList(&p, "u.user_id", userIDs)+" ORDER BY u.user_id", p...)
+	if err != nil {
+		r.logger.Error("failed to get users by IDs", zap.Error(err))
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) GetActiveByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE u.team_name = ?1 AND u.is_active ORDER BY u.user_id", teamName)
+	if err != nil {
+		r.logger.Error("failed to get active users by team", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get active users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateIsActive(ctx context.Context, userID string, isActive bool) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE users SET is_active = ?2 WHERE user_id = ?1", userID, isActive)
+	if err != nil {
+		r.logger.Error("failed to update user is_active", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateIsActiveMany(ctx context.Context, userIDs []string, isActive bool) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	p := params{isActive}
+	if _, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE users SET is_active = ?1 WHERE "+inList(&p, "user_id", userIDs), p...); err != nil {
+		r.logger.Error("failed to update users is_active", zap.Error(err), zap.Int("count", len(userIDs)))
+		return fmt.Errorf("failed to update users: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE u.team_name = ?1 ORDER BY u.user_id", teamName)
+	if err != nil {
+		r.logger.Error("failed to get users by team", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) List(ctx context.Context, query repository.UserListQuery) ([]*domain.User, error) {
+	var p params
+	var conditions []string
+	if query.TeamName != "" {
+		conditions = append(conditions, "u.team_name = "+p.add(query.TeamName))
+	}
+	if query.IsActive != nil {
+		conditions = append(conditions, "u.is_active = "+p.add(*query.IsActive))
+	}
+
+	sortColumn := "u.user_id"
+	if query.SortBy == domain.UserSortUsername {
+		sortColumn = "u.username"
+	}
+	order := keyset{sortColumn: sortColumn, idColumn: "u.user_id", descending: query.Descending}
+	if query.AfterUserID != "" {
+		conditions = append(conditions, order.after(&p, query.AfterValue, query.AfterUserID))
+	}
+
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u"+where(conditions)+order.orderBy()+limitClause(query.Limit), p...)
+	if err != nil {
+		r.logger.Error("failed to list users", zap.Error(err))
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, userID string, update repository.UserProfileUpdate) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE users SET
+			username = COALESCE(?2, username),
+			email = COALESCE(?3, email),
+			max_open_reviews = COALESCE(?4, max_open_reviews)
+		WHERE user_id = ?1`,
+		userID, update.Username, update.Email, update.MaxOpenReviews)
+	if err != nil {
+		r.logger.Error("failed to update user profile", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update user profile: %w", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateTeam(ctx context.Context, userID, teamName string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE users SET team_name = ?2 WHERE user_id = ?1", userID, teamName)
+	if err != nil {
+		r.logger.Error("failed to update user team", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update user team: %w", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) RenameTeam(ctx context.Context, oldName, newName string) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE users SET team_name = ?2 WHERE team_name = ?1", oldName, newName); err != nil {
+		r.logger.Error("failed to rename team of users", zap.Error(err), zap.String("team_name", oldName))
+		return fmt.Errorf("failed to rename team of users: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) ListTeamNames(ctx context.Context) ([]string, error) {
+	names, err := listStrings(ctx, conn(ctx, r.db), "SELECT DISTINCT team_name FROM users WHERE team_name <> '' ORDER BY team_name")
+	if err != nil {
+		r.logger.Error("failed to list team names of users", zap.Error(err))
+		return nil, fmt.Errorf("failed to list team names: %w", err)
+	}
+
+	return names, nil
+}
+
+func (r *UserRepository) GetByEmails(ctx context.Context, emails []string) ([]*domain.User, error) {
+	if len(emails) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	var p params
+	users, err := r.find(ctx, "SELECT "+userColumns+" FROM users u WHERE u.email <> '' AND "+inList(&p, "u.email", emails)+" ORDER BY u.user_id", p...)
+	if err != nil {
+		r.logger.Error("failed to get users by emails", zap.Error(err))
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) AddAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	err := insertAbsence(ctx, conn(ctx, r.db), userID, absence)
+	if isForeignKeyViolation(err) {
+		return domain.ErrUserNotFound
+	}
+	if err != nil {
+		r.logger.Error("failed to add absence", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to add absence: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) RemoveAbsence(ctx context.Context, userID, absenceID string) error {
+	var userExists, removed bool
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE user_id = ?1)", userID).Scan(&userExists); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, "DELETE FROM absences WHERE user_id = ?1 AND absence_id = ?2", userID, absenceID)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		removed = affected > 0
+		return err
+	})
+	if err != nil {
+		r.logger.Error("failed to remove absence", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to remove absence: %w", err)
+	}
+
+	if !userExists {
+		return domain.ErrUserNotFound
+	}
+	if !removed {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) ListAbsenceHandoffs(ctx context.Context, at time.Time) ([]*domain.User, error) {
+	users, err := r.find(ctx, `SELECT `+userColumns+` FROM users u
+		WHERE EXISTS (
+			SELECT 1 FROM absences a
+			WHERE a.user_id = u.user_id AND a.handoff AND a.handed_off_at IS NULL AND a.starts_at <= ?1 AND a.ends_at > ?1
+		)
+		ORDER BY u.user_id`, at.UnixNano())
+	if err != nil {
+		r.logger.Error("failed to find users with due absence handoffs", zap.Error(err))
+		return nil, fmt.Errorf("failed to find users with due absence handoffs: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateAbsence(ctx context.Context, userID string, absence *domain.Absence) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE absences
+		SET starts_at = ?3, ends_at = ?4, reason = ?5, handoff = ?6, handed_off_at = ?7
+		WHERE user_id = ?1 AND absence_id = ?2`,
+		userID, absence.AbsenceID, absence.From.UnixNano(), absence.To.UnixNano(), absence.Reason, absence.Handoff, timeArg(absence.HandedOffAt))
+	if err != nil {
+		r.logger.Error("failed to update absence", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to update absence: %w", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) MarkAbsenceHandedOff(ctx context.Context, userID, absenceID string, at time.Time) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE absences SET handed_off_at = ?3 WHERE user_id = ?1 AND absence_id = ?2", userID, absenceID, at.UnixNano())
+	if err != nil {
+		r.logger.Error("failed to mark absence handed off", zap.Error(err), zap.String("user_id", userID))
+		return fmt.Errorf("failed to mark absence handed off: %w", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrAbsenceNotFound
+	}
+
+	return nil
+}
+
+// find runs a query selecting userColumns and loads the absences of the found users, no rows give an empty slice
+func (r *UserRepository) find(ctx context.Context, query string, args ...any) ([]*domain.User, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	byID := make(map[string]*domain.User)
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews, &user.Email); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+		byID[user.UserID] = &user
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return users, nil
+	}
+
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.UserID)
+	}
+
+	var p params
+	absences, err := conn(ctx, r.db).QueryContext(ctx, `SELECT user_id, absence_id, starts_at, ends_at, reason, handoff, handed_off_at
+		FROM absences WHERE `+inList(&p, "user_id", ids)+` ORDER BY position`, p...)
+	if err != nil {
+		return nil, err
+	}
+	defer absences.Close()
+
+	for absences.Next() {
+		var userID string
+		var a domain.Absence
+		var from, to int64
+		var handedOffAt sql.NullInt64
+		if err := absences.Scan(&userID, &a.AbsenceID, &from, &to, &a.Reason, &a.Handoff, &handedOffAt); err != nil {
+			return nil, err
+		}
+		a.From = time.Unix(0, from).UTC()
+		a.To = time.Unix(0, to).UTC()
+		a.HandedOffAt = timeValue(handedOffAt)
+
+		user := byID[userID]
+		user.Absences = append(user.Absences, a)
+	}
+
+	return users, absences.Err()
+}
+
+func insertAbsence(ctx context.Context, db querier, userID string, absence *domain.Absence) error {
+	_, err := db.ExecContext(ctx, `INSERT INTO absences (user_id, absence_id, starts_at, ends_at, reason, handoff, handed_off_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)`,
+		userID, absence.AbsenceID, absence.From.UnixNano(), absence.To.UnixNano(), absence.Reason, absence.Handoff, timeArg(absence.HandedOffAt))
+	return err
+}
+
+// listStrings runs a query selecting one text column
+func listStrings(ctx context.Context, db querier, query string, args ...any) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
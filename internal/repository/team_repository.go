@@ -12,4 +12,36 @@ type TeamRepository interface {
 	GetByName(ctx context.Context, teamName string) (*domain.Team, error)
 
 	Exists(ctx context.Context, teamName string) (bool, error)
+
+	// GetWithMembers returns the team with Members read from users of the team
+	GetWithMembers(ctx context.Context, teamName string) (*domain.Team, error)
+
+	ListNames(ctx context.Context) ([]string, error)
+
+	// List returns a page of teams with their members
+	List(ctx context.Context, query TeamListQuery) ([]*domain.Team, error)
+
+	// Rename changes the team name, including its mentions in fallback_teams of other teams.
+	// Returns ErrTeamExists if newName is taken.
+	Rename(ctx context.Context, oldName, newName string) error
+
+	// Delete removes the team and drops it from fallback_teams of other teams
+	Delete(ctx context.Context, teamName string) error
+
+	// ListLegacyMembers returns members arrays left in team documents by versions that stored the roster
+	// in both teams and users, keyed by team name
+	ListLegacyMembers(ctx context.Context) (map[string][]domain.TeamMember, error)
+
+	DropLegacyMembers(ctx context.Context, teamName string) error
+}
+
+// TeamListQuery selects a page of teams ordered by team_name
+type TeamListQuery struct {
+	Descending bool
+
+	// team_name of the last team of the previous page, empty means the first page
+	AfterTeamName string
+
+	// 0 means no limit
+	Limit int
 }
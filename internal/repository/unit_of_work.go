@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// UnitOfWork makes several repository writes one: they are all kept or all discarded.
+// Repository calls take part in the unit of work when they are made with the ctx passed to fn.
+type UnitOfWork interface {
+	// Do runs fn and keeps its writes when it returns nil. When fn fails its writes are discarded
+	// and its error is returned as is. A Do within fn joins the unit of work it runs in.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"assignment-service/internal/domain"
 )
@@ -11,9 +12,73 @@ type UserRepository interface {
 
 	GetByID(ctx context.Context, userID string) (*domain.User, error)
 
+	// GetByIDs returns the existing users among userIDs, unknown ids are skipped
+	GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error)
+
 	GetActiveByTeam(ctx context.Context, teamName string) ([]*domain.User, error)
 
 	UpdateIsActive(ctx context.Context, userID string, isActive bool) error
 
+	// UpdateIsActiveMany sets is_active of all given users in one write, unknown ids are ignored
+	UpdateIsActiveMany(ctx context.Context, userIDs []string, isActive bool) error
+
 	GetByTeam(ctx context.Context, teamName string) ([]*domain.User, error)
+
+	// List returns a page of users
+	List(ctx context.Context, query UserListQuery) ([]*domain.User, error)
+
+	// UpdateProfile sets the given profile fields of the user in one write, other fields and absences are kept
+	UpdateProfile(ctx context.Context, userID string, update UserProfileUpdate) error
+
+	// UpdateTeam sets team_name of the user, empty teamName leaves the user without a team
+	UpdateTeam(ctx context.Context, userID, teamName string) error
+
+	// RenameTeam moves all users of oldName to newName in one write
+	RenameTeam(ctx context.Context, oldName, newName string) error
+
+	// ListTeamNames returns the distinct non-empty team names of users
+	ListTeamNames(ctx context.Context) ([]string, error)
+
+	// GetByEmails returns users having one of the normalized emails
+	GetByEmails(ctx context.Context, emails []string) ([]*domain.User, error)
+
+	AddAbsence(ctx context.Context, userID string, absence *domain.Absence) error
+
+	// UpdateAbsence replaces the absence having the same id in place, HandedOffAt included.
+	// Returns ErrAbsenceNotFound if the user has no absence with the id
+	UpdateAbsence(ctx context.Context, userID string, absence *domain.Absence) error
+
+	// RemoveAbsence returns ErrAbsenceNotFound if the user has no absence with the id
+	RemoveAbsence(ctx context.Context, userID, absenceID string) error
+
+	// ListAbsenceHandoffs returns users having an absence whose handoff is due at the given time
+	ListAbsenceHandoffs(ctx context.Context, at time.Time) ([]*domain.User, error)
+
+	MarkAbsenceHandedOff(ctx context.Context, userID, absenceID string, at time.Time) error
+}
+
+// UserProfileUpdate holds the profile fields to set, nil fields are left as is
+type UserProfileUpdate struct {
+	Username *string
+	// normalized, an empty email removes it
+	Email          *string
+	MaxOpenReviews *int
+}
+
+// UserListQuery selects a page of users ordered by SortBy and then by user_id
+type UserListQuery struct {
+	TeamName string
+	// nil means both active and inactive users
+	IsActive *bool
+
+	// domain.UserSortID or domain.UserSortUsername
+	SortBy     string
+	Descending bool
+
+	// sort value and user_id of the last user of the previous page, empty AfterUserID means the first page
+	AfterValue  string
+	AfterUserID string
+
+	// 0 means no limit
+	Limit int
 }
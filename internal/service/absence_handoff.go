@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// AbsenceHandoff periodically hands off open reviews of users whose absence with handoff has started
+type AbsenceHandoff struct {
+	userRepo  repository.UserRepository
+	prService *PRService
+	interval  time.Duration
+	logger    *zap.Logger
+}
+
+func NewAbsenceHandoff(userRepo repository.UserRepository, prService *PRService, interval time.Duration, logger *zap.Logger) *AbsenceHandoff {
+	return &AbsenceHandoff{
+		userRepo:  userRepo,
+		prService: prService,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Run checks absences every interval until ctx is done
+func (j *AbsenceHandoff) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.RunOnce(ctx, time.Now()); err != nil {
+			j.logger.Error("absence handoff failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce releases reviews of every user whose absence handoff is due at now and returns the number of handed off users.
+// An absence is marked handed off only after all its reviews were released, so failed users are retried on the next run.
+func (j *AbsenceHandoff) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	users, err := j.userRepo.ListAbsenceHandoffs(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	handedOff := 0
+	for _, user := range users {
+		var due []string
+		for _, absence := range user.Absences {
+			if absence.HandoffDue(now) {
+				due = append(due, absence.AbsenceID)
+			}
+		}
+		if len(due) == 0 {
+			continue
+		}
+
+		results, err := j.prService.ReleaseReviewer(ctx, user.UserID, domain.PREventReasonAbsent)
+		if err != nil {
+			j.logger.Error("failed to hand off reviews of absent user", zap.Error(err), zap.String("user_id", user.UserID))
+			continue
+		}
+		// the user still reviews the PRs that failed, the absence stays due so the next run retries them
+		if failed := countFailed(results); failed > 0 {
+			j.logger.Warn("some reviews of absent user were not handed off",
+				zap.String("user_id", user.UserID),
+				zap.Int("failed", failed))
+			continue
+		}
+
+		for _, absenceID := range due {
+			if err := j.userRepo.MarkAbsenceHandedOff(ctx, user.UserID, absenceID, now); err != nil {
+				j.logger.Error("failed to mark absence handed off",
+					zap.Error(err),
+					zap.String("user_id", user.UserID),
+					zap.String("absence_id", absenceID))
+			}
+		}
+
+		handedOff++
+		j.logger.Info("reviews of absent user handed off",
+			zap.String("user_id", user.UserID),
+			zap.Int("reassignments", len(results)))
+	}
+
+	return handedOff, nil
+}
+
+func countFailed(results []*domain.ReviewerReassignment) int {
+	failed := 0
+	for _, result := range results {
+		if result.Outcome == domain.ReassignmentFailed {
+			failed++
+		}
+	}
+	return failed
+}
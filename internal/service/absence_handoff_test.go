@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAbsenceHandoffRunOnce(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	absent := &domain.User{UserID: "user-2", TeamName: "team-1", IsActive: true, Absences: []domain.Absence{
+		{AbsenceID: "abs-1", From: now.Add(-time.Hour), To: now.Add(24 * time.Hour), Handoff: true},
+		{AbsenceID: "abs-2", From: now.Add(48 * time.Hour), To: now.Add(72 * time.Hour), Handoff: true},
+	}}
+
+	t.Run("hands off reviews and marks due absences", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		eventRepo := new(mocks.MockPREventRepository)
+		prService := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, eventRepo, mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+		job := NewAbsenceHandoff(mockUserRepo, prService, time.Minute, logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}},
+		}
+
+		mockUserRepo.On("ListAbsenceHandoffs", ctx, now).Return([]*domain.User{absent}, nil)
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(absent, nil)
+		mockPRRepo.On("GetByReviewer", ctx, "user-2").Return(prs, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		// the absent user is still active, candidate selection has to skip them
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{absent, {UserID: "user-3", IsActive: true}}, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+		eventRepo.On("Append", ctx, mock.MatchedBy(func(events []*domain.PREvent) bool {
+			return len(events) == 1 && events[0].Reason == domain.PREventReasonAbsent
+		})).Return(nil).Once()
+		mockUserRepo.On("MarkAbsenceHandedOff", ctx, "user-2", "abs-1", now).Return(nil).Once()
+
+		handedOff, err := job.RunOnce(ctx, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, handedOff)
+		assert.Equal(t, []string{"user-3"}, prs[0].AssignedReviewers)
+		mockUserRepo.AssertExpectations(t)
+		eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed release is retried later", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		prService := NewPRService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+		job := NewAbsenceHandoff(mockUserRepo, prService, time.Minute, logger)
+
+		mockUserRepo.On("ListAbsenceHandoffs", ctx, now).Return([]*domain.User{absent}, nil)
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(absent, nil)
+		mockPRRepo.On("GetByReviewer", ctx, "user-2").Return(nil, assert.AnError)
+
+		handedOff, err := job.RunOnce(ctx, now)
+
+		require.NoError(t, err)
+		assert.Zero(t, handedOff)
+		mockUserRepo.AssertNotCalled(t, "MarkAbsenceHandedOff")
+	})
+
+	t.Run("absence stays due while a PR failed", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		prService := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+		job := NewAbsenceHandoff(mockUserRepo, prService, time.Minute, logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}},
+			{PullRequestID: "pr-2", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}},
+		}
+
+		mockUserRepo.On("ListAbsenceHandoffs", ctx, now).Return([]*domain.User{absent}, nil)
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(absent, nil)
+		mockPRRepo.On("GetByReviewer", ctx, "user-2").Return(prs, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{{UserID: "user-3", IsActive: true}}, nil)
+		mockPRRepo.On("Update", ctx, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return pr.PullRequestID == "pr-1"
+		})).Return(nil)
+		mockPRRepo.On("Update", ctx, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return pr.PullRequestID == "pr-2"
+		})).Return(assert.AnError)
+
+		handedOff, err := job.RunOnce(ctx, now)
+
+		require.NoError(t, err)
+		assert.Zero(t, handedOff)
+		assert.Equal(t, []string{"user-3"}, prs[0].AssignedReviewers)
+		mockUserRepo.AssertNotCalled(t, "MarkAbsenceHandedOff")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		job := NewAbsenceHandoff(mockUserRepo, nil, time.Minute, logger)
+
+		mockUserRepo.On("ListAbsenceHandoffs", ctx, now).Return(nil, assert.AnError)
+
+		_, err := job.RunOnce(ctx, now)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestAbsenceHandoffRun(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	job := NewAbsenceHandoff(mockUserRepo, nil, time.Hour, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockUserRepo.On("ListAbsenceHandoffs", ctx, mock.AnythingOfType("time.Time")).
+		Run(func(mock.Arguments) { cancel() }).
+		Return([]*domain.User{}, nil).Once()
+
+	done := make(chan struct{})
+	go func() {
+		job.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after context cancellation")
+	}
+	mockUserRepo.AssertExpectations(t)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/ical"
+
+	"go.uber.org/zap"
+)
+
+// ImportAbsences creates absences from calendar events for attendees matched to users by email.
+// Absence ids are derived from event UIDs, so importing an updated export changes the existing absences
+// and cancelled events remove them. A failure on one event is reported in its result and does not stop the others.
+func (s *UserService) ImportAbsences(ctx context.Context, calendar io.Reader, handoff bool) ([]*domain.AbsenceImportResult, error) {
+	events, err := ical.Parse(calendar)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidCalendar, err)
+	}
+
+	var emails []string
+	for _, event := range events {
+		for _, person := range event.People() {
+			emails = append(emails, domain.NormalizeEmail(person.Email))
+		}
+	}
+	slices.Sort(emails)
+	emails = slices.Compact(emails)
+
+	users, err := s.userRepo.GetByEmails(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	byEmail := make(map[string][]*domain.User, len(users))
+	for _, user := range users {
+		byEmail[user.Email] = append(byEmail[user.Email], user)
+	}
+
+	results := []*domain.AbsenceImportResult{}
+	for _, event := range events {
+		skip := func(message string) {
+			results = append(results, &domain.AbsenceImportResult{
+				EventUID: event.UID,
+				Summary:  event.Summary,
+				Outcome:  domain.AbsenceImportSkipped,
+				Error:    message,
+			})
+		}
+
+		switch {
+		case event.Err != nil:
+			skip(event.Err.Error())
+			continue
+		case event.Recurring:
+			skip("recurring events are not supported")
+			continue
+		case len(event.People()) == 0:
+			skip("event has no attendees with email")
+			continue
+		}
+
+		for _, person := range event.People() {
+			result := &domain.AbsenceImportResult{
+				EventUID: event.UID,
+				Summary:  event.Summary,
+				Email:    domain.NormalizeEmail(person.Email),
+			}
+			results = append(results, result)
+
+			matched := byEmail[result.Email]
+			if len(matched) != 1 {
+				result.Outcome = domain.AbsenceImportSkipped
+				result.Error = "no user with this email"
+				if len(matched) > 1 {
+					result.Error = "email belongs to several users"
+				}
+				continue
+			}
+
+			s.importEvent(ctx, event, matched[0], handoff, result)
+		}
+	}
+
+	return results, nil
+}
+
+func (s *UserService) importEvent(ctx context.Context, event *ical.Event, user *domain.User, handoff bool, result *domain.AbsenceImportResult) {
+	result.UserID = user.UserID
+	result.AbsenceID = calendarAbsenceID(event)
+
+	var existing *domain.Absence
+	for i := range user.Absences {
+		if user.Absences[i].AbsenceID == result.AbsenceID {
+			existing = &user.Absences[i]
+			break
+		}
+	}
+
+	fail := func(err error) {
+		s.logger.Error("failed to import absence",
+			zap.Error(err),
+			zap.String("user_id", user.UserID),
+			zap.String("event_uid", event.UID))
+		result.Outcome = domain.AbsenceImportFailed
+		result.Error = "failed to save absence"
+	}
+
+	if event.Cancelled {
+		if existing == nil {
+			result.Outcome = domain.AbsenceImportSkipped
+			result.Error = "event is cancelled"
+			return
+		}
+		if err := s.userRepo.RemoveAbsence(ctx, user.UserID, result.AbsenceID); err != nil {
+			fail(err)
+			return
+		}
+		result.Outcome = domain.AbsenceImportRemoved
+		return
+	}
+
+	absence := &domain.Absence{
+		AbsenceID: result.AbsenceID,
+		From:      event.Start,
+		To:        event.End,
+		Reason:    event.Summary,
+		Handoff:   handoff,
+	}
+	if err := absence.Validate(); err != nil {
+		result.Outcome = domain.AbsenceImportSkipped
+		result.Error = err.Error()
+		return
+	}
+
+	if existing != nil {
+		if existing.From.Equal(absence.From) && existing.To.Equal(absence.To) &&
+			existing.Reason == absence.Reason && existing.Handoff == absence.Handoff {
+			result.Outcome = domain.AbsenceImportUnchanged
+			return
+		}
+		// reviews already handed off for the same dates are not handed off again
+		if existing.From.Equal(absence.From) && existing.To.Equal(absence.To) {
+			absence.HandedOffAt = existing.HandedOffAt
+		}
+		if err := s.userRepo.UpdateAbsence(ctx, user.UserID, absence); err != nil {
+			fail(err)
+			return
+		}
+		result.Outcome = domain.AbsenceImportUpdated
+		return
+	}
+
+	if err := s.userRepo.AddAbsence(ctx, user.UserID, absence); err != nil {
+		fail(err)
+		return
+	}
+
+	result.Outcome = domain.AbsenceImportCreated
+}
+
+// calendarAbsenceID is stable across imports of the same event
+func calendarAbsenceID(event *ical.Event) string {
+	key := event.UID
+	if key == "" {
+		key = fmt.Sprintf("%s|%s|%s", event.Start, event.End, event.Summary)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "ical-" + hex.EncodeToString(sum[:8])
+}
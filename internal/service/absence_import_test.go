@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/ical"
+	"assignment-service/internal/repository/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func calendarOf(events ...string) string {
+	return "BEGIN:VCALENDAR\nVERSION:2.0\n" + strings.Join(events, "") + "END:VCALENDAR\n"
+}
+
+func TestUserServiceImportAbsences(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	vacation := "BEGIN:VEVENT\nUID:vacation-1\nSUMMARY:Vacation\nDTSTART;VALUE=DATE:20240701\nDTEND;VALUE=DATE:20240715\n" +
+		"ATTENDEE;CN=Alice:mailto:Alice@Example.com\nATTENDEE:mailto:stranger@example.com\nEND:VEVENT\n"
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("creates absences for matched attendees", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("GetByEmails", ctx, []string{"alice@example.com", "stranger@example.com"}).
+			Return([]*domain.User{{UserID: "user-1", Email: "alice@example.com"}}, nil)
+		mockUserRepo.On("AddAbsence", ctx, "user-1", mock.MatchedBy(func(a *domain.Absence) bool {
+			return strings.HasPrefix(a.AbsenceID, "ical-") && a.From.Equal(from) && a.To.Equal(to) &&
+				a.Reason == "Vacation" && a.Handoff
+		})).Return(nil).Once()
+
+		results, err := service.ImportAbsences(ctx, strings.NewReader(calendarOf(vacation)), true)
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, domain.AbsenceImportCreated, results[0].Outcome)
+		assert.Equal(t, "user-1", results[0].UserID)
+		assert.Equal(t, "alice@example.com", results[0].Email)
+		assert.Equal(t, domain.AbsenceImportSkipped, results[1].Outcome)
+		assert.Equal(t, "no user with this email", results[1].Error)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("reimport keeps, updates and removes existing absences", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		moved := strings.Replace(vacation, "UID:vacation-1", "UID:vacation-2", 1)
+		moved = strings.Replace(moved, "DTEND;VALUE=DATE:20240715", "DTEND;VALUE=DATE:20240720", 1)
+		cancelled := strings.Replace(vacation, "UID:vacation-1", "UID:vacation-3", 1)
+		cancelled = strings.Replace(cancelled, "END:VEVENT", "STATUS:CANCELLED\nEND:VEVENT", 1)
+
+		id := func(uid string) string {
+			return calendarAbsenceID(&ical.Event{UID: uid})
+		}
+		alice := &domain.User{UserID: "user-1", Email: "alice@example.com", Absences: []domain.Absence{
+			{AbsenceID: id("vacation-1"), From: from, To: to, Reason: "Vacation"},
+			{AbsenceID: id("vacation-2"), From: from, To: to, Reason: "Vacation"},
+			{AbsenceID: id("vacation-3"), From: from, To: to, Reason: "Vacation"},
+		}}
+
+		mockUserRepo.On("GetByEmails", ctx, mock.Anything).Return([]*domain.User{alice}, nil)
+		mockUserRepo.On("UpdateAbsence", ctx, "user-1", mock.MatchedBy(func(a *domain.Absence) bool {
+			return a.AbsenceID == id("vacation-2") && a.To.Equal(time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC))
+		})).Return(nil).Once()
+		mockUserRepo.On("RemoveAbsence", ctx, "user-1", id("vacation-3")).Return(nil).Once()
+
+		results, err := service.ImportAbsences(ctx, strings.NewReader(calendarOf(vacation, moved, cancelled)), false)
+
+		require.NoError(t, err)
+		outcomes := make(map[string]string)
+		for _, r := range results {
+			if r.UserID == "user-1" {
+				outcomes[r.EventUID] = r.Outcome
+			}
+		}
+		assert.Equal(t, map[string]string{
+			"vacation-1": domain.AbsenceImportUnchanged,
+			"vacation-2": domain.AbsenceImportUpdated,
+			"vacation-3": domain.AbsenceImportRemoved,
+		}, outcomes)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("update keeps the handoff of the same dates", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		handedOffAt := from.Add(time.Hour)
+		alice := &domain.User{UserID: "user-1", Email: "alice@example.com", Absences: []domain.Absence{
+			{AbsenceID: calendarAbsenceID(&ical.Event{UID: "vacation-1"}), From: from, To: to, Reason: "Trip", Handoff: true, HandedOffAt: &handedOffAt},
+		}}
+		moved := strings.Replace(vacation, "DTEND;VALUE=DATE:20240715", "DTEND;VALUE=DATE:20240720", 1)
+
+		mockUserRepo.On("GetByEmails", ctx, mock.Anything).Return([]*domain.User{alice}, nil)
+		mockUserRepo.On("UpdateAbsence", ctx, "user-1", mock.MatchedBy(func(a *domain.Absence) bool {
+			return a.Reason == "Vacation" && a.HandedOffAt != nil && a.HandedOffAt.Equal(handedOffAt)
+		})).Return(nil).Once()
+		mockUserRepo.On("UpdateAbsence", ctx, "user-1", mock.MatchedBy(func(a *domain.Absence) bool {
+			return a.To.Equal(time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC)) && a.HandedOffAt == nil
+		})).Return(nil).Once()
+
+		for _, calendar := range []string{vacation, moved} {
+			results, err := service.ImportAbsences(ctx, strings.NewReader(calendarOf(calendar)), true)
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.AbsenceImportUpdated, results[0].Outcome)
+		}
+		mockUserRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "RemoveAbsence", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed update keeps the absence", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		alice := &domain.User{UserID: "user-1", Email: "alice@example.com", Absences: []domain.Absence{
+			{AbsenceID: calendarAbsenceID(&ical.Event{UID: "vacation-1"}), From: from, To: to, Reason: "Trip"},
+		}}
+
+		mockUserRepo.On("GetByEmails", ctx, mock.Anything).Return([]*domain.User{alice}, nil)
+		mockUserRepo.On("UpdateAbsence", ctx, "user-1", mock.AnythingOfType("*domain.Absence")).Return(assert.AnError)
+
+		results, err := service.ImportAbsences(ctx, strings.NewReader(calendarOf(vacation)), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AbsenceImportFailed, results[0].Outcome)
+		mockUserRepo.AssertNotCalled(t, "RemoveAbsence", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unsupported and broken events are skipped", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		recurring := "BEGIN:VEVENT\nUID:weekly\nDTSTART:20240701T090000Z\nDTEND:20240701T100000Z\nRRULE:FREQ=WEEKLY\n" +
+			"ATTENDEE:mailto:alice@example.com\nEND:VEVENT\n"
+		noAttendees := "BEGIN:VEVENT\nUID:nobody\nDTSTART;VALUE=DATE:20240701\nEND:VEVENT\n"
+		broken := "BEGIN:VEVENT\nUID:broken\nDTSTART:soon\nATTENDEE:mailto:alice@example.com\nEND:VEVENT\n"
+		empty := "BEGIN:VEVENT\nUID:empty\nDTSTART:20240701T090000Z\nATTENDEE:mailto:alice@example.com\nEND:VEVENT\n"
+		cancelled := "BEGIN:VEVENT\nUID:cancelled\nDTSTART;VALUE=DATE:20240701\nSTATUS:CANCELLED\n" +
+			"ATTENDEE:mailto:alice@example.com\nEND:VEVENT\n"
+
+		mockUserRepo.On("GetByEmails", ctx, []string{"alice@example.com"}).Return([]*domain.User{
+			{UserID: "user-1", Email: "alice@example.com"},
+		}, nil)
+
+		results, err := service.ImportAbsences(ctx, strings.NewReader(calendarOf(recurring, noAttendees, broken, empty, cancelled)), false)
+
+		require.NoError(t, err)
+		require.Len(t, results, 5)
+		for _, r := range results {
+			assert.Equal(t, domain.AbsenceImportSkipped, r.Outcome, r.EventUID)
+			assert.NotEmpty(t, r.Error, r.EventUID)
+		}
+		mockUserRepo.AssertNotCalled(t, "AddAbsence")
+	})
+
+	t.Run("ambiguous email", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("GetByEmails", ctx, mock.Anything).Return([]*domain.User{
+			{UserID: "user-1", Email: "alice@example.com"},
+			{UserID: "user-2", Email: "alice@example.com"},
+		}, nil)
+
+		results, err := service.ImportAbsences(ctx, strings.NewReader(calendarOf(vacation)), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "email belongs to several users", results[0].Error)
+	})
+
+	t.Run("save error is reported per event", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("GetByEmails", ctx, mock.Anything).Return([]*domain.User{{UserID: "user-1", Email: "alice@example.com"}}, nil)
+		mockUserRepo.On("AddAbsence", ctx, "user-1", mock.AnythingOfType("*domain.Absence")).Return(assert.AnError)
+
+		results, err := service.ImportAbsences(ctx, strings.NewReader(calendarOf(vacation)), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AbsenceImportFailed, results[0].Outcome)
+	})
+
+	t.Run("not a calendar", func(t *testing.T) {
+		service := NewUserService(new(mocks.MockUserRepository), logger)
+
+		results, err := service.ImportAbsences(ctx, strings.NewReader("hello"), false)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidCalendar)
+		assert.Nil(t, results)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("GetByEmails", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		_, err := service.ImportAbsences(ctx, strings.NewReader(calendarOf(vacation)), false)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"assignment-service/internal/domain"
+)
+
+// Page size limits of list endpoints
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// encodeCursor turns the position after the last item of a page into an opaque token
+func encodeCursor(position any) string {
+	raw, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor reads a token made by encodeCursor, a malformed token is reported as ErrInvalidQuery
+func decodeCursor(token string, position any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domain.ErrInvalidQuery
+	}
+	if err := json.Unmarshal(raw, position); err != nil {
+		return domain.ErrInvalidQuery
+	}
+	return nil
+}
+
+// pageLimit applies the default to a zero limit and rejects values out of range
+func pageLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultPageLimit, nil
+	}
+	if limit < 0 || limit > MaxPageLimit {
+		return 0, domain.ErrInvalidQuery
+	}
+	return limit, nil
+}
+
+// sortOrder validates an "asc"/"desc" order, empty order is replaced with defaultOrder
+func sortOrder(order, defaultOrder string) (string, error) {
+	switch order {
+	case "":
+		return defaultOrder, nil
+	case "asc", "desc":
+		return order, nil
+	default:
+		return "", domain.ErrInvalidQuery
+	}
+}
@@ -3,8 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"slices"
+	"strings"
 	"time"
 
 	"assignment-service/internal/domain"
@@ -13,25 +13,50 @@ import (
 	"go.uber.org/zap"
 )
 
+// AssignmentPolicy holds reviewer assignment settings shared by all teams
+type AssignmentPolicy struct {
+	// OverflowTeam lends reviewers when the author's team has no free candidates, empty value disables it
+	OverflowTeam string
+	// ReassignTimeLimit bounds bulk reassignment, zero means no limit
+	ReassignTimeLimit time.Duration
+}
+
 type PRService struct {
-	prRepo   repository.PRRepository
-	userRepo repository.UserRepository
-	logger   *zap.Logger
+	prRepo    repository.PRRepository
+	userRepo  repository.UserRepository
+	teamRepo  repository.TeamRepository
+	eventRepo repository.PREventRepository
+	uow       repository.UnitOfWork
+	selectors *SelectorRegistry
+	policy    AssignmentPolicy
+	logger    *zap.Logger
 }
 
 func NewPRService(
 	prRepo repository.PRRepository,
 	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	eventRepo repository.PREventRepository,
+	uow repository.UnitOfWork,
+	selectors *SelectorRegistry,
+	policy AssignmentPolicy,
 	logger *zap.Logger,
 ) *PRService {
 	return &PRService{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		logger:   logger,
+		prRepo:    prRepo,
+		userRepo:  userRepo,
+		teamRepo:  teamRepo,
+		eventRepo: eventRepo,
+		uow:       uow,
+		selectors: selectors,
+		policy:    policy,
+		logger:    logger,
 	}
 }
 
-func (s *PRService) CreatePR(ctx context.Context, prID, prName, authorID string) (*domain.PullRequest, error) {
+// CreatePR creates an OPEN PR and assigns reviewers from the author's team.
+// reviewersCount == 0 means the team default. Draft PRs get reviewers when marked ready.
+func (s *PRService) CreatePR(ctx context.Context, prID, prName, authorID string, reviewersCount int, draft bool) (*domain.PullRequest, error) {
 	exists, err := s.prRepo.Exists(ctx, prID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check PR existence: %w", err)
@@ -45,37 +70,47 @@ func (s *PRService) CreatePR(ctx context.Context, prID, prName, authorID string)
 		return nil, domain.ErrUserNotFound
 	}
 
-	teamMembers, err := s.userRepo.GetActiveByTeam(ctx, author.TeamName)
+	team, err := s.getTeam(ctx, author.TeamName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get team members: %w", err)
+		return nil, err
 	}
 
-	var candidates []*domain.User
-	for _, member := range teamMembers {
-		if member.UserID != authorID {
-			candidates = append(candidates, member)
-		}
+	required, err := team.ReviewersFor(reviewersCount)
+	if err != nil {
+		return nil, err
 	}
 
-	reviewers := s.selectReviewers(candidates, 2)
-
 	now := time.Now()
 	pr := &domain.PullRequest{
 		PullRequestID:     prID,
 		PullRequestName:   prName,
 		AuthorID:          authorID,
 		Status:            domain.PRStatusOpen,
-		AssignedReviewers: reviewers,
+		AssignedReviewers: []string{},
 		CreatedAt:         &now,
+		RequiredReviewers: required,
+	}
+
+	events := []*domain.PREvent{{PullRequestID: prID, Type: domain.PREventCreated, At: now}}
+	if draft {
+		pr.Status = domain.PRStatusDraft
+	} else {
+		pick, err := s.staffPR(ctx, pr, team, now)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, assignedEvents(prID, pick, domain.PREventReasonAssignment, now)...)
 	}
 
 	if err := s.prRepo.Create(ctx, pr); err != nil {
 		return nil, err
 	}
+	s.record(ctx, events...)
 
 	return pr, nil
 }
 
+// MergePR marks the PR as MERGED, refusing it while the PR has fewer approvals than the author's team requires
 func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
 	pr, err := s.prRepo.GetByID(ctx, prID)
 	if err != nil {
@@ -87,14 +122,117 @@ func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullReque
 		return pr, nil
 	}
 
+	if err := pr.EnsureOpen(); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	team, err := s.getTeam(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Approvals() < team.RequiredApprovals {
+		return nil, domain.ErrNotEnoughApprovals
+	}
+
+	now := time.Now()
+	if err := pr.TransitionTo(domain.PRStatusMerged, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		return nil, err
+	}
+	s.record(ctx, &domain.PREvent{PullRequestID: prID, Type: domain.PREventMerged, At: now})
+
+	return pr, nil
+}
+
+// MarkReady moves a DRAFT PR to OPEN and assigns its reviewers
+func (s *PRService) MarkReady(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return s.openPR(ctx, prID, (*domain.PullRequest).MarkReady, domain.PREventReadyForReview)
+}
+
+// ReopenPR moves a CLOSED PR back to OPEN and assigns new reviewers
+func (s *PRService) ReopenPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return s.openPR(ctx, prID, (*domain.PullRequest).Reopen, domain.PREventReopened)
+}
+
+// ClosePR abandons a DRAFT or OPEN PR without merging and releases its reviewers
+func (s *PRService) ClosePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
-	pr.Status = domain.PRStatusMerged
-	pr.MergedAt = &now
+	released := pr.AssignedReviewers
+	if err := pr.TransitionTo(domain.PRStatusClosed, now); err != nil {
+		return nil, err
+	}
 
 	if err := s.prRepo.Update(ctx, pr); err != nil {
 		return nil, err
 	}
 
+	events := []*domain.PREvent{{PullRequestID: prID, Type: domain.PREventClosed, At: now}}
+	for _, reviewerID := range released {
+		events = append(events, &domain.PREvent{
+			PullRequestID: prID,
+			Type:          domain.PREventReviewerUnassigned,
+			At:            now,
+			ReviewerID:    reviewerID,
+			Reason:        domain.PREventReasonClosed,
+		})
+	}
+	s.record(ctx, events...)
+
+	return pr, nil
+}
+
+func (s *PRService) openPR(
+	ctx context.Context,
+	prID string,
+	transition func(*domain.PullRequest, time.Time) error,
+	eventType domain.PREventType,
+) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := transition(pr, now); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	team, err := s.getTeam(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	pick, err := s.staffPR(ctx, pr, team, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	events := []*domain.PREvent{{PullRequestID: prID, Type: eventType, At: now}}
+	s.record(ctx, append(events, assignedEvents(prID, pick, domain.PREventReasonAssignment, now)...)...)
+
 	return pr, nil
 }
 
@@ -104,8 +242,8 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldReviewerID st
 		return nil, "", err
 	}
 
-	if pr.IsMerged() {
-		return nil, "", domain.ErrPRMerged
+	if err := pr.EnsureOpen(); err != nil {
+		return nil, "", err
 	}
 
 	if !s.isReviewerAssigned(pr.AssignedReviewers, oldReviewerID) {
@@ -117,38 +255,204 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldReviewerID st
 		return nil, "", domain.ErrUserNotFound
 	}
 
-	teamMembers, err := s.userRepo.GetActiveByTeam(ctx, oldReviewer.TeamName)
+	team, err := s.getTeam(ctx, oldReviewer.TeamName)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get team members: %w", err)
+		return nil, "", err
 	}
 
-	var candidates []*domain.User
-	for _, member := range teamMembers {
-		if member.UserID != pr.AuthorID &&
-			member.UserID != oldReviewerID &&
-			!s.isReviewerAssigned(pr.AssignedReviewers, member.UserID) {
-			candidates = append(candidates, member)
-		}
+	pick, err := s.pickReviewers(ctx, newCandidatePool(), team, pr.AuthorID, pr.AssignedReviewers, 1)
+	if err != nil {
+		return nil, "", err
 	}
-
-	if len(candidates) == 0 {
+	if len(pick.reviewers) == 0 {
 		return nil, "", domain.ErrNoCandidate
 	}
-
-	newReviewer := s.selectRandomCandidate(candidates)
+	newReviewer := pick.reviewers[0]
+	if pick.fallbackTeam != "" {
+		pr.FallbackTeam = pick.fallbackTeam
+	}
+	if pick.overflowTeam != "" {
+		pr.OverflowTeam = pick.overflowTeam
+	}
 
 	for i, reviewerID := range pr.AssignedReviewers {
 		if reviewerID == oldReviewerID {
-			pr.AssignedReviewers[i] = newReviewer.UserID
+			pr.AssignedReviewers[i] = newReviewer
 			break
 		}
 	}
+	now := time.Now()
+	pr.SyncReviews(now)
 
 	if err := s.prRepo.Update(ctx, pr); err != nil {
 		return nil, "", err
 	}
+	s.record(ctx, &domain.PREvent{
+		PullRequestID:      prID,
+		Type:               domain.PREventReviewerReplaced,
+		At:                 now,
+		ReviewerID:         newReviewer,
+		ReplacedReviewerID: oldReviewerID,
+		TeamName:           pick.from[newReviewer],
+		Reason:             domain.PREventReasonManualReassign,
+	})
 
-	return pr, newReviewer.UserID, nil
+	return pr, newReviewer, nil
+}
+
+// ReleaseReviewer takes the reviewer off every OPEN PR. Each slot goes to an active teammate of the reviewer
+// (or a fallback team), a PR without candidates keeps one reviewer less and is flagged under-staffed.
+// A failure on one PR is reported in its outcome and does not stop the others.
+func (s *PRService) ReleaseReviewer(ctx context.Context, reviewerID, reason string) ([]*domain.ReviewerReassignment, error) {
+	reviewer, err := s.userRepo.GetByID(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	prs, err := s.prRepo.GetByReviewer(ctx, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PRs by reviewer: %w", err)
+	}
+
+	team, err := s.getTeam(ctx, reviewer.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := newCandidatePool()
+	results := []*domain.ReviewerReassignment{}
+	for _, pr := range prs {
+		if pr.Status != domain.PRStatusOpen || !s.isReviewerAssigned(pr.AssignedReviewers, reviewerID) {
+			continue
+		}
+		results = append(results, s.releaseReviewers(ctx, pool, pr, team, []string{reviewerID}, reason)...)
+	}
+
+	return results, nil
+}
+
+// releaseReviewers replaces or drops the leaving reviewers of an OPEN PR with one update,
+// candidates are looked up in pool starting from team
+func (s *PRService) releaseReviewers(ctx context.Context, pool *candidatePool, pr *domain.PullRequest, team *domain.Team, leaving []string, reason string) []*domain.ReviewerReassignment {
+	results := make([]*domain.ReviewerReassignment, 0, len(leaving))
+	fail := func(message string) []*domain.ReviewerReassignment {
+		results = results[:0]
+		for _, reviewerID := range leaving {
+			results = append(results, &domain.ReviewerReassignment{
+				PullRequestID: pr.PullRequestID,
+				OldReviewerID: reviewerID,
+				Outcome:       domain.ReassignmentFailed,
+				Error:         message,
+			})
+		}
+		return results
+	}
+
+	pick, err := s.pickReviewers(ctx, pool, team, pr.AuthorID, pr.AssignedReviewers, len(leaving))
+	if err != nil {
+		s.logger.Error("failed to select reviewers on release",
+			zap.Error(err),
+			zap.String("pr_id", pr.PullRequestID))
+		return fail("failed to select reviewer")
+	}
+
+	// PRs created before the reviewers count was stored needed as many reviewers as they had
+	if pr.RequiredReviewers == 0 {
+		pr.RequiredReviewers = len(pr.AssignedReviewers)
+	}
+
+	now := time.Now()
+	events := make([]*domain.PREvent, 0, len(leaving))
+	for i, reviewerID := range leaving {
+		result := &domain.ReviewerReassignment{
+			PullRequestID: pr.PullRequestID,
+			OldReviewerID: reviewerID,
+		}
+		event := &domain.PREvent{
+			PullRequestID: pr.PullRequestID,
+			At:            now,
+			Reason:        reason,
+		}
+
+		if i < len(pick.reviewers) {
+			newReviewer := pick.reviewers[i]
+			pr.AssignedReviewers[slices.Index(pr.AssignedReviewers, reviewerID)] = newReviewer
+
+			event.Type = domain.PREventReviewerReplaced
+			event.ReviewerID = newReviewer
+			event.ReplacedReviewerID = reviewerID
+			event.TeamName = pick.from[newReviewer]
+			result.NewReviewerID = newReviewer
+			result.Outcome = domain.ReassignmentReplaced
+		} else {
+			pr.AssignedReviewers = slices.DeleteFunc(pr.AssignedReviewers, func(id string) bool {
+				return id == reviewerID
+			})
+
+			event.Type = domain.PREventReviewerUnassigned
+			event.ReviewerID = reviewerID
+			result.Outcome = domain.ReassignmentUnassigned
+		}
+
+		results = append(results, result)
+		events = append(events, event)
+	}
+
+	if pick.fallbackTeam != "" {
+		pr.FallbackTeam = pick.fallbackTeam
+	}
+	if pick.overflowTeam != "" {
+		pr.OverflowTeam = pick.overflowTeam
+	}
+	pr.UnderStaffed = len(pr.AssignedReviewers) < pr.RequiredReviewers
+	pr.SyncReviews(now)
+
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		s.logger.Error("failed to update PR on release",
+			zap.Error(err),
+			zap.String("pr_id", pr.PullRequestID))
+		return fail("failed to update PR")
+	}
+	pool.assigned(pick.reviewers...)
+	pool.released(leaving[:min(len(leaving), len(pick.reviewers))]...)
+	s.record(ctx, events...)
+
+	return results
+}
+
+// SubmitReview records the review of an assigned reviewer on an OPEN PR
+func (s *PRService) SubmitReview(ctx context.Context, prID, reviewerID string, state domain.ReviewState) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := pr.SubmitReview(reviewerID, state, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		return nil, err
+	}
+	s.record(ctx, &domain.PREvent{
+		PullRequestID: prID,
+		Type:          domain.PREventReviewSubmitted,
+		At:            now,
+		ReviewerID:    reviewerID,
+		ReviewState:   state,
+	})
+
+	return pr, nil
+}
+
+// GetHistory returns the PR events in the order they happened
+func (s *PRService) GetHistory(ctx context.Context, prID string) ([]*domain.PREvent, error) {
+	if _, err := s.prRepo.GetByID(ctx, prID); err != nil {
+		return nil, err
+	}
+
+	return s.eventRepo.ListByPR(ctx, prID)
 }
 
 func (s *PRService) GetPRsByReviewer(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
@@ -165,100 +469,545 @@ func (s *PRService) GetPRsByReviewer(ctx context.Context, userID string) ([]*dom
 	return prs, nil
 }
 
-func (s *PRService) ReassignOpenPRsForTeam(ctx context.Context, teamName string) error {
-	users, err := s.userRepo.GetByTeam(ctx, teamName)
+// ReassignOpenPRsForTeam takes the given members of the team off every OPEN PR they review. Their slots go to
+// active members of the team (or its fallback teams) the same way as in ReleaseReviewer.
+// PRs left when the policy's ReassignTimeLimit runs out are reported as skipped, so a repeated call finishes the job.
+// The PRs are reassigned all together or not at all: if one of them fails, the call fails and no PR is changed.
+func (s *PRService) ReassignOpenPRsForTeam(ctx context.Context, teamName string, reviewerIDs []string, reason string) ([]*domain.ReviewerReassignment, error) {
+	var results []*domain.ReviewerReassignment
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		team, err := s.getTeam(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		prs, err := s.prRepo.GetOpenByReviewers(ctx, reviewerIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get open PRs by reviewers: %w", err)
+		}
+
+		results = s.reassignOpenPRs(ctx, team, prs, reviewerIDs, reason)
+		return reassignmentError(results)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get team members: %w", err)
+		return nil, err
 	}
 
-	teamUserIDs := make(map[string]bool)
-	for _, user := range users {
-		teamUserIDs[user.UserID] = true
-	}
+	return results, nil
+}
+
+// ReleaseMovedReviewer takes a user who moved from fromTeam to toTeam off the OPEN PRs they review, except PRs
+// authored by their new teammates. The slots are refilled from fromTeam the same way as in ReassignOpenPRsForTeam,
+// all together or not at all.
+func (s *PRService) ReleaseMovedReviewer(ctx context.Context, userID, fromTeam, toTeam string) ([]*domain.ReviewerReassignment, error) {
+	var results []*domain.ReviewerReassignment
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		team, err := s.getTeam(ctx, fromTeam)
+		if err != nil {
+			return err
+		}
 
-	var prsToReassign []*domain.PullRequest
+		prs, err := s.prRepo.GetOpenByReviewers(ctx, []string{userID})
+		if err != nil {
+			return fmt.Errorf("failed to get open PRs by reviewers: %w", err)
+		}
 
-	for _, user := range users {
-		prs, err := s.prRepo.GetByReviewer(ctx, user.UserID)
+		teammates, err := s.userRepo.GetByTeam(ctx, toTeam)
 		if err != nil {
+			return fmt.Errorf("failed to get team members: %w", err)
+		}
+		newTeam := make(map[string]bool, len(teammates))
+		for _, u := range teammates {
+			newTeam[u.UserID] = true
+		}
+
+		prs = slices.DeleteFunc(prs, func(pr *domain.PullRequest) bool {
+			return newTeam[pr.AuthorID]
+		})
+
+		results = s.reassignOpenPRs(ctx, team, prs, []string{userID}, domain.PREventReasonMovedTeam)
+		return reassignmentError(results)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// reassignmentError fails the unit of work of a bulk reassignment that failed on one of the PRs
+func reassignmentError(results []*domain.ReviewerReassignment) error {
+	for _, result := range results {
+		if result.Outcome == domain.ReassignmentFailed {
+			return fmt.Errorf("failed to reassign PR %s: %s", result.PullRequestID, result.Error)
+		}
+	}
+	return nil
+}
+
+// reassignOpenPRs releases the reviewers on the given OPEN PRs within the policy's ReassignTimeLimit.
+// Candidates and their load are read once for all the PRs, the limit only guards against a slow storage.
+func (s *PRService) reassignOpenPRs(ctx context.Context, team *domain.Team, prs []*domain.PullRequest, reviewerIDs []string, reason string) []*domain.ReviewerReassignment {
+	var deadline time.Time
+	if s.policy.ReassignTimeLimit > 0 {
+		deadline = time.Now().Add(s.policy.ReassignTimeLimit)
+	}
+
+	pool := newCandidatePool()
+	results := []*domain.ReviewerReassignment{}
+	for _, pr := range prs {
+		var leaving []string
+		for _, reviewerID := range pr.AssignedReviewers {
+			if slices.Contains(reviewerIDs, reviewerID) {
+				leaving = append(leaving, reviewerID)
+			}
+		}
+		if len(leaving) == 0 {
 			continue
 		}
 
-		for _, pr := range prs {
-			if pr.Status == domain.PRStatusOpen {
-				if teamUserIDs[pr.AuthorID] {
-					prsToReassign = append(prsToReassign, pr)
-				}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			for _, reviewerID := range leaving {
+				results = append(results, &domain.ReviewerReassignment{
+					PullRequestID: pr.PullRequestID,
+					OldReviewerID: reviewerID,
+					Outcome:       domain.ReassignmentSkipped,
+					Error:         "time limit reached, repeat the request to reassign the rest",
+				})
 			}
+			continue
 		}
+
+		results = append(results, s.releaseReviewers(ctx, pool, pr, team, leaving, reason)...)
 	}
 
-	uniquePRs := make(map[string]*domain.PullRequest)
-	for _, pr := range prsToReassign {
-		uniquePRs[pr.PullRequestID] = pr
+	return results
+}
+
+// getTeam returns the team settings, teams that were never registered via /team/add get the defaults
+func (s *PRService) getTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	team, err := s.teamRepo.GetByName(ctx, teamName)
+	if err == domain.ErrTeamNotFound {
+		return &domain.Team{TeamName: teamName}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 
-	for _, pr := range uniquePRs {
-		author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
-		if err != nil {
+	return team, nil
+}
+
+// staffPR assigns reviewers to the PR until it has RequiredReviewers of them (team default when not set)
+func (s *PRService) staffPR(ctx context.Context, pr *domain.PullRequest, team *domain.Team, now time.Time) (*reviewerPick, error) {
+	if pr.RequiredReviewers == 0 {
+		pr.RequiredReviewers = team.DefaultReviewers()
+	}
+
+	pick, err := s.pickReviewers(ctx, newCandidatePool(), team, pr.AuthorID, pr.AssignedReviewers, pr.RequiredReviewers-len(pr.AssignedReviewers))
+	if err != nil {
+		return nil, err
+	}
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, pick.reviewers...)
+	pr.UnderStaffed = len(pr.AssignedReviewers) < pr.RequiredReviewers
+	pr.FallbackTeam = pick.fallbackTeam
+	pr.OverflowTeam = pick.overflowTeam
+	pr.SyncReviews(now)
+
+	return pick, nil
+}
+
+// assignedEvents describes the reviewers added by pick
+func assignedEvents(prID string, pick *reviewerPick, reason string, now time.Time) []*domain.PREvent {
+	events := make([]*domain.PREvent, 0, len(pick.reviewers))
+	for _, reviewerID := range pick.reviewers {
+		events = append(events, &domain.PREvent{
+			PullRequestID: prID,
+			Type:          domain.PREventReviewerAssigned,
+			At:            now,
+			ReviewerID:    reviewerID,
+			TeamName:      pick.from[reviewerID],
+			Reason:        reason,
+		})
+	}
+	return events
+}
+
+// record appends events to the PR history. The PR itself is already saved at this point,
+// so a failure is logged rather than returned.
+func (s *PRService) record(ctx context.Context, events ...*domain.PREvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	if err := s.eventRepo.Append(ctx, events...); err != nil {
+		s.logger.Error("failed to record PR events",
+			zap.Error(err),
+			zap.String("pr_id", events[0].PullRequestID))
+	}
+}
+
+// reviewerPick is the outcome of reviewer selection for a PR
+type reviewerPick struct {
+	reviewers []string
+	// team every reviewer was taken from
+	from map[string]string
+	// first fallback team that lent a reviewer
+	fallbackTeam string
+	// set when a reviewer was borrowed from the overflow team
+	overflowTeam string
+}
+
+// pickReviewers selects up to count reviewers among the team members, then borrows the missing ones
+// from the team fallback teams in the declared order and finally from the overflow team
+func (s *PRService) pickReviewers(ctx context.Context, pool *candidatePool, team *domain.Team, authorID string, exclude []string, count int) (*reviewerPick, error) {
+	sources := append([]string{team.TeamName}, team.FallbackTeams...)
+	if s.policy.OverflowTeam != "" {
+		sources = append(sources, s.policy.OverflowTeam)
+	}
+
+	pick := &reviewerPick{from: make(map[string]string)}
+	visited := make(map[string]bool, len(sources))
+	for i, teamName := range sources {
+		if len(pick.reviewers) >= count {
+			break
+		}
+		if visited[teamName] {
 			continue
 		}
+		visited[teamName] = true
 
-		teamMembers, err := s.userRepo.GetActiveByTeam(ctx, author.TeamName)
+		candidates, err := s.findCandidates(ctx, pool, teamName, authorID, append(slices.Clone(exclude), pick.reviewers...))
 		if err != nil {
+			return nil, err
+		}
+
+		selected, err := s.selectReviewers(ctx, teamName, candidates, count-len(pick.reviewers))
+		if err != nil {
+			return nil, err
+		}
+		if len(selected) == 0 {
 			continue
 		}
+		pick.reviewers = append(pick.reviewers, selected...)
+		for _, reviewerID := range selected {
+			pick.from[reviewerID] = teamName
+		}
 
-		var candidates []*domain.User
-		for _, member := range teamMembers {
-			if member.UserID != pr.AuthorID &&
-				!s.isReviewerAssigned(pr.AssignedReviewers, member.UserID) {
-				candidates = append(candidates, member)
+		switch {
+		case i == 0:
+		case i <= len(team.FallbackTeams):
+			if pick.fallbackTeam == "" {
+				pick.fallbackTeam = teamName
 			}
+		default:
+			pick.overflowTeam = teamName
 		}
+	}
 
-		newReviewers := s.selectReviewers(candidates, 2)
-		pr.AssignedReviewers = newReviewers
+	return pick, nil
+}
+
+// candidatePool keeps the active members of the teams and the open reviews load of those with a limit
+// for the PRs of one call, so that a bulk reassignment reads them once per team rather than once per PR.
+// The load is kept up to date in memory as reviewers are assigned and released.
+type candidatePool struct {
+	members map[string][]*domain.User
+	load    map[string]int
+}
 
-		if err := s.prRepo.Update(ctx, pr); err != nil {
-			s.logger.Error("failed to update PR during reassignment",
-				zap.Error(err),
-				zap.String("pr_id", pr.PullRequestID))
+func newCandidatePool() *candidatePool {
+	return &candidatePool{
+		members: make(map[string][]*domain.User),
+		load:    make(map[string]int),
+	}
+}
+
+// assigned counts one more open review for every limited reviewer
+func (p *candidatePool) assigned(reviewerIDs ...string) {
+	for _, id := range reviewerIDs {
+		if _, ok := p.load[id]; ok {
+			p.load[id]++
 		}
 	}
+}
 
-	return nil
+// released counts one open review less for every limited reviewer
+func (p *candidatePool) released(reviewerIDs ...string) {
+	for _, id := range reviewerIDs {
+		if _, ok := p.load[id]; ok {
+			p.load[id]--
+		}
+	}
 }
 
-func (s *PRService) selectReviewers(candidates []*domain.User, maxReviewers int) []string {
-	if len(candidates) == 0 {
-		return nil
+// teamMembers returns the active members of the team, reading them and their load on the first call
+func (s *PRService) teamMembers(ctx context.Context, pool *candidatePool, teamName string) ([]*domain.User, error) {
+	if members, ok := pool.members[teamName]; ok {
+		return members, nil
 	}
 
-	count := min(len(candidates), maxReviewers)
+	members, err := s.userRepo.GetActiveByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
 
-	shuffled := slices.Clone(candidates)
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
+	var limited []string
+	for _, member := range members {
+		if member.MaxOpenReviews > 0 {
+			limited = append(limited, member.UserID)
+		}
+	}
 
-	selected := shuffled[:count]
-	reviewers := make([]string, 0, count)
-	for _, u := range selected {
-		reviewers = append(reviewers, u.UserID)
+	if len(limited) > 0 {
+		load, err := s.prRepo.CountOpenByReviewers(ctx, limited)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reviewers load: %w", err)
+		}
+		for _, userID := range limited {
+			pool.load[userID] = load[userID]
+		}
 	}
 
-	return reviewers
+	pool.members[teamName] = members
+	return members, nil
 }
 
-func (s *PRService) selectRandomCandidate(candidates []*domain.User) *domain.User {
+// findCandidates returns active team members except the author, excluded and out-of-office users
+// who have not reached their open reviews limit
+func (s *PRService) findCandidates(ctx context.Context, pool *candidatePool, teamName, authorID string, exclude []string) ([]*domain.User, error) {
+	teamMembers, err := s.teamMembers(ctx, pool, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var candidates []*domain.User
+	for _, member := range teamMembers {
+		if member.UserID == authorID || s.isReviewerAssigned(exclude, member.UserID) || member.IsAbsent(now) {
+			continue
+		}
+		if !member.HasCapacity(pool.load[member.UserID]) {
+			continue
+		}
+		candidates = append(candidates, member)
+	}
+
+	return candidates, nil
+}
+
+// selectReviewers delegates the choice to the strategy configured for the team
+func (s *PRService) selectReviewers(ctx context.Context, teamName string, candidates []*domain.User, count int) ([]string, error) {
 	if len(candidates) == 0 {
-		return nil
+		return nil, nil
 	}
-	idx := rand.Intn(len(candidates))
-	return candidates[idx]
+
+	selected, err := s.selectors.ForTeam(teamName).Select(ctx, teamName, candidates, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewers: %w", err)
+	}
+
+	reviewers := make([]string, 0, len(selected))
+	for _, u := range selected {
+		reviewers = append(reviewers, u.UserID)
+	}
+
+	return reviewers, nil
 }
 
 func (s *PRService) isReviewerAssigned(reviewers []string, userID string) bool {
 	return slices.Contains(reviewers, userID)
 }
+
+// PRListParams are the filters, order and page of the PRs list
+type PRListParams struct {
+	// TeamName filters by the current team of the author
+	TeamName   string
+	AuthorID   string
+	ReviewerID string
+	Status     domain.PRStatus
+	// created_at window, From is inclusive, To is exclusive
+	From *time.Time
+	To   *time.Time
+	// domain.PRSortCreatedAt (default) or domain.PRSortID
+	SortBy string
+	// "asc" or "desc", desc by default
+	Order  string
+	Cursor string
+	Limit  int
+}
+
+type PullRequestsPage struct {
+	PullRequests []*domain.PullRequest `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+// prListCursor is the position after the last PR of a page, the sort is kept to reject mixed up cursors
+type prListCursor struct {
+	SortBy    string     `json:"s"`
+	Order     string     `json:"o"`
+	CreatedAt *time.Time `json:"c,omitempty"`
+	PRID      string     `json:"p"`
+}
+
+// ListPRs returns a page of PRs, ties of created_at are ordered by pull_request_id
+func (s *PRService) ListPRs(ctx context.Context, params PRListParams) (*PullRequestsPage, error) {
+	if params.Status != "" && !params.Status.Valid() {
+		return nil, domain.ErrInvalidQuery
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	query := repository.PRListQuery{
+		Status:     params.Status,
+		ReviewerID: params.ReviewerID,
+		Created:    repository.TimeWindow{From: params.From, To: params.To},
+		SortBy:     params.SortBy,
+	}
+
+	switch query.SortBy {
+	case "":
+		query.SortBy = domain.PRSortCreatedAt
+	case domain.PRSortCreatedAt, domain.PRSortID:
+	default:
+		return nil, domain.ErrInvalidQuery
+	}
+
+	order, err := sortOrder(params.Order, "desc")
+	if err != nil {
+		return nil, err
+	}
+	query.Descending = order == "desc"
+
+	limit, err := pageLimit(params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	// one extra PR tells whether there is a next page
+	query.Limit = limit + 1
+
+	if params.Cursor != "" {
+		var after prListCursor
+		if err := decodeCursor(params.Cursor, &after); err != nil {
+			return nil, err
+		}
+		if after.SortBy != query.SortBy || after.Order != order || after.PRID == "" {
+			return nil, domain.ErrInvalidQuery
+		}
+		query.AfterCreatedAt = after.CreatedAt
+		query.AfterPRID = after.PRID
+	}
+
+	if params.AuthorID != "" {
+		query.AuthorIDs = []string{params.AuthorID}
+	}
+	if params.TeamName != "" {
+		members, err := s.userRepo.GetByTeam(ctx, params.TeamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team members: %w", err)
+		}
+
+		authorIDs := []string{}
+		for _, member := range members {
+			if params.AuthorID == "" || member.UserID == params.AuthorID {
+				authorIDs = append(authorIDs, member.UserID)
+			}
+		}
+		query.AuthorIDs = authorIDs
+	}
+
+	prs, err := s.prRepo.List(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+
+	page := &PullRequestsPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		last := page.PullRequests[limit-1]
+		page.NextCursor = encodeCursor(prListCursor{
+			SortBy:    query.SortBy,
+			Order:     order,
+			CreatedAt: last.CreatedAt,
+			PRID:      last.PullRequestID,
+		})
+	}
+
+	return page, nil
+}
+
+// PRSearchParams are the text query, filters and page of the PR search
+type PRSearchParams struct {
+	// words, "quoted phrases" and -excluded words
+	Query  string
+	Status domain.PRStatus
+	Cursor string
+	Limit  int
+}
+
+type PRSearchPage struct {
+	PullRequests []domain.PullRequestShort `json:"pull_requests"`
+	NextCursor   string                    `json:"next_cursor,omitempty"`
+}
+
+// prSearchCursor is the position after the last hit of a page, the query is kept to reject cursors of other searches
+type prSearchCursor struct {
+	Query  string          `json:"q"`
+	Status domain.PRStatus `json:"st,omitempty"`
+	Score  float64         `json:"sc"`
+	PRID   string          `json:"p"`
+}
+
+// SearchPRs returns a page of PRs matching the query, best matches first, ties are ordered by pull_request_id
+func (s *PRService) SearchPRs(ctx context.Context, params PRSearchParams) (*PRSearchPage, error) {
+	text := strings.TrimSpace(params.Query)
+	if text == "" {
+		return nil, domain.ErrInvalidQuery
+	}
+	if params.Status != "" && !params.Status.Valid() {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	limit, err := pageLimit(params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra hit tells whether there is a next page
+	query := repository.PRSearchQuery{Text: text, Status: params.Status, Limit: limit + 1}
+
+	if params.Cursor != "" {
+		var after prSearchCursor
+		if err := decodeCursor(params.Cursor, &after); err != nil {
+			return nil, err
+		}
+		if after.Query != text || after.Status != params.Status || after.PRID == "" {
+			return nil, domain.ErrInvalidQuery
+		}
+		query.AfterScore = after.Score
+		query.AfterPRID = after.PRID
+	}
+
+	hits, err := s.prRepo.Search(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search PRs: %w", err)
+	}
+
+	page := &PRSearchPage{PullRequests: make([]domain.PullRequestShort, 0, min(len(hits), limit))}
+	if len(hits) > limit {
+		hits = hits[:limit]
+		last := hits[limit-1]
+		page.NextCursor = encodeCursor(prSearchCursor{
+			Query:  text,
+			Status: params.Status,
+			Score:  last.Score,
+			PRID:   last.PullRequestID,
+		})
+	}
+	for _, hit := range hits {
+		page.PullRequests = append(page.PullRequests, hit.PullRequestShort)
+	}
+
+	return page, nil
+}
@@ -6,10 +6,13 @@ import (
 	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+	"assignment-service/internal/repository/memory"
 	"assignment-service/internal/repository/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -20,7 +23,8 @@ func TestPRServiceCreatePR(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 		author := &domain.User{
 			UserID:   "user-1",
@@ -37,10 +41,11 @@ func TestPRServiceCreatePR(t *testing.T) {
 
 		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
 		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
 		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
 		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
-		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1")
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, pr)
@@ -54,14 +59,45 @@ func TestPRServiceCreatePR(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 
+	t.Run("out-of-office members are skipped", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		now := time.Now()
+		author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+		teamMembers := []*domain.User{
+			{UserID: "user-2", TeamName: "team-1", IsActive: true, Absences: []domain.Absence{
+				{AbsenceID: "abs-1", From: now.Add(-time.Hour), To: now.Add(time.Hour)},
+			}},
+			{UserID: "user-3", TeamName: "team-1", IsActive: true, Absences: []domain.Absence{
+				{AbsenceID: "abs-2", From: now.Add(-48 * time.Hour), To: now.Add(-24 * time.Hour)},
+			}},
+			{UserID: "user-4", TeamName: "team-1", IsActive: true},
+		}
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"user-3", "user-4"}, pr.AssignedReviewers)
+	})
+
 	t.Run("PR already exists", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 		mockPRRepo.On("Exists", ctx, "pr-1").Return(true, nil)
 
-		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1")
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
 
 		assert.Error(t, err)
 		assert.Equal(t, domain.ErrPRExists, err)
@@ -72,12 +108,13 @@ func TestPRServiceCreatePR(t *testing.T) {
 	t.Run("user not found", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
 		mockUserRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
 
-		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1")
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
 
 		assert.Error(t, err)
 		assert.Equal(t, domain.ErrUserNotFound, err)
@@ -89,7 +126,8 @@ func TestPRServiceCreatePR(t *testing.T) {
 	t.Run("no candidates for review", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 		author := &domain.User{
 			UserID:   "user-1",
@@ -100,10 +138,11 @@ func TestPRServiceCreatePR(t *testing.T) {
 
 		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
 		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
 		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{}, nil)
 		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
-		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1")
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, pr)
@@ -113,6 +152,110 @@ func TestPRServiceCreatePR(t *testing.T) {
 	})
 }
 
+func TestPRServiceCreatePRReviewersCount(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+	teamMembers := []*domain.User{
+		author,
+		{UserID: "user-2", TeamName: "team-1", IsActive: true},
+		{UserID: "user-3", TeamName: "team-1", IsActive: true},
+		{UserID: "user-4", TeamName: "team-1", IsActive: true},
+	}
+
+	t.Run("team default count", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(&domain.Team{TeamName: "team-1", ReviewersCount: 3}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.Len(t, pr.AssignedReviewers, 3)
+		assert.Equal(t, 3, pr.RequiredReviewers)
+		assert.False(t, pr.UnderStaffed)
+	})
+
+	t.Run("count requested for PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(&domain.Team{TeamName: "team-1", MaxReviewers: 3}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 1, false)
+
+		require.NoError(t, err)
+		assert.Len(t, pr.AssignedReviewers, 1)
+		assert.Equal(t, 1, pr.RequiredReviewers)
+	})
+
+	t.Run("not enough candidates for required count", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 5, false)
+
+		require.NoError(t, err)
+		assert.Len(t, pr.AssignedReviewers, 3)
+		assert.True(t, pr.UnderStaffed)
+	})
+
+	t.Run("count out of team bounds", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(&domain.Team{TeamName: "team-1", MinReviewers: 2, MaxReviewers: 3}, nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 1, false)
+
+		assert.Equal(t, domain.ErrInvalidReviewersCount, err)
+		assert.Nil(t, pr)
+		mockUserRepo.AssertNotCalled(t, "GetActiveByTeam", mock.Anything, mock.Anything)
+	})
+
+	t.Run("team lookup error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, assert.AnError)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, pr)
+	})
+}
+
 func TestPRServiceMergePR(t *testing.T) {
 	logger := zap.NewNop()
 	ctx := context.Background()
@@ -120,7 +263,8 @@ func TestPRServiceMergePR(t *testing.T) {
 	t.Run("successful merge", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 		now := time.Now()
 		pr := &domain.PullRequest{
@@ -133,6 +277,8 @@ func TestPRServiceMergePR(t *testing.T) {
 		}
 
 		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-1"}, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
 		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Run(func(args mock.Arguments) {
 			updatedPR := args.Get(1).(*domain.PullRequest)
 			assert.Equal(t, domain.PRStatusMerged, updatedPR.Status)
@@ -151,7 +297,8 @@ func TestPRServiceMergePR(t *testing.T) {
 	t.Run("already merged PR", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 		now := time.Now()
 		mergedAt := time.Now()
@@ -178,7 +325,8 @@ func TestPRServiceMergePR(t *testing.T) {
 	t.Run("PR not found", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 		mockPRRepo.On("GetByID", ctx, "pr-1").Return(nil, domain.ErrPRNotFound)
 
@@ -191,231 +339,683 @@ func TestPRServiceMergePR(t *testing.T) {
 	})
 }
 
-func TestPRServiceReassignReviewer(t *testing.T) {
+func TestPRServiceMergePRApprovals(t *testing.T) {
 	logger := zap.NewNop()
 	ctx := context.Background()
 
-	t.Run("successful reassignment", func(t *testing.T) {
-		mockPRRepo := new(mocks.MockPRRepository)
-		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+	author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+	team := &domain.Team{TeamName: "team-1", RequiredApprovals: 2}
 
-		now := time.Now()
+	newPR := func(states ...domain.ReviewState) *domain.PullRequest {
 		pr := &domain.PullRequest{
 			PullRequestID:     "pr-1",
-			PullRequestName:   "Test PR",
 			AuthorID:          "user-1",
 			Status:            domain.PRStatusOpen,
-			AssignedReviewers: []string{"user-2"},
-			CreatedAt:         &now,
+			AssignedReviewers: []string{"user-2", "user-3"},
 		}
-
-		oldReviewer := &domain.User{
-			UserID:   "user-2",
-			Username: "reviewer",
-			TeamName: "team-1",
-			IsActive: true,
+		for i, state := range states {
+			pr.Reviews = append(pr.Reviews, domain.Review{ReviewerID: pr.AssignedReviewers[i], State: state})
 		}
+		return pr
+	}
 
-		newReviewer := &domain.User{
-			UserID:   "user-3",
-			Username: "newreviewer",
-			TeamName: "team-1",
-			IsActive: true,
-		}
+	t.Run("refuses merge without enough approvals", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
-		mockUserRepo.On("GetByID", ctx, "user-2").Return(oldReviewer, nil)
-		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{newReviewer}, nil)
-		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(newPR(domain.ReviewStateApproved, domain.ReviewStateChangesRequested), nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(team, nil)
 
-		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+		result, err := service.MergePR(ctx, "pr-1")
 
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, "user-3", newUserID)
-		assert.Contains(t, result.AssignedReviewers, "user-3")
-		assert.NotContains(t, result.AssignedReviewers, "user-2")
-		mockPRRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
+		assert.Equal(t, domain.ErrNotEnoughApprovals, err)
+		assert.Nil(t, result)
+		mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 	})
 
-	t.Run("PR not found", func(t *testing.T) {
+	t.Run("merges when approvals are reached", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		mockPRRepo.On("GetByID", ctx, "pr-1").Return(nil, domain.ErrPRNotFound)
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(newPR(domain.ReviewStateApproved, domain.ReviewStateApproved), nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(team, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
-		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+		result, err := service.MergePR(ctx, "pr-1")
 
-		assert.Error(t, err)
-		assert.Equal(t, domain.ErrPRNotFound, err)
-		assert.Nil(t, result)
-		assert.Empty(t, newUserID)
-		mockPRRepo.AssertExpectations(t)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusMerged, result.Status)
 	})
 
-	t.Run("PR already merged", func(t *testing.T) {
+	t.Run("author not found", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		now := time.Now()
-		mergedAt := time.Now()
-		pr := &domain.PullRequest{
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(newPR(), nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
+
+		result, err := service.MergePR(ctx, "pr-1")
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestPRServiceSubmitReview(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	newPR := func() *domain.PullRequest {
+		return &domain.PullRequest{
 			PullRequestID:     "pr-1",
-			PullRequestName:   "Test PR",
 			AuthorID:          "user-1",
-			Status:            domain.PRStatusMerged,
-			AssignedReviewers: []string{"user-2"},
-			CreatedAt:         &now,
-			MergedAt:          &mergedAt,
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-2", "user-3"},
 		}
+	}
 
-		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+	t.Run("records approval", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(newPR(), nil)
+		mockPRRepo.On("Update", ctx, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return pr.Approvals() == 1 && len(pr.Reviews) == 2
+		})).Return(nil)
 
-		assert.Error(t, err)
-		assert.Equal(t, domain.ErrPRMerged, err)
-		assert.Nil(t, result)
-		assert.Empty(t, newUserID)
+		result, err := service.SubmitReview(ctx, "pr-1", "user-2", domain.ReviewStateApproved)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReviewStateApproved, result.ReviewOf("user-2").State)
+		assert.Equal(t, domain.ReviewStatePending, result.ReviewOf("user-3").State)
 		mockPRRepo.AssertExpectations(t)
 	})
 
 	t.Run("reviewer not assigned", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
-		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
-
-		now := time.Now()
-		pr := &domain.PullRequest{
-			PullRequestID:     "pr-1",
-			PullRequestName:   "Test PR",
-			AuthorID:          "user-1",
-			Status:            domain.PRStatusOpen,
-			AssignedReviewers: []string{"user-3"}, // user-2 is not assigned
-			CreatedAt:         &now,
-		}
+		service := NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(newPR(), nil)
 
-		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+		result, err := service.SubmitReview(ctx, "pr-1", "user-9", domain.ReviewStateApproved)
 
-		assert.Error(t, err)
 		assert.Equal(t, domain.ErrNotAssigned, err)
 		assert.Nil(t, result)
-		assert.Empty(t, newUserID)
-		mockPRRepo.AssertExpectations(t)
+		mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 	})
 
-	t.Run("no candidate", func(t *testing.T) {
+	t.Run("PR not found", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
-		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		service := NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		now := time.Now()
-		pr := &domain.PullRequest{
-			PullRequestID:     "pr-1",
-			PullRequestName:   "Test PR",
-			AuthorID:          "user-1",
-			Status:            domain.PRStatusOpen,
-			AssignedReviewers: []string{"user-2"},
-			CreatedAt:         &now,
-		}
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(nil, domain.ErrPRNotFound)
 
-		oldReviewer := &domain.User{
-			UserID:   "user-2",
-			Username: "reviewer",
-			TeamName: "team-1",
-			IsActive: true,
-		}
+		result, err := service.SubmitReview(ctx, "pr-1", "user-2", domain.ReviewStateApproved)
 
-		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
-		mockUserRepo.On("GetByID", ctx, "user-2").Return(oldReviewer, nil)
-		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{}, nil)
+		assert.Equal(t, domain.ErrPRNotFound, err)
+		assert.Nil(t, result)
+	})
 
-		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+	t.Run("update error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		assert.Error(t, err)
-		assert.Equal(t, domain.ErrNoCandidate, err)
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(newPR(), nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(assert.AnError)
+
+		result, err := service.SubmitReview(ctx, "pr-1", "user-2", domain.ReviewStateCommented)
+
+		assert.Equal(t, assert.AnError, err)
 		assert.Nil(t, result)
-		assert.Empty(t, newUserID)
-		mockPRRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
 	})
 }
 
-func TestPRServiceGetPRsByReviewer(t *testing.T) {
+func TestPRServiceLifecycle(t *testing.T) {
 	logger := zap.NewNop()
 	ctx := context.Background()
 
-	t.Run("successful get PRs", func(t *testing.T) {
+	author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+	teamMembers := []*domain.User{
+		author,
+		{UserID: "user-2", TeamName: "team-1", IsActive: true},
+		{UserID: "user-3", TeamName: "team-1", IsActive: true},
+	}
+
+	t.Run("CreatePR as draft assigns nobody", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
-
-		user := &domain.User{
-			UserID:   "user-1",
-			Username: "reviewer",
-			TeamName: "team-1",
-			IsActive: true,
-		}
-
-		now := time.Now()
-		prs := []*domain.PullRequest{
-			{
-				PullRequestID:     "pr-1",
-				PullRequestName:   "PR 1",
-				AuthorID:          "author-1",
-				Status:            domain.PRStatusOpen,
-				AssignedReviewers: []string{"user-1"},
-				CreatedAt:         &now,
-			},
-		}
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		mockUserRepo.On("GetByID", ctx, "user-1").Return(user, nil)
-		mockPRRepo.On("GetByReviewer", ctx, "user-1").Return(prs, nil)
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
-		result, err := service.GetPRsByReviewer(ctx, "user-1")
+		pr, err := service.CreatePR(ctx, "pr-1", "PR", "user-1", 1, true)
 
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Len(t, result, 1)
-		mockUserRepo.AssertExpectations(t)
-		mockPRRepo.AssertExpectations(t)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusDraft, pr.Status)
+		assert.Empty(t, pr.AssignedReviewers)
+		assert.Equal(t, 1, pr.RequiredReviewers)
+		assert.False(t, pr.UnderStaffed)
+		mockUserRepo.AssertNotCalled(t, "GetActiveByTeam", mock.Anything, mock.Anything)
 	})
 
-	t.Run("user not found", func(t *testing.T) {
+	t.Run("MarkReady assigns reviewers", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		mockUserRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
+		draft := &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusDraft, AssignedReviewers: []string{}}
 
-		result, err := service.GetPRsByReviewer(ctx, "user-1")
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(draft, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
-		assert.Error(t, err)
-		assert.Equal(t, domain.ErrUserNotFound, err)
-		assert.Nil(t, result)
-		mockUserRepo.AssertExpectations(t)
-	})
-}
+		pr, err := service.MarkReady(ctx, "pr-1")
 
-func TestPRServiceReassignOpenPRsForTeam(t *testing.T) {
-	logger := zap.NewNop()
-	ctx := context.Background()
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+		assert.ElementsMatch(t, []string{"user-2", "user-3"}, pr.AssignedReviewers)
+		assert.Equal(t, domain.DefaultReviewersCount, pr.RequiredReviewers)
+		assert.Len(t, pr.Reviews, 2)
+	})
 
-	t.Run("successful reassignment", func(t *testing.T) {
+	t.Run("MarkReady on open PR", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
-		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewPRService(mockPRRepo, mockUserRepo, logger)
+		service := NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		users := []*domain.User{
-			{UserID: "user-1", Username: "user1", TeamName: "team-1", IsActive: true},
-			{UserID: "user-2", Username: "user2", TeamName: "team-1", IsActive: false},
-		}
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen}, nil)
+
+		pr, err := service.MarkReady(ctx, "pr-1")
+
+		assert.Equal(t, domain.ErrInvalidTransition, err)
+		assert.Nil(t, pr)
+	})
+
+	t.Run("ClosePR releases reviewers", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		open := &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(open, nil)
+		mockPRRepo.On("Update", ctx, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return pr.Status == domain.PRStatusClosed && len(pr.AssignedReviewers) == 0 && pr.ClosedAt != nil
+		})).Return(nil)
+
+		pr, err := service.ClosePR(ctx, "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusClosed, pr.Status)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ClosePR on merged PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusMerged}, nil)
+
+		pr, err := service.ClosePR(ctx, "pr-1")
+
+		assert.Equal(t, domain.ErrPRMerged, err)
+		assert.Nil(t, pr)
+		mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ReopenPR assigns new reviewers", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		closedAt := time.Now()
+		closed := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusClosed,
+			AssignedReviewers: []string{},
+			RequiredReviewers: 1,
+			ClosedAt:          &closedAt,
+		}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(closed, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.ReopenPR(ctx, "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+		assert.Nil(t, pr.ClosedAt)
+		assert.Len(t, pr.AssignedReviewers, 1)
+	})
+
+	t.Run("ReopenPR author not found", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusClosed}, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
+
+		pr, err := service.ReopenPR(ctx, "pr-1")
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+		assert.Nil(t, pr)
+	})
+
+	t.Run("MergePR refuses draft", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusDraft}, nil)
+
+		pr, err := service.MergePR(ctx, "pr-1")
+
+		assert.Equal(t, domain.ErrPRDraft, err)
+		assert.Nil(t, pr)
+	})
+
+	t.Run("ReassignReviewer refuses closed PR", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewPRService(mockPRRepo, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusClosed}, nil)
+
+		pr, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		assert.Equal(t, domain.ErrPRClosed, err)
+		assert.Nil(t, pr)
+		assert.Empty(t, newUserID)
+	})
+}
+
+func TestPRServiceHistory(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+
+	t.Run("CreatePR records creation and assignments", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockEventRepo := new(mocks.MockPREventRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, mockEventRepo, mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{OverflowTeam: "platform"}, logger)
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{
+			author,
+			{UserID: "user-2", TeamName: "team-1", IsActive: true},
+		}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "platform").Return([]*domain.User{
+			{UserID: "user-10", TeamName: "platform", IsActive: true},
+		}, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+		mockEventRepo.On("Append", ctx, mock.MatchedBy(func(events []*domain.PREvent) bool {
+			return len(events) == 3 &&
+				events[0].Type == domain.PREventCreated &&
+				events[1].Type == domain.PREventReviewerAssigned && events[1].ReviewerID == "user-2" && events[1].TeamName == "team-1" &&
+				events[2].ReviewerID == "user-10" && events[2].TeamName == "platform" &&
+				events[2].Reason == domain.PREventReasonAssignment
+		})).Return(nil)
+
+		_, err := service.CreatePR(ctx, "pr-1", "PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("ReassignReviewer records replacement with reason", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockEventRepo := new(mocks.MockPREventRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, mockEventRepo, mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		pr := &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}}
+		oldReviewer := &domain.User{UserID: "user-2", TeamName: "team-1", IsActive: true}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(oldReviewer, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{
+			author, oldReviewer, {UserID: "user-3", TeamName: "team-1", IsActive: true},
+		}, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+		mockEventRepo.On("Append", ctx, mock.MatchedBy(func(events []*domain.PREvent) bool {
+			return len(events) == 1 &&
+				events[0].Type == domain.PREventReviewerReplaced &&
+				events[0].ReviewerID == "user-3" &&
+				events[0].ReplacedReviewerID == "user-2" &&
+				events[0].Reason == domain.PREventReasonManualReassign
+		})).Return(nil)
+
+		_, _, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		require.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("event write failure does not fail the mutation", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockEventRepo := new(mocks.MockPREventRepository)
+		service := NewPRService(mockPRRepo, nil, nil, mockEventRepo, mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		pr := &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+		mockEventRepo.On("Append", ctx, mock.Anything).Return(assert.AnError)
+
+		result, err := service.SubmitReview(ctx, "pr-1", "user-2", domain.ReviewStateApproved)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReviewStateApproved, result.ReviewOf("user-2").State)
+	})
+
+	t.Run("ClosePR records released reviewers", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockEventRepo := new(mocks.MockPREventRepository)
+		service := NewPRService(mockPRRepo, nil, nil, mockEventRepo, mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		pr := &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+		mockEventRepo.On("Append", ctx, mock.MatchedBy(func(events []*domain.PREvent) bool {
+			return len(events) == 2 &&
+				events[0].Type == domain.PREventClosed &&
+				events[1].Type == domain.PREventReviewerUnassigned &&
+				events[1].ReviewerID == "user-2" &&
+				events[1].Reason == domain.PREventReasonClosed
+		})).Return(nil)
+
+		_, err := service.ClosePR(ctx, "pr-1")
+
+		require.NoError(t, err)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetHistory", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockEventRepo := new(mocks.MockPREventRepository)
+		service := NewPRService(mockPRRepo, nil, nil, mockEventRepo, mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		events := []*domain.PREvent{{PullRequestID: "pr-1", Type: domain.PREventCreated}}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1"}, nil)
+		mockEventRepo.On("ListByPR", ctx, "pr-1").Return(events, nil)
+
+		result, err := service.GetHistory(ctx, "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, events, result)
+	})
+
+	t.Run("GetHistory PR not found", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockEventRepo := new(mocks.MockPREventRepository)
+		service := NewPRService(mockPRRepo, nil, nil, mockEventRepo, mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(nil, domain.ErrPRNotFound)
+
+		result, err := service.GetHistory(ctx, "pr-1")
+
+		assert.Equal(t, domain.ErrPRNotFound, err)
+		assert.Nil(t, result)
+		mockEventRepo.AssertNotCalled(t, "ListByPR", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPRServiceReassignReviewer(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("successful reassignment", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		now := time.Now()
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Test PR",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+			CreatedAt:         &now,
+		}
+
+		oldReviewer := &domain.User{
+			UserID:   "user-2",
+			Username: "reviewer",
+			TeamName: "team-1",
+			IsActive: true,
+		}
+
+		newReviewer := &domain.User{
+			UserID:   "user-3",
+			Username: "newreviewer",
+			TeamName: "team-1",
+			IsActive: true,
+		}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(oldReviewer, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{newReviewer}, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, "user-3", newUserID)
+		assert.Contains(t, result.AssignedReviewers, "user-3")
+		assert.NotContains(t, result.AssignedReviewers, "user-2")
+		mockPRRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("PR not found", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(nil, domain.ErrPRNotFound)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		assert.Error(t, err)
+		assert.Equal(t, domain.ErrPRNotFound, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newUserID)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("PR already merged", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		now := time.Now()
+		mergedAt := time.Now()
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Test PR",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusMerged,
+			AssignedReviewers: []string{"user-2"},
+			CreatedAt:         &now,
+			MergedAt:          &mergedAt,
+		}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		assert.Error(t, err)
+		assert.Equal(t, domain.ErrPRMerged, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newUserID)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("reviewer not assigned", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		now := time.Now()
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Test PR",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-3"}, // user-2 is not assigned
+			CreatedAt:         &now,
+		}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		assert.Error(t, err)
+		assert.Equal(t, domain.ErrNotAssigned, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newUserID)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("no candidate", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		now := time.Now()
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Test PR",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+			CreatedAt:         &now,
+		}
+
+		oldReviewer := &domain.User{
+			UserID:   "user-2",
+			Username: "reviewer",
+			TeamName: "team-1",
+			IsActive: true,
+		}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(oldReviewer, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{}, nil)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		assert.Error(t, err)
+		assert.Equal(t, domain.ErrNoCandidate, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newUserID)
+		mockPRRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestPRServiceGetPRsByReviewer(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("successful get PRs", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		user := &domain.User{
+			UserID:   "user-1",
+			Username: "reviewer",
+			TeamName: "team-1",
+			IsActive: true,
+		}
+
+		now := time.Now()
+		prs := []*domain.PullRequest{
+			{
+				PullRequestID:     "pr-1",
+				PullRequestName:   "PR 1",
+				AuthorID:          "author-1",
+				Status:            domain.PRStatusOpen,
+				AssignedReviewers: []string{"user-1"},
+				CreatedAt:         &now,
+			},
+		}
+
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(user, nil)
+		mockPRRepo.On("GetByReviewer", ctx, "user-1").Return(prs, nil)
+
+		result, err := service.GetPRsByReviewer(ctx, "user-1")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Len(t, result, 1)
+		mockUserRepo.AssertExpectations(t)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
+
+		result, err := service.GetPRsByReviewer(ctx, "user-1")
+
+		assert.Error(t, err)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+		assert.Nil(t, result)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestPRServiceReassignOpenPRsForTeam(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("successful reassignment", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 		now := time.Now()
 		prs := []*domain.PullRequest{
@@ -424,71 +1024,780 @@ func TestPRServiceReassignOpenPRsForTeam(t *testing.T) {
 				PullRequestName:   "PR 1",
 				AuthorID:          "user-1",
 				Status:            domain.PRStatusOpen,
-				AssignedReviewers: []string{"user-2"},
+				AssignedReviewers: []string{"user-2", "user-4"},
+				RequiredReviewers: 2,
+				CreatedAt:         &now,
+			},
+			{
+				PullRequestID:     "pr-2",
+				PullRequestName:   "PR 2",
+				AuthorID:          "user-5",
+				Status:            domain.PRStatusOpen,
+				AssignedReviewers: []string{"user-5", "user-6"},
 				CreatedAt:         &now,
 			},
 		}
 
-		newReviewers := []*domain.User{
-			{UserID: "user-3", Username: "user3", TeamName: "team-1", IsActive: true},
+		newReviewers := []*domain.User{
+			{UserID: "user-3", Username: "user3", TeamName: "team-1", IsActive: true},
+		}
+
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(&domain.Team{TeamName: "team-1", ReviewersCount: 2}, nil)
+		mockPRRepo.On("GetOpenByReviewers", ctx, []string{"user-2", "user-4", "user-6"}).Return(prs, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(newReviewers, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		results, err := service.ReassignOpenPRsForTeam(ctx, "team-1", []string{"user-2", "user-4", "user-6"}, domain.PREventReasonDeactivated)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*domain.ReviewerReassignment{
+			{PullRequestID: "pr-1", OldReviewerID: "user-2", NewReviewerID: "user-3", Outcome: domain.ReassignmentReplaced},
+			{PullRequestID: "pr-1", OldReviewerID: "user-4", Outcome: domain.ReassignmentUnassigned},
+			{PullRequestID: "pr-2", OldReviewerID: "user-6", NewReviewerID: "user-3", Outcome: domain.ReassignmentReplaced},
+		}, results)
+
+		assert.Equal(t, []string{"user-3"}, prs[0].AssignedReviewers)
+		assert.True(t, prs[0].UnderStaffed)
+		assert.Equal(t, []string{"user-5", "user-3"}, prs[1].AssignedReviewers)
+		assert.False(t, prs[1].UnderStaffed)
+		// one update per PR, whatever the number of leaving reviewers
+		mockPRRepo.AssertNumberOfCalls(t, "Update", 2)
+	})
+
+	t.Run("reads candidates and their load once for all PRs", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}, RequiredReviewers: 1},
+			{PullRequestID: "pr-2", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}, RequiredReviewers: 1},
+			{PullRequestID: "pr-3", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}, RequiredReviewers: 1},
+		}
+		teamMembers := []*domain.User{
+			{UserID: "user-1", TeamName: "team-1", IsActive: true},
+			{UserID: "user-3", TeamName: "team-1", IsActive: true, MaxOpenReviews: 2},
+			{UserID: "user-4", TeamName: "team-1", IsActive: true, MaxOpenReviews: 1},
+		}
+
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockPRRepo.On("GetOpenByReviewers", ctx, []string{"user-2"}).Return(prs, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil).Once()
+		mockPRRepo.On("CountOpenByReviewers", ctx, []string{"user-3", "user-4"}).Return(map[string]int{}, nil).Once()
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		results, err := service.ReassignOpenPRsForTeam(ctx, "team-1", []string{"user-2"}, domain.PREventReasonDeactivated)
+
+		require.NoError(t, err)
+		picked := map[string]int{}
+		for _, result := range results {
+			assert.Equal(t, domain.ReassignmentReplaced, result.Outcome)
+			picked[result.NewReviewerID]++
+		}
+		// the load counted once is kept up to date, so nobody gets more reviews than their limit
+		assert.Equal(t, map[string]int{"user-3": 2, "user-4": 1}, picked)
+		mockUserRepo.AssertExpectations(t)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("skips PRs after time limit", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{
+			ReassignTimeLimit: time.Millisecond,
+		}, logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}},
+			{PullRequestID: "pr-2", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}},
+		}
+
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockPRRepo.On("GetOpenByReviewers", ctx, []string{"user-2"}).Return(prs, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{{UserID: "user-3", IsActive: true}}, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Run(func(mock.Arguments) {
+			time.Sleep(5 * time.Millisecond)
+		})
+
+		results, err := service.ReassignOpenPRsForTeam(ctx, "team-1", []string{"user-2"}, domain.PREventReasonDeactivated)
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, domain.ReassignmentReplaced, results[0].Outcome)
+		assert.Equal(t, domain.ReassignmentSkipped, results[1].Outcome)
+		assert.Equal(t, []string{"user-2"}, prs[1].AssignedReviewers)
+		mockPRRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, new(mocks.MockUserRepository), mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockPRRepo.On("GetOpenByReviewers", ctx, []string{"user-2"}).Return(nil, assert.AnError)
+
+		results, err := service.ReassignOpenPRsForTeam(ctx, "team-1", []string{"user-2"}, domain.PREventReasonDeactivated)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, results)
+	})
+}
+
+func TestPRServiceReopenDropsOldReviews(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "team-1", ReviewersCount: 1, RequiredApprovals: 1}))
+	for _, userID := range []string{"user-1", "user-2"} {
+		require.NoError(t, repos.Users.CreateOrUpdate(ctx, &domain.User{UserID: userID, TeamName: "team-1", IsActive: true}))
+	}
+	service := NewPRService(repos.PRs, repos.Users, repos.Teams, repos.PREvents, repos.UnitOfWork, NewSelectorRegistry(), AssignmentPolicy{}, zap.NewNop())
+
+	_, err := service.CreatePR(ctx, "pr-1", "PR 1", "user-1", 0, false)
+	require.NoError(t, err)
+	_, err = service.SubmitReview(ctx, "pr-1", "user-2", domain.ReviewStateApproved)
+	require.NoError(t, err)
+	_, err = service.ClosePR(ctx, "pr-1")
+	require.NoError(t, err)
+
+	pr, err := service.ReopenPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Nil(t, pr.ClosedAt)
+
+	// the approval given before the PR was closed does not count, user-2 is back as PENDING
+	stored, err := repos.PRs.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Nil(t, stored.ClosedAt)
+	require.Len(t, stored.Reviews, 1)
+	assert.Equal(t, domain.ReviewStatePending, stored.Reviews[0].State)
+
+	_, err = service.MergePR(ctx, "pr-1")
+	assert.ErrorIs(t, err, domain.ErrNotEnoughApprovals)
+}
+
+// failingPRRepo fails Update of one PR
+type failingPRRepo struct {
+	repository.PRRepository
+	prID string
+}
+
+func (r *failingPRRepo) Update(ctx context.Context, pr *domain.PullRequest) error {
+	if pr.PullRequestID == r.prID {
+		return assert.AnError
+	}
+	return r.PRRepository.Update(ctx, pr)
+}
+
+func TestPRServiceReassignOpenPRsForTeamRollback(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "team-1"}))
+	for _, userID := range []string{"user-1", "user-2", "user-3"} {
+		require.NoError(t, repos.Users.CreateOrUpdate(ctx, &domain.User{UserID: userID, TeamName: "team-1", IsActive: true}))
+	}
+	for _, prID := range []string{"pr-1", "pr-2"} {
+		require.NoError(t, repos.PRs.Create(ctx, &domain.PullRequest{
+			PullRequestID:     prID,
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+			RequiredReviewers: 1,
+		}))
+	}
+
+	prRepo := &failingPRRepo{PRRepository: repos.PRs, prID: "pr-2"}
+	service := NewPRService(prRepo, repos.Users, repos.Teams, repos.PREvents, repos.UnitOfWork, NewSelectorRegistry(), AssignmentPolicy{}, zap.NewNop())
+
+	results, err := service.ReassignOpenPRsForTeam(ctx, "team-1", []string{"user-2"}, domain.PREventReasonDeactivated)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pr-2")
+	assert.Nil(t, results)
+	// pr-1 was reassigned before pr-2 failed and is back as it was, history included
+	pr, err := repos.PRs.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-2"}, pr.AssignedReviewers)
+	events, err := repos.PREvents.ListByPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// without the failure both PRs are reassigned
+	prRepo.prID = ""
+	results, err = service.ReassignOpenPRsForTeam(ctx, "team-1", []string{"user-2"}, domain.PREventReasonDeactivated)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, prID := range []string{"pr-1", "pr-2"} {
+		pr, err := repos.PRs.GetByID(ctx, prID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-3"}, pr.AssignedReviewers)
+	}
+}
+
+func TestPRServiceSelectReviewers(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	service := NewPRService(nil, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+	t.Run("select from multiple candidates", func(t *testing.T) {
+		candidates := []*domain.User{
+			{UserID: "user-1"},
+			{UserID: "user-2"},
+			{UserID: "user-3"},
+			{UserID: "user-4"},
+		}
+
+		reviewers, err := service.selectReviewers(ctx, "team-1", candidates, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, reviewers, 2)
+		for _, reviewer := range reviewers {
+			assert.Contains(t, []string{"user-1", "user-2", "user-3", "user-4"}, reviewer)
+		}
+	})
+
+	t.Run("select all when candidates less than max", func(t *testing.T) {
+		candidates := []*domain.User{
+			{UserID: "user-1"},
+		}
+
+		reviewers, err := service.selectReviewers(ctx, "team-1", candidates, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, reviewers, 1)
+		assert.Equal(t, "user-1", reviewers[0])
+	})
+
+	t.Run("empty candidates", func(t *testing.T) {
+		reviewers, err := service.selectReviewers(ctx, "team-1", []*domain.User{}, 2)
+
+		assert.NoError(t, err)
+		assert.Empty(t, reviewers)
+	})
+
+	t.Run("uses strategy configured for team", func(t *testing.T) {
+		selectors := NewSelectorRegistry()
+		require.NoError(t, selectors.Register("last", lastCandidateSelector{}))
+		require.NoError(t, selectors.SetTeamStrategy("team-2", "last"))
+		svc := NewPRService(nil, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, selectors, AssignmentPolicy{}, logger)
+
+		candidates := []*domain.User{{UserID: "user-1"}, {UserID: "user-2"}}
+
+		reviewers, err := svc.selectReviewers(ctx, "team-2", candidates, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"user-2"}, reviewers)
+	})
+
+	t.Run("selector error", func(t *testing.T) {
+		selectors := NewSelectorRegistry()
+		require.NoError(t, selectors.Register("broken", failingSelector{}))
+		require.NoError(t, selectors.SetDefault("broken"))
+		svc := NewPRService(nil, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, selectors, AssignmentPolicy{}, logger)
+
+		reviewers, err := svc.selectReviewers(ctx, "team-1", []*domain.User{{UserID: "user-1"}}, 1)
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, reviewers)
+	})
+}
+
+func TestPRServiceCustomSelector(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	selectors := NewSelectorRegistry()
+	require.NoError(t, selectors.Register("last", lastCandidateSelector{}))
+	require.NoError(t, selectors.SetDefault("last"))
+
+	t.Run("CreatePR goes through selector", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, selectors, AssignmentPolicy{}, logger)
+
+		author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+		teamMembers := []*domain.User{
+			author,
+			{UserID: "user-2", TeamName: "team-1", IsActive: true},
+			{UserID: "user-3", TeamName: "team-1", IsActive: true},
+			{UserID: "user-4", TeamName: "team-1", IsActive: true},
+		}
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-3", "user-4"}, pr.AssignedReviewers)
+	})
+
+	t.Run("ReassignReviewer goes through selector", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, selectors, AssignmentPolicy{}, logger)
+
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+		}
+		oldReviewer := &domain.User{UserID: "user-2", TeamName: "team-1", IsActive: true}
+		teamMembers := []*domain.User{
+			oldReviewer,
+			{UserID: "user-3", TeamName: "team-1", IsActive: true},
+			{UserID: "user-4", TeamName: "team-1", IsActive: true},
+		}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(oldReviewer, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-4", newUserID)
+		assert.Equal(t, []string{"user-4"}, result.AssignedReviewers)
+	})
+
+	t.Run("ReassignReviewer selector returns nobody", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		emptySelectors := NewSelectorRegistry()
+		require.NoError(t, emptySelectors.Register("nobody", nobodySelector{}))
+		require.NoError(t, emptySelectors.SetDefault("nobody"))
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, emptySelectors, AssignmentPolicy{}, logger)
+
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+		}
+		oldReviewer := &domain.User{UserID: "user-2", TeamName: "team-1", IsActive: true}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(oldReviewer, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{
+			{UserID: "user-3", TeamName: "team-1", IsActive: true},
+		}, nil)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		assert.Equal(t, domain.ErrNoCandidate, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newUserID)
+	})
+}
+
+func TestPRServiceLeastLoadedSelector(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	mockPRRepo := new(mocks.MockPRRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	selectors := NewSelectorRegistry()
+	require.NoError(t, selectors.Register(SelectorLeastLoaded, NewLeastLoadedSelector(mockPRRepo)))
+	require.NoError(t, selectors.SetDefault(SelectorLeastLoaded))
+	mockTeamRepo := new(mocks.MockTeamRepository)
+	service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, selectors, AssignmentPolicy{}, logger)
+
+	author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+	teamMembers := []*domain.User{
+		author,
+		{UserID: "user-2", TeamName: "team-1", IsActive: true},
+		{UserID: "user-3", TeamName: "team-1", IsActive: true},
+		{UserID: "user-4", TeamName: "team-1", IsActive: true},
+	}
+
+	mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+	mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+	mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+	mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+	mockPRRepo.On("CountOpenByReviewers", ctx, []string{"user-2", "user-3", "user-4"}).
+		Return(map[string]int{"user-2": 3, "user-3": 1}, nil)
+	mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+	pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-4", "user-3"}, pr.AssignedReviewers)
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPRServiceReviewCapacity(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+	teamMembers := []*domain.User{
+		author,
+		{UserID: "user-2", TeamName: "team-1", IsActive: true, MaxOpenReviews: 2},
+		{UserID: "user-3", TeamName: "team-1", IsActive: true, MaxOpenReviews: 1},
+		{UserID: "user-4", TeamName: "team-1", IsActive: true},
+	}
+
+	t.Run("CreatePR skips candidates at capacity", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("CountOpenByReviewers", ctx, []string{"user-2", "user-3"}).
+			Return(map[string]int{"user-2": 2}, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"user-3", "user-4"}, pr.AssignedReviewers)
+		assert.False(t, pr.UnderStaffed)
+		assert.Empty(t, pr.OverflowTeam)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("CreatePR flags under-staffed PR when everybody is full", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		full := []*domain.User{
+			author,
+			{UserID: "user-2", TeamName: "team-1", IsActive: true, MaxOpenReviews: 1},
+			{UserID: "user-3", TeamName: "team-1", IsActive: true, MaxOpenReviews: 1},
+		}
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(full, nil)
+		mockPRRepo.On("CountOpenByReviewers", ctx, []string{"user-2", "user-3"}).
+			Return(map[string]int{"user-2": 1, "user-3": 4}, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.Empty(t, pr.AssignedReviewers)
+		assert.True(t, pr.UnderStaffed)
+	})
+
+	t.Run("CreatePR borrows reviewers from overflow team", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{OverflowTeam: "platform"}, logger)
+
+		small := []*domain.User{
+			author,
+			{UserID: "user-2", TeamName: "team-1", IsActive: true},
+		}
+		platform := []*domain.User{
+			{UserID: "user-10", TeamName: "platform", IsActive: true},
+		}
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(small, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "platform").Return(platform, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-2", "user-10"}, pr.AssignedReviewers)
+		assert.False(t, pr.UnderStaffed)
+		assert.Equal(t, "platform", pr.OverflowTeam)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("CreatePR overflow team without free reviewers", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{OverflowTeam: "platform"}, logger)
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{author}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "platform").Return([]*domain.User{}, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.Empty(t, pr.AssignedReviewers)
+		assert.True(t, pr.UnderStaffed)
+		assert.Empty(t, pr.OverflowTeam)
+	})
+
+	t.Run("CreatePR load lookup error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("CountOpenByReviewers", ctx, []string{"user-2", "user-3"}).Return(nil, assert.AnError)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "Test PR", "user-1", 0, false)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, pr)
+		mockPRRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ReassignReviewer skips full candidates", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-4"},
+		}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-4").Return(teamMembers[3], nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("CountOpenByReviewers", ctx, []string{"user-2", "user-3"}).
+			Return(map[string]int{"user-3": 1}, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-4")
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-2", newUserID)
+		assert.Equal(t, []string{"user-2"}, result.AssignedReviewers)
+	})
+
+	t.Run("ReassignReviewer falls back to overflow team", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{OverflowTeam: "platform"}, logger)
+
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-4"},
 		}
 
-		mockUserRepo.On("GetByTeam", ctx, "team-1").Return(users, nil)
-		mockPRRepo.On("GetByReviewer", ctx, "user-1").Return(prs, nil)
-		mockPRRepo.On("GetByReviewer", ctx, "user-2").Return([]*domain.PullRequest{}, nil)
-		mockUserRepo.On("GetByID", ctx, "user-1").Return(users[0], nil)
-		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(newReviewers, nil)
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-4").Return(teamMembers[3], nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("CountOpenByReviewers", ctx, []string{"user-2", "user-3"}).
+			Return(map[string]int{"user-2": 2, "user-3": 1}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "platform").Return([]*domain.User{
+			{UserID: "user-10", TeamName: "platform", IsActive: true},
+		}, nil)
 		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
-		err := service.ReassignOpenPRsForTeam(ctx, "team-1")
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-4")
 
-		assert.NoError(t, err)
-		mockPRRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
+		require.NoError(t, err)
+		assert.Equal(t, "user-10", newUserID)
+		assert.Equal(t, "platform", result.OverflowTeam)
+	})
+
+	t.Run("ReassignReviewer no free candidates", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-4"},
+		}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-4").Return(teamMembers[3], nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
+		mockPRRepo.On("CountOpenByReviewers", ctx, []string{"user-2", "user-3"}).
+			Return(map[string]int{"user-2": 2, "user-3": 1}, nil)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-4")
+
+		assert.Equal(t, domain.ErrNoCandidate, err)
+		assert.Nil(t, result)
+		assert.Empty(t, newUserID)
+		mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 	})
 }
 
-func TestPRServiceSelectReviewers(t *testing.T) {
+func TestPRServiceFallbackTeams(t *testing.T) {
 	logger := zap.NewNop()
-	service := NewPRService(nil, nil, logger)
+	ctx := context.Background()
 
-	t.Run("select from multiple candidates", func(t *testing.T) {
-		candidates := []*domain.User{
-			{UserID: "user-1"},
-			{UserID: "user-2"},
-			{UserID: "user-3"},
-			{UserID: "user-4"},
-		}
+	author := &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}
+	team := &domain.Team{TeamName: "team-1", FallbackTeams: []string{"mobile", "web"}}
 
-		reviewers := service.selectReviewers(candidates, 2)
+	t.Run("CreatePR borrows from fallback teams in order", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{OverflowTeam: "platform"}, logger)
 
-		assert.Len(t, reviewers, 2)
-		for _, reviewer := range reviewers {
-			assert.Contains(t, []string{"user-1", "user-2", "user-3", "user-4"}, reviewer)
-		}
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(team, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{author}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "mobile").Return([]*domain.User{
+			{UserID: "user-20", TeamName: "mobile", IsActive: true},
+		}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "web").Return([]*domain.User{
+			{UserID: "user-30", TeamName: "web", IsActive: true},
+		}, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-20", "user-30"}, pr.AssignedReviewers)
+		assert.Equal(t, "mobile", pr.FallbackTeam)
+		assert.Empty(t, pr.OverflowTeam)
+		assert.False(t, pr.UnderStaffed)
+		mockUserRepo.AssertNotCalled(t, "GetActiveByTeam", ctx, "platform")
 	})
 
-	t.Run("select all when candidates less than max", func(t *testing.T) {
-		candidates := []*domain.User{
-			{UserID: "user-1"},
-		}
+	t.Run("CreatePR does not ask fallback teams when own team is enough", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
-		reviewers := service.selectReviewers(candidates, 2)
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(team, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{
+			author,
+			{UserID: "user-2", TeamName: "team-1", IsActive: true},
+			{UserID: "user-3", TeamName: "team-1", IsActive: true},
+		}, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
 
-		assert.Len(t, reviewers, 1)
-		assert.Equal(t, "user-1", reviewers[0])
+		pr, err := service.CreatePR(ctx, "pr-1", "PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"user-2", "user-3"}, pr.AssignedReviewers)
+		assert.Empty(t, pr.FallbackTeam)
+		mockUserRepo.AssertNotCalled(t, "GetActiveByTeam", ctx, "mobile")
 	})
 
-	t.Run("empty candidates", func(t *testing.T) {
-		reviewers := service.selectReviewers([]*domain.User{}, 2)
+	t.Run("CreatePR uses overflow team after fallback teams", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{OverflowTeam: "platform"}, logger)
 
-		assert.Empty(t, reviewers)
+		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(team, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{author}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "mobile").Return([]*domain.User{}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "web").Return([]*domain.User{}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "platform").Return([]*domain.User{
+			{UserID: "user-10", TeamName: "platform", IsActive: true},
+		}, nil)
+		mockPRRepo.On("Create", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		pr, err := service.CreatePR(ctx, "pr-1", "PR", "user-1", 0, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-10"}, pr.AssignedReviewers)
+		assert.Empty(t, pr.FallbackTeam)
+		assert.Equal(t, "platform", pr.OverflowTeam)
+		assert.True(t, pr.UnderStaffed)
+	})
+
+	t.Run("ReassignReviewer borrows from fallback team", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "user-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"user-2"},
+		}
+		oldReviewer := &domain.User{UserID: "user-2", TeamName: "team-1", IsActive: true}
+
+		mockPRRepo.On("GetByID", ctx, "pr-1").Return(pr, nil)
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(oldReviewer, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(team, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{author, oldReviewer}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "mobile").Return([]*domain.User{}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "web").Return([]*domain.User{
+			{UserID: "user-30", TeamName: "web", IsActive: true},
+		}, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+
+		result, newUserID, err := service.ReassignReviewer(ctx, "pr-1", "user-2")
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-30", newUserID)
+		assert.Equal(t, "web", result.FallbackTeam)
 	})
 }
 
+// lastCandidateSelector deterministically picks candidates from the end of the list
+type lastCandidateSelector struct{}
+
+func (lastCandidateSelector) Select(_ context.Context, _ string, candidates []*domain.User, count int) ([]*domain.User, error) {
+	count = min(count, len(candidates))
+	return candidates[len(candidates)-count:], nil
+}
+
+type failingSelector struct{}
+
+func (failingSelector) Select(context.Context, string, []*domain.User, int) ([]*domain.User, error) {
+	return nil, assert.AnError
+}
+
+type nobodySelector struct{}
+
+func (nobodySelector) Select(context.Context, string, []*domain.User, int) ([]*domain.User, error) {
+	return nil, nil
+}
+
 func TestPRServiceIsReviewerAssigned(t *testing.T) {
 	logger := zap.NewNop()
-	service := NewPRService(nil, nil, logger)
+	service := NewPRService(nil, nil, nil, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 	t.Run("reviewer is assigned", func(t *testing.T) {
 		reviewers := []string{"user-1", "user-2", "user-3"}
@@ -520,7 +1829,8 @@ func TestPRServiceCreatePRCreateError(t *testing.T) {
 	t.Run("error when creating PR in repo", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		svc := NewPRService(mockPRRepo, mockUserRepo, logger)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		svc := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
 
 		author := &domain.User{UserID: "author-1", TeamName: "team-1"}
 		teamMembers := []*domain.User{
@@ -530,12 +1840,13 @@ func TestPRServiceCreatePRCreateError(t *testing.T) {
 
 		mockPRRepo.On("Exists", ctx, "pr-1").Return(false, nil)
 		mockUserRepo.On("GetByID", ctx, "author-1").Return(author, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
 		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return(teamMembers, nil)
 
 		createErr := assert.AnError
 		mockPRRepo.On("Create", ctx, mock.Anything).Return(createErr)
 
-		pr, err := svc.CreatePR(ctx, "pr-1", "Fix bug", "author-1")
+		pr, err := svc.CreatePR(ctx, "pr-1", "Fix bug", "author-1", 0, false)
 
 		assert.Error(t, err)
 		assert.Equal(t, createErr, err)
@@ -545,3 +1856,424 @@ func TestPRServiceCreatePRCreateError(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 }
+
+func TestPRServiceReleaseReviewer(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	reviewer := &domain.User{UserID: "user-2", TeamName: "team-1", IsActive: false}
+
+	t.Run("replaces or drops every open review slot", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		eventRepo := new(mocks.MockPREventRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, eventRepo, mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2", "user-4"}, RequiredReviewers: 2},
+			{PullRequestID: "pr-2", AuthorID: "user-3", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}},
+			{PullRequestID: "pr-3", AuthorID: "user-1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"user-2"}},
+		}
+
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(reviewer, nil)
+		mockPRRepo.On("GetByReviewer", ctx, "user-2").Return(prs, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		// user-3 is the only active teammate: a candidate for pr-1, but the author of pr-2
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{
+			{UserID: "user-3", TeamName: "team-1", IsActive: true},
+		}, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+		eventRepo.On("Append", ctx, mock.MatchedBy(func(events []*domain.PREvent) bool {
+			return len(events) == 1 && events[0].Reason == domain.PREventReasonDeactivated
+		})).Return(nil).Twice()
+
+		results, err := service.ReleaseReviewer(ctx, "user-2", domain.PREventReasonDeactivated)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*domain.ReviewerReassignment{
+			{PullRequestID: "pr-1", OldReviewerID: "user-2", NewReviewerID: "user-3", Outcome: domain.ReassignmentReplaced},
+			{PullRequestID: "pr-2", OldReviewerID: "user-2", Outcome: domain.ReassignmentUnassigned},
+		}, results)
+
+		assert.Equal(t, []string{"user-3", "user-4"}, prs[0].AssignedReviewers)
+		assert.False(t, prs[0].UnderStaffed)
+		assert.Empty(t, prs[1].AssignedReviewers)
+		assert.True(t, prs[1].UnderStaffed)
+		assert.Equal(t, 1, prs[1].RequiredReviewers)
+		assert.Equal(t, []string{"user-2"}, prs[2].AssignedReviewers)
+		mockPRRepo.AssertNumberOfCalls(t, "Update", 2)
+		eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed update is reported and does not stop others", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}},
+			{PullRequestID: "pr-2", AuthorID: "user-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-2"}},
+		}
+
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(reviewer, nil)
+		mockPRRepo.On("GetByReviewer", ctx, "user-2").Return(prs, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockUserRepo.On("GetActiveByTeam", ctx, "team-1").Return([]*domain.User{{UserID: "user-3", IsActive: true}}, nil)
+		mockPRRepo.On("Update", ctx, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return pr.PullRequestID == "pr-1"
+		})).Return(assert.AnError)
+		mockPRRepo.On("Update", ctx, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+			return pr.PullRequestID == "pr-2"
+		})).Return(nil)
+
+		results, err := service.ReleaseReviewer(ctx, "user-2", domain.PREventReasonDeactivated)
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, domain.ReassignmentFailed, results[0].Outcome)
+		assert.Empty(t, results[0].NewReviewerID)
+		assert.NotEmpty(t, results[0].Error)
+		assert.Equal(t, domain.ReassignmentReplaced, results[1].Outcome)
+	})
+
+	t.Run("no open reviews", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(reviewer, nil)
+		mockPRRepo.On("GetByReviewer", ctx, "user-2").Return([]*domain.PullRequest{}, nil)
+		mockTeamRepo.On("GetByName", ctx, "team-1").Return(&domain.Team{TeamName: "team-1"}, nil)
+
+		results, err := service.ReleaseReviewer(ctx, "user-2", domain.PREventReasonDeactivated)
+
+		require.NoError(t, err)
+		assert.NotNil(t, results)
+		assert.Empty(t, results)
+		mockPRRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewPRService(new(mocks.MockPRRepository), mockUserRepo, new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(nil, domain.ErrUserNotFound)
+
+		results, err := service.ReleaseReviewer(ctx, "user-2", domain.PREventReasonDeactivated)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Nil(t, results)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockUserRepo.On("GetByID", ctx, "user-2").Return(reviewer, nil)
+		mockPRRepo.On("GetByReviewer", ctx, "user-2").Return(nil, assert.AnError)
+
+		results, err := service.ReleaseReviewer(ctx, "user-2", domain.PREventReasonDeactivated)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, results)
+	})
+}
+
+// newEventRepo returns an event repository accepting any events for tests that do not check the history
+func newEventRepo() *mocks.MockPREventRepository {
+	eventRepo := new(mocks.MockPREventRepository)
+	eventRepo.On("Append", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return eventRepo
+}
+
+func TestPRServiceReleaseMovedReviewer(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("keeps reviews on new teammates PRs", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		mockEventRepo := new(mocks.MockPREventRepository)
+		service := NewPRService(mockPRRepo, mockUserRepo, mockTeamRepo, mockEventRepo, mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-old", AuthorID: "old-author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"mover"}, RequiredReviewers: 1},
+			{PullRequestID: "pr-new", AuthorID: "new-author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"mover"}, RequiredReviewers: 1},
+		}
+		kept := prs[1]
+
+		mockTeamRepo.On("GetByName", ctx, "old").Return(&domain.Team{TeamName: "old"}, nil)
+		mockPRRepo.On("GetOpenByReviewers", ctx, []string{"mover"}).Return(prs, nil)
+		mockUserRepo.On("GetByTeam", ctx, "new").Return([]*domain.User{
+			{UserID: "mover", TeamName: "new"},
+			{UserID: "new-author", TeamName: "new"},
+		}, nil)
+		mockUserRepo.On("GetActiveByTeam", ctx, "old").Return([]*domain.User{
+			{UserID: "old-author", TeamName: "old", IsActive: true},
+			{UserID: "old-reviewer", TeamName: "old", IsActive: true},
+		}, nil)
+		mockPRRepo.On("Update", ctx, mock.AnythingOfType("*domain.PullRequest")).Return(nil).Once()
+		mockEventRepo.On("Append", ctx, mock.MatchedBy(func(events []*domain.PREvent) bool {
+			return len(events) == 1 && events[0].Reason == domain.PREventReasonMovedTeam
+		})).Return(nil).Once()
+
+		results, err := service.ReleaseMovedReviewer(ctx, "mover", "old", "new")
+
+		require.NoError(t, err)
+		assert.Equal(t, []*domain.ReviewerReassignment{
+			{PullRequestID: "pr-old", OldReviewerID: "mover", NewReviewerID: "old-reviewer", Outcome: domain.ReassignmentReplaced},
+		}, results)
+		assert.Equal(t, []string{"mover"}, kept.AssignedReviewers)
+		mockPRRepo.AssertExpectations(t)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("error getting PRs", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewPRService(mockPRRepo, new(mocks.MockUserRepository), mockTeamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+
+		mockTeamRepo.On("GetByName", ctx, "old").Return(&domain.Team{TeamName: "old"}, nil)
+		mockPRRepo.On("GetOpenByReviewers", ctx, []string{"mover"}).Return(nil, assert.AnError)
+
+		results, err := service.ReleaseMovedReviewer(ctx, "mover", "old", "new")
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, results)
+	})
+}
+
+func TestPRServiceListPRs(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	created := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	newService := func() (*PRService, *mocks.MockPRRepository, *mocks.MockUserRepository) {
+		prRepo := new(mocks.MockPRRepository)
+		userRepo := new(mocks.MockUserRepository)
+		return NewPRService(prRepo, userRepo, new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger), prRepo, userRepo
+	}
+
+	t.Run("newest first with next cursor", func(t *testing.T) {
+		service, prRepo, _ := newService()
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-3", CreatedAt: &created},
+			{PullRequestID: "pr-2", CreatedAt: &created},
+			{PullRequestID: "pr-1"},
+		}
+		to := created.Add(24 * time.Hour)
+		prRepo.On("List", ctx, repository.PRListQuery{
+			Status:     domain.PRStatusOpen,
+			ReviewerID: "user-1",
+			Created:    repository.TimeWindow{From: &created, To: &to},
+			SortBy:     domain.PRSortCreatedAt,
+			Descending: true,
+			Limit:      3,
+		}).Return(prs, nil)
+
+		page, err := service.ListPRs(ctx, PRListParams{
+			Status:     domain.PRStatusOpen,
+			ReviewerID: "user-1",
+			From:       &created,
+			To:         &to,
+			Limit:      2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, prs[:2], page.PullRequests)
+
+		var cursor prListCursor
+		require.NoError(t, decodeCursor(page.NextCursor, &cursor))
+		assert.Equal(t, domain.PRSortCreatedAt, cursor.SortBy)
+		assert.Equal(t, "desc", cursor.Order)
+		assert.Equal(t, "pr-2", cursor.PRID)
+		require.NotNil(t, cursor.CreatedAt)
+		assert.True(t, created.Equal(*cursor.CreatedAt))
+	})
+
+	t.Run("cursor of a PR without created_at", func(t *testing.T) {
+		service, prRepo, _ := newService()
+
+		cursor := encodeCursor(prListCursor{SortBy: domain.PRSortCreatedAt, Order: "asc", PRID: "pr-1"})
+		prRepo.On("List", ctx, repository.PRListQuery{
+			SortBy:    domain.PRSortCreatedAt,
+			AfterPRID: "pr-1",
+			Limit:     DefaultPageLimit + 1,
+		}).Return([]*domain.PullRequest{}, nil)
+
+		page, err := service.ListPRs(ctx, PRListParams{Order: "asc", Cursor: cursor})
+
+		require.NoError(t, err)
+		assert.Empty(t, page.PullRequests)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("team filter selects authors of the team", func(t *testing.T) {
+		service, prRepo, userRepo := newService()
+
+		userRepo.On("GetByTeam", ctx, "backend").Return([]*domain.User{{UserID: "user-1"}, {UserID: "user-2"}}, nil)
+		prRepo.On("List", ctx, mock.MatchedBy(func(q repository.PRListQuery) bool {
+			return assert.ObjectsAreEqual([]string{"user-1", "user-2"}, q.AuthorIDs)
+		})).Return([]*domain.PullRequest{}, nil)
+
+		_, err := service.ListPRs(ctx, PRListParams{TeamName: "backend", SortBy: domain.PRSortID})
+
+		require.NoError(t, err)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("author outside the team matches nothing", func(t *testing.T) {
+		service, prRepo, userRepo := newService()
+
+		userRepo.On("GetByTeam", ctx, "backend").Return([]*domain.User{{UserID: "user-1"}}, nil)
+		prRepo.On("List", ctx, mock.MatchedBy(func(q repository.PRListQuery) bool {
+			return q.AuthorIDs != nil && len(q.AuthorIDs) == 0
+		})).Return([]*domain.PullRequest{}, nil)
+
+		_, err := service.ListPRs(ctx, PRListParams{TeamName: "backend", AuthorID: "user-9"})
+
+		require.NoError(t, err)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		service, _, _ := newService()
+		otherSort := encodeCursor(prListCursor{SortBy: domain.PRSortID, Order: "desc", PRID: "pr-1"})
+		later := created.Add(time.Hour)
+
+		cases := map[string]PRListParams{
+			"unknown status":    {Status: "REVIEWED"},
+			"empty window":      {From: &later, To: &created},
+			"unknown sort":      {SortBy: "merged_at"},
+			"unknown order":     {Order: "up"},
+			"limit too large":   {Limit: MaxPageLimit + 1},
+			"malformed cursor":  {Cursor: "not a cursor"},
+			"cursor of another": {Cursor: otherSort},
+		}
+
+		for name, params := range cases {
+			t.Run(name, func(t *testing.T) {
+				page, err := service.ListPRs(ctx, params)
+
+				assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+				assert.Nil(t, page)
+			})
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		service, prRepo, _ := newService()
+
+		prRepo.On("List", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		page, err := service.ListPRs(ctx, PRListParams{})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, page)
+	})
+}
+
+func TestPRServiceSearchPRs(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	newService := func() (*PRService, *mocks.MockPRRepository) {
+		prRepo := new(mocks.MockPRRepository)
+		return NewPRService(prRepo, new(mocks.MockUserRepository), new(mocks.MockTeamRepository), newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger), prRepo
+	}
+	hit := func(id string, score float64) *domain.PRSearchHit {
+		return &domain.PRSearchHit{PullRequestShort: domain.PullRequestShort{PullRequestID: id, Status: domain.PRStatusOpen}, Score: score}
+	}
+
+	t.Run("best matches with next cursor", func(t *testing.T) {
+		service, prRepo := newService()
+
+		prRepo.On("Search", ctx, repository.PRSearchQuery{
+			Text:   "payment retry",
+			Status: domain.PRStatusOpen,
+			Limit:  3,
+		}).Return([]*domain.PRSearchHit{hit("pr-2", 2), hit("pr-1", 1.5), hit("pr-3", 1.5)}, nil)
+
+		page, err := service.SearchPRs(ctx, PRSearchParams{Query: "  payment retry ", Status: domain.PRStatusOpen, Limit: 2})
+
+		require.NoError(t, err)
+		require.Len(t, page.PullRequests, 2)
+		assert.Equal(t, "pr-2", page.PullRequests[0].PullRequestID)
+		assert.Equal(t, "pr-1", page.PullRequests[1].PullRequestID)
+
+		var cursor prSearchCursor
+		require.NoError(t, decodeCursor(page.NextCursor, &cursor))
+		assert.Equal(t, prSearchCursor{Query: "payment retry", Status: domain.PRStatusOpen, Score: 1.5, PRID: "pr-1"}, cursor)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("last page continues from cursor", func(t *testing.T) {
+		service, prRepo := newService()
+
+		cursor := encodeCursor(prSearchCursor{Query: "payment", Score: 1.5, PRID: "pr-1"})
+		prRepo.On("Search", ctx, repository.PRSearchQuery{
+			Text:       "payment",
+			AfterScore: 1.5,
+			AfterPRID:  "pr-1",
+			Limit:      DefaultPageLimit + 1,
+		}).Return([]*domain.PRSearchHit{hit("pr-3", 1.5)}, nil)
+
+		page, err := service.SearchPRs(ctx, PRSearchParams{Query: "payment", Cursor: cursor})
+
+		require.NoError(t, err)
+		require.Len(t, page.PullRequests, 1)
+		assert.Equal(t, "pr-3", page.PullRequests[0].PullRequestID)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		service, prRepo := newService()
+
+		prRepo.On("Search", ctx, mock.Anything).Return([]*domain.PRSearchHit{}, nil)
+
+		page, err := service.SearchPRs(ctx, PRSearchParams{Query: "kubernetes"})
+
+		require.NoError(t, err)
+		assert.NotNil(t, page.PullRequests)
+		assert.Empty(t, page.PullRequests)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		service, _ := newService()
+		otherQuery := encodeCursor(prSearchCursor{Query: "retry", PRID: "pr-1"})
+
+		cases := map[string]PRSearchParams{
+			"empty query":       {Query: "  "},
+			"unknown status":    {Query: "payment", Status: "REVIEWED"},
+			"negative limit":    {Query: "payment", Limit: -1},
+			"malformed cursor":  {Query: "payment", Cursor: "not a cursor"},
+			"cursor of another": {Query: "payment", Cursor: otherQuery},
+		}
+
+		for name, params := range cases {
+			t.Run(name, func(t *testing.T) {
+				page, err := service.SearchPRs(ctx, params)
+
+				assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+				assert.Nil(t, page)
+			})
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		service, prRepo := newService()
+
+		prRepo.On("Search", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		page, err := service.SearchPRs(ctx, PRSearchParams{Query: "payment"})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, page)
+	})
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+)
+
+// Built-in reviewer selection strategies
+const (
+	SelectorRandom      = "random"
+	SelectorRoundRobin  = "round_robin"
+	SelectorLeastLoaded = "least_loaded"
+)
+
+// ReviewerSelector picks up to count reviewers out of candidates.
+// Candidates are already filtered: they never contain the PR author or reviewers already assigned to the PR.
+type ReviewerSelector interface {
+	Select(ctx context.Context, teamName string, candidates []*domain.User, count int) ([]*domain.User, error)
+}
+
+type RandomSelector struct{}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+func (s *RandomSelector) Select(_ context.Context, _ string, candidates []*domain.User, count int) ([]*domain.User, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return nil, nil
+	}
+
+	shuffled := slices.Clone(candidates)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled[:min(len(shuffled), count)], nil
+}
+
+// RoundRobinSelector walks over team members ordered by user_id and continues
+// right after the last reviewer it picked for the same team
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	last map[string]string
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{
+		last: make(map[string]string),
+	}
+}
+
+func (s *RoundRobinSelector) Select(_ context.Context, teamName string, candidates []*domain.User, count int) ([]*domain.User, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return nil, nil
+	}
+
+	ordered := slices.Clone(candidates)
+	slices.SortFunc(ordered, func(a, b *domain.User) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the last picked user may have left the candidates list, so look for the first id after it
+	start := 0
+	if last, ok := s.last[teamName]; ok {
+		start = len(ordered)
+		for i, u := range ordered {
+			if u.UserID > last {
+				start = i
+				break
+			}
+		}
+	}
+
+	count = min(len(ordered), count)
+	selected := make([]*domain.User, 0, count)
+	for i := 0; i < count; i++ {
+		selected = append(selected, ordered[(start+i)%len(ordered)])
+	}
+
+	s.last[teamName] = selected[len(selected)-1].UserID
+	return selected, nil
+}
+
+// LeastLoadedSelector prefers candidates with the smallest number of OPEN PRs to review,
+// candidates with equal load are picked randomly
+type LeastLoadedSelector struct {
+	prRepo repository.PRRepository
+}
+
+func NewLeastLoadedSelector(prRepo repository.PRRepository) *LeastLoadedSelector {
+	return &LeastLoadedSelector{
+		prRepo: prRepo,
+	}
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, _ string, candidates []*domain.User, count int) ([]*domain.User, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return nil, nil
+	}
+
+	userIDs := make([]string, 0, len(candidates))
+	for _, u := range candidates {
+		userIDs = append(userIDs, u.UserID)
+	}
+
+	load, err := s.prRepo.CountOpenByReviewers(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewers load: %w", err)
+	}
+
+	// shuffle first so that the stable sort keeps random order among equally loaded candidates
+	ordered := slices.Clone(candidates)
+	rand.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+	slices.SortStableFunc(ordered, func(a, b *domain.User) int {
+		return load[a.UserID] - load[b.UserID]
+	})
+
+	return ordered[:min(len(ordered), count)], nil
+}
+
+// SelectorRegistry keeps named reviewer selectors and decides which one is used for a team
+type SelectorRegistry struct {
+	mu          sync.RWMutex
+	selectors   map[string]ReviewerSelector
+	defaultName string
+	teams       map[string]string
+}
+
+// NewSelectorRegistry returns a registry with the built-in strategies, random is used by default
+func NewSelectorRegistry() *SelectorRegistry {
+	return &SelectorRegistry{
+		selectors: map[string]ReviewerSelector{
+			SelectorRandom:     NewRandomSelector(),
+			SelectorRoundRobin: NewRoundRobinSelector(),
+		},
+		defaultName: SelectorRandom,
+		teams:       make(map[string]string),
+	}
+}
+
+func (r *SelectorRegistry) Register(name string, selector ReviewerSelector) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("reviewer selector name must not be empty")
+	}
+	if selector == nil {
+		return fmt.Errorf("reviewer selector %q must not be nil", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.selectors[name]; exists {
+		return fmt.Errorf("reviewer selector %q is already registered", name)
+	}
+	r.selectors[name] = selector
+
+	return nil
+}
+
+func (r *SelectorRegistry) SetDefault(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.selectors[name]; !exists {
+		return fmt.Errorf("unknown reviewer selector %q", name)
+	}
+	r.defaultName = name
+
+	return nil
+}
+
+func (r *SelectorRegistry) SetTeamStrategy(teamName, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.selectors[name]; !exists {
+		return fmt.Errorf("unknown reviewer selector %q for team %q", name, teamName)
+	}
+	r.teams[teamName] = name
+
+	return nil
+}
+
+// Configure applies the global strategy and per-team overrides (usually taken from config.Config)
+func (r *SelectorRegistry) Configure(defaultName string, teamStrategies map[string]string) error {
+	if err := r.SetDefault(defaultName); err != nil {
+		return err
+	}
+
+	for teamName, name := range teamStrategies {
+		if err := r.SetTeamStrategy(teamName, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *SelectorRegistry) ForTeam(teamName string) ReviewerSelector {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if name, ok := r.teams[teamName]; ok {
+		return r.selectors[name]
+	}
+
+	return r.selectors[r.defaultName]
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func userIDs(users []*domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	return ids
+}
+
+func TestRandomSelector(t *testing.T) {
+	ctx := context.Background()
+	selector := NewRandomSelector()
+
+	candidates := []*domain.User{{UserID: "user-1"}, {UserID: "user-2"}, {UserID: "user-3"}}
+
+	t.Run("selects requested count", func(t *testing.T) {
+		selected, err := selector.Select(ctx, "team-1", candidates, 2)
+
+		require.NoError(t, err)
+		assert.Len(t, selected, 2)
+		assert.NotEqual(t, selected[0].UserID, selected[1].UserID)
+	})
+
+	t.Run("does not modify candidates order", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			_, _ = selector.Select(ctx, "team-1", candidates, 3)
+		}
+
+		assert.Equal(t, []string{"user-1", "user-2", "user-3"}, userIDs(candidates))
+	})
+
+	t.Run("empty candidates", func(t *testing.T) {
+		selected, err := selector.Select(ctx, "team-1", nil, 2)
+
+		require.NoError(t, err)
+		assert.Empty(t, selected)
+	})
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rotates over team members", func(t *testing.T) {
+		selector := NewRoundRobinSelector()
+		candidates := []*domain.User{{UserID: "user-3"}, {UserID: "user-1"}, {UserID: "user-2"}}
+
+		first, err := selector.Select(ctx, "team-1", candidates, 2)
+		require.NoError(t, err)
+		second, err := selector.Select(ctx, "team-1", candidates, 2)
+		require.NoError(t, err)
+		third, err := selector.Select(ctx, "team-1", candidates, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"user-1", "user-2"}, userIDs(first))
+		assert.Equal(t, []string{"user-3", "user-1"}, userIDs(second))
+		assert.Equal(t, []string{"user-2"}, userIDs(third))
+	})
+
+	t.Run("teams have independent positions", func(t *testing.T) {
+		selector := NewRoundRobinSelector()
+		candidates := []*domain.User{{UserID: "user-1"}, {UserID: "user-2"}}
+
+		_, _ = selector.Select(ctx, "team-1", candidates, 1)
+		selected, err := selector.Select(ctx, "team-2", candidates, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-1"}, userIDs(selected))
+	})
+
+	t.Run("continues after last picked when it is no longer a candidate", func(t *testing.T) {
+		selector := NewRoundRobinSelector()
+
+		_, _ = selector.Select(ctx, "team-1", []*domain.User{{UserID: "user-1"}, {UserID: "user-2"}}, 2)
+		selected, err := selector.Select(ctx, "team-1", []*domain.User{{UserID: "user-1"}, {UserID: "user-3"}}, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-3"}, userIDs(selected))
+	})
+
+	t.Run("empty candidates", func(t *testing.T) {
+		selector := NewRoundRobinSelector()
+
+		selected, err := selector.Select(ctx, "team-1", nil, 2)
+
+		require.NoError(t, err)
+		assert.Empty(t, selected)
+	})
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	ctx := context.Background()
+	candidates := []*domain.User{{UserID: "user-1"}, {UserID: "user-2"}, {UserID: "user-3"}, {UserID: "user-4"}}
+	ids := []string{"user-1", "user-2", "user-3", "user-4"}
+
+	t.Run("picks least loaded candidates", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		selector := NewLeastLoadedSelector(mockPRRepo)
+
+		mockPRRepo.On("CountOpenByReviewers", ctx, ids).Return(map[string]int{"user-1": 4, "user-2": 1, "user-4": 2}, nil)
+
+		selected, err := selector.Select(ctx, "team-1", candidates, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-3", "user-2"}, userIDs(selected))
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("ties are broken among equally loaded candidates only", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		selector := NewLeastLoadedSelector(mockPRRepo)
+
+		mockPRRepo.On("CountOpenByReviewers", ctx, ids).Return(map[string]int{"user-1": 1, "user-4": 1}, nil)
+
+		for i := 0; i < 20; i++ {
+			selected, err := selector.Select(ctx, "team-1", candidates, 2)
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"user-2", "user-3"}, userIDs(selected))
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		selector := NewLeastLoadedSelector(mockPRRepo)
+
+		mockPRRepo.On("CountOpenByReviewers", ctx, ids).Return(nil, assert.AnError)
+
+		selected, err := selector.Select(ctx, "team-1", candidates, 2)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, selected)
+	})
+
+	t.Run("empty candidates do not hit repository", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		selector := NewLeastLoadedSelector(mockPRRepo)
+
+		selected, err := selector.Select(ctx, "team-1", nil, 2)
+
+		require.NoError(t, err)
+		assert.Empty(t, selected)
+		mockPRRepo.AssertNotCalled(t, "CountOpenByReviewers")
+	})
+}
+
+func TestSelectorRegistry(t *testing.T) {
+	t.Run("random is default", func(t *testing.T) {
+		registry := NewSelectorRegistry()
+
+		assert.IsType(t, &RandomSelector{}, registry.ForTeam("team-1"))
+	})
+
+	t.Run("global and team strategies", func(t *testing.T) {
+		registry := NewSelectorRegistry()
+
+		require.NoError(t, registry.Configure(SelectorRoundRobin, map[string]string{"team-2": SelectorRandom}))
+
+		assert.IsType(t, &RoundRobinSelector{}, registry.ForTeam("team-1"))
+		assert.IsType(t, &RandomSelector{}, registry.ForTeam("team-2"))
+	})
+
+	t.Run("unknown strategies are rejected", func(t *testing.T) {
+		registry := NewSelectorRegistry()
+
+		assert.Error(t, registry.SetDefault("unknown"))
+		assert.Error(t, registry.SetTeamStrategy("team-1", "unknown"))
+		assert.Error(t, registry.Configure(SelectorRandom, map[string]string{"team-1": "unknown"}))
+	})
+
+	t.Run("register custom selector", func(t *testing.T) {
+		registry := NewSelectorRegistry()
+
+		require.NoError(t, registry.Register("last", lastCandidateSelector{}))
+		require.NoError(t, registry.SetTeamStrategy("team-1", "last"))
+
+		assert.IsType(t, lastCandidateSelector{}, registry.ForTeam("team-1"))
+	})
+
+	t.Run("invalid registrations", func(t *testing.T) {
+		registry := NewSelectorRegistry()
+
+		assert.Error(t, registry.Register("", lastCandidateSelector{}))
+		assert.Error(t, registry.Register("last", nil))
+		assert.Error(t, registry.Register(SelectorRandom, lastCandidateSelector{}))
+	})
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"assignment-service/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// CheckRosters reports where team documents and users disagree about membership.
+// Users' team_name is the roster: with repair legacy members arrays are folded into users
+// (missing users are created, teamless ones join the team, users of another team stay there)
+// and dropped, and teams known only from users get a team document with default settings.
+// A failed repair is reported in its drift and does not stop the others.
+func (s *TeamService) CheckRosters(ctx context.Context, repair bool) ([]*domain.RosterDrift, error) {
+	legacy, err := s.teamRepo.ListLegacyMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	teamNames := make([]string, 0, len(legacy))
+	for teamName := range legacy {
+		teamNames = append(teamNames, teamName)
+	}
+	slices.Sort(teamNames)
+
+	drifts := []*domain.RosterDrift{}
+	fix := func(drift *domain.RosterDrift, err error) {
+		if err != nil {
+			s.logger.Error("failed to repair roster drift",
+				zap.Error(err),
+				zap.String("kind", drift.Kind),
+				zap.String("team_name", drift.TeamName),
+				zap.String("user_id", drift.UserID))
+			drift.Error = err.Error()
+			return
+		}
+		drift.Repaired = true
+	}
+
+	// teams are handled one by one: a user listed in two legacy arrays joins the first team and mismatches the second
+	for _, teamName := range teamNames {
+		members := legacy[teamName]
+		teamDrift := &domain.RosterDrift{
+			Kind:     domain.RosterLegacyMembers,
+			TeamName: teamName,
+			Detail:   fmt.Sprintf("%d members stored in the team document", len(members)),
+		}
+		drifts = append(drifts, teamDrift)
+
+		userIDs := make([]string, 0, len(members))
+		for _, member := range members {
+			userIDs = append(userIDs, member.UserID)
+		}
+		users, err := s.userRepo.GetByIDs(ctx, userIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team users: %w", err)
+		}
+		byID := make(map[string]*domain.User, len(users))
+		for _, user := range users {
+			byID[user.UserID] = user
+		}
+
+		var mismatches []*domain.RosterDrift
+		folded := true
+		for _, member := range members {
+			drift := &domain.RosterDrift{TeamName: teamName, UserID: member.UserID}
+			user, found := byID[member.UserID]
+			switch {
+			case !found:
+				drift.Kind = domain.RosterMissingUser
+				if repair {
+					fix(drift, s.userRepo.CreateOrUpdate(ctx, &domain.User{
+						UserID:         member.UserID,
+						Username:       member.Username,
+						TeamName:       teamName,
+						IsActive:       member.IsActive,
+						MaxOpenReviews: member.MaxOpenReviews,
+						Email:          domain.NormalizeEmail(member.Email),
+					}))
+				}
+			case user.TeamName == "":
+				drift.Kind = domain.RosterTeamlessUser
+				if repair {
+					fix(drift, s.userRepo.UpdateTeam(ctx, member.UserID, teamName))
+				}
+			case user.TeamName != teamName:
+				drift.Kind = domain.RosterTeamMismatch
+				drift.Detail = fmt.Sprintf("user is in team %s", user.TeamName)
+				mismatches = append(mismatches, drift)
+			default:
+				continue
+			}
+
+			drifts = append(drifts, drift)
+			if drift.Error != "" {
+				folded = false
+			}
+		}
+
+		// the array is kept until every member is reflected in users, so a failed run can be repeated
+		if !repair || !folded {
+			continue
+		}
+		fix(teamDrift, s.teamRepo.DropLegacyMembers(ctx, teamName))
+		for _, drift := range mismatches {
+			drift.Repaired = teamDrift.Repaired
+		}
+	}
+
+	usedNames, err := s.userRepo.ListTeamNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	knownNames, err := s.teamRepo.ListNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(usedNames)
+
+	for _, teamName := range usedNames {
+		if slices.Contains(knownNames, teamName) {
+			continue
+		}
+
+		drift := &domain.RosterDrift{Kind: domain.RosterMissingTeam, TeamName: teamName}
+		drifts = append(drifts, drift)
+		if repair {
+			err := s.teamRepo.Create(ctx, &domain.Team{TeamName: teamName})
+			if err == domain.ErrTeamExists {
+				err = nil
+			}
+			fix(drift, err)
+		}
+	}
+
+	return drifts, nil
+}
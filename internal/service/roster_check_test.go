@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"assignment-service/internal/domain"
+	"assignment-service/internal/repository/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTeamServiceCheckRosters(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	legacy := map[string][]domain.TeamMember{
+		"team-1": {
+			{UserID: "user-1", Username: "alice", IsActive: true},
+			{UserID: "user-2", Username: "bob", IsActive: true, Email: "Bob@Example.com"},
+			{UserID: "user-3", Username: "carol"},
+			{UserID: "user-4", Username: "dave"},
+		},
+	}
+	users := []*domain.User{
+		{UserID: "user-1", TeamName: "team-1"},
+		{UserID: "user-3", TeamName: ""},
+		{UserID: "user-4", TeamName: "team-2"},
+	}
+	userIDs := []string{"user-1", "user-2", "user-3", "user-4"}
+
+	t.Run("reports drift without changing anything", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("ListLegacyMembers", ctx).Return(legacy, nil)
+		userRepo.On("GetByIDs", ctx, userIDs).Return(users, nil)
+		userRepo.On("ListTeamNames", ctx).Return([]string{"team-2", "team-1"}, nil)
+		teamRepo.On("ListNames", ctx).Return([]string{"team-1"}, nil)
+
+		drifts, err := svc.CheckRosters(ctx, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*domain.RosterDrift{
+			{Kind: domain.RosterLegacyMembers, TeamName: "team-1", Detail: "4 members stored in the team document"},
+			{Kind: domain.RosterMissingUser, TeamName: "team-1", UserID: "user-2"},
+			{Kind: domain.RosterTeamlessUser, TeamName: "team-1", UserID: "user-3"},
+			{Kind: domain.RosterTeamMismatch, TeamName: "team-1", UserID: "user-4", Detail: "user is in team team-2"},
+			{Kind: domain.RosterMissingTeam, TeamName: "team-2"},
+		}, drifts)
+		userRepo.AssertNotCalled(t, "CreateOrUpdate")
+		userRepo.AssertNotCalled(t, "UpdateTeam")
+		teamRepo.AssertNotCalled(t, "DropLegacyMembers")
+		teamRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("repairs drift", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("ListLegacyMembers", ctx).Return(legacy, nil)
+		userRepo.On("GetByIDs", ctx, userIDs).Return(users, nil)
+		userRepo.On("CreateOrUpdate", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.UserID == "user-2" && u.TeamName == "team-1" && u.IsActive && u.Email == "bob@example.com"
+		})).Return(nil).Once()
+		userRepo.On("UpdateTeam", ctx, "user-3", "team-1").Return(nil).Once()
+		teamRepo.On("DropLegacyMembers", ctx, "team-1").Return(nil).Once()
+		userRepo.On("ListTeamNames", ctx).Return([]string{"team-1", "team-2"}, nil)
+		teamRepo.On("ListNames", ctx).Return([]string{"team-1"}, nil)
+		teamRepo.On("Create", ctx, &domain.Team{TeamName: "team-2"}).Return(nil).Once()
+
+		drifts, err := svc.CheckRosters(ctx, true)
+
+		require.NoError(t, err)
+		require.Len(t, drifts, 5)
+		for _, drift := range drifts {
+			assert.True(t, drift.Repaired, drift.Kind)
+			assert.Empty(t, drift.Error, drift.Kind)
+		}
+		teamRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed member repair keeps the legacy array", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("ListLegacyMembers", ctx).Return(legacy, nil)
+		userRepo.On("GetByIDs", ctx, userIDs).Return(users, nil)
+		userRepo.On("CreateOrUpdate", ctx, mock.AnythingOfType("*domain.User")).Return(assert.AnError)
+		userRepo.On("UpdateTeam", ctx, "user-3", "team-1").Return(nil)
+		userRepo.On("ListTeamNames", ctx).Return([]string{"team-1"}, nil)
+		teamRepo.On("ListNames", ctx).Return([]string{"team-1"}, nil)
+
+		drifts, err := svc.CheckRosters(ctx, true)
+
+		require.NoError(t, err)
+		require.Len(t, drifts, 4)
+		assert.False(t, drifts[0].Repaired)
+		assert.False(t, drifts[1].Repaired)
+		assert.Equal(t, assert.AnError.Error(), drifts[1].Error)
+		assert.True(t, drifts[2].Repaired)
+		assert.False(t, drifts[3].Repaired)
+		teamRepo.AssertNotCalled(t, "DropLegacyMembers")
+	})
+
+	t.Run("team created concurrently counts as repaired", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("ListLegacyMembers", ctx).Return(map[string][]domain.TeamMember{}, nil)
+		userRepo.On("ListTeamNames", ctx).Return([]string{"team-2"}, nil)
+		teamRepo.On("ListNames", ctx).Return([]string{}, nil)
+		teamRepo.On("Create", ctx, mock.AnythingOfType("*domain.Team")).Return(domain.ErrTeamExists)
+
+		drifts, err := svc.CheckRosters(ctx, true)
+
+		require.NoError(t, err)
+		require.Len(t, drifts, 1)
+		assert.True(t, drifts[0].Repaired)
+	})
+
+	t.Run("consistent database", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("ListLegacyMembers", ctx).Return(map[string][]domain.TeamMember{}, nil)
+		userRepo.On("ListTeamNames", ctx).Return([]string{"team-1"}, nil)
+		teamRepo.On("ListNames", ctx).Return([]string{"team-1", "team-2"}, nil)
+
+		drifts, err := svc.CheckRosters(ctx, true)
+
+		require.NoError(t, err)
+		assert.NotNil(t, drifts)
+		assert.Empty(t, drifts)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("ListLegacyMembers", ctx).Return(nil, assert.AnError)
+
+		drifts, err := svc.CheckRosters(ctx, false)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, drifts)
+	})
+}
@@ -1,8 +1,11 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"assignment-service/internal/domain"
 	"assignment-service/internal/repository"
@@ -13,29 +16,49 @@ import (
 type StatsService struct {
 	prRepo   repository.PRRepository
 	userRepo repository.UserRepository
+	teamRepo repository.TeamRepository
 	logger   *zap.Logger
 }
 
 func NewStatsService(
 	prRepo repository.PRRepository,
 	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
 	logger *zap.Logger,
 ) *StatsService {
 	return &StatsService{
 		prRepo:   prRepo,
 		userRepo: userRepo,
+		teamRepo: teamRepo,
 		logger:   logger,
 	}
 }
 
-type UserStats struct {
-	UserID        string `json:"user_id"`
-	AssignedCount int    `json:"assigned_count"`
-	OpenPRCount   int    `json:"open_pr_count"`
-	MergedPRCount int    `json:"merged_pr_count"`
+// UserStatsParams are the filters, order and page of the users stats list
+type UserStatsParams struct {
+	TeamName string
+	// one of domain.UserStatsSort* constants, assigned_count by default
+	SortBy string
+	// "asc" or "desc", desc by default
+	Order  string
+	Cursor string
+	Limit  int
 }
 
-func (s *StatsService) GetUserStats(ctx context.Context, userID string) (*UserStats, error) {
+type UserStatsPage struct {
+	Users      []*domain.UserStats `json:"users"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// userStatsCursor is the position after the last row of a page, the sort is kept to reject mixed up cursors
+type userStatsCursor struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	Value  int    `json:"v"`
+	UserID string `json:"u"`
+}
+
+func (s *StatsService) GetUserStats(ctx context.Context, userID string) (*domain.UserStats, error) {
 	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -46,23 +69,296 @@ func (s *StatsService) GetUserStats(ctx context.Context, userID string) (*UserSt
 		return nil, fmt.Errorf("failed to get PRs by reviewer: %w", err)
 	}
 
-	stats := &UserStats{
-		UserID:        userID,
-		AssignedCount: len(prs),
+	stats := &domain.UserStats{
+		UserID: userID,
 	}
 
+	// DRAFT and CLOSED PRs have no reviewers, so they are not counted even if left in old data
 	for _, pr := range prs {
 		switch pr.Status {
 		case domain.PRStatusOpen:
 			stats.OpenPRCount++
 		case domain.PRStatusMerged:
 			stats.MergedPRCount++
+		default:
+			continue
+		}
+		stats.AssignedCount++
+	}
+
+	return stats, nil
+}
+
+// GetAllUserStats returns a page of users stats, users without assigned PRs are included with zero counters
+func (s *StatsService) GetAllUserStats(ctx context.Context, params UserStatsParams) (*UserStatsPage, error) {
+	query := repository.UserStatsQuery{
+		TeamName: params.TeamName,
+		SortBy:   params.SortBy,
+	}
+
+	switch query.SortBy {
+	case "":
+		query.SortBy = domain.UserStatsSortAssigned
+	case domain.UserStatsSortAssigned, domain.UserStatsSortOpen, domain.UserStatsSortMerged:
+	default:
+		return nil, domain.ErrInvalidQuery
+	}
+
+	order := params.Order
+	switch order {
+	case "", "desc":
+		order = "desc"
+		query.Descending = true
+	case "asc":
+	default:
+		return nil, domain.ErrInvalidQuery
+	}
+
+	limit, err := pageLimit(params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	// one extra row tells whether there is a next page
+	query.Limit = limit + 1
+
+	if params.Cursor != "" {
+		var after userStatsCursor
+		if err := decodeCursor(params.Cursor, &after); err != nil {
+			return nil, err
+		}
+		if after.SortBy != query.SortBy || after.Order != order || after.UserID == "" {
+			return nil, domain.ErrInvalidQuery
 		}
+		query.AfterValue = after.Value
+		query.AfterUserID = after.UserID
+	}
+
+	stats, err := s.prRepo.ListUserStats(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users stats: %w", err)
+	}
+
+	page := &UserStatsPage{Users: stats}
+	if len(stats) > limit {
+		page.Users = stats[:limit]
+		last := page.Users[limit-1]
+		page.NextCursor = encodeCursor(userStatsCursor{
+			SortBy: query.SortBy,
+			Order:  order,
+			Value:  last.SortValue(query.SortBy),
+			UserID: last.UserID,
+		})
+	}
+
+	return page, nil
+}
+
+// GetTeamStats counts PRs authored by team members and review assignments of the members.
+// Only PRs created in [from, to) are taken into account, nil bounds leave the window open.
+func (s *StatsService) GetTeamStats(ctx context.Context, teamName string, from, to *time.Time) (*domain.TeamStats, error) {
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	window := repository.TimeWindow{From: from, To: to}
+
+	members, err := s.userRepo.GetByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	authorIDs := make([]string, 0, len(members))
+	for _, m := range members {
+		authorIDs = append(authorIDs, m.UserID)
+	}
+
+	counts, err := s.prRepo.CountByStatus(ctx, repository.PRCountQuery{AuthorIDs: authorIDs, Created: window})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count team PRs: %w", err)
+	}
+
+	load, err := s.prRepo.ListUserStats(ctx, repository.UserStatsQuery{
+		TeamName:   teamName,
+		SortBy:     domain.UserStatsSortAssigned,
+		Descending: true,
+		Created:    window,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members stats: %w", err)
+	}
+
+	stats := &domain.TeamStats{
+		TeamName:  teamName,
+		From:      from,
+		To:        to,
+		DraftPRs:  counts[domain.PRStatusDraft],
+		OpenPRs:   counts[domain.PRStatusOpen],
+		MergedPRs: counts[domain.PRStatusMerged],
+		ClosedPRs: counts[domain.PRStatusClosed],
+		Members:   load,
+	}
+	for _, c := range counts {
+		stats.TotalPRs += c
+	}
+
+	assigned := make([]int, 0, len(load))
+	for _, m := range load {
+		assigned = append(assigned, m.AssignedCount)
+	}
+	stats.Fairness = domain.NewFairness(assigned)
+
+	return stats, nil
+}
+
+// GetMergeTimeStats returns median and p90 time from creation to merge overall, per team and per author.
+// Only PRs merged in [from, to) are taken into account, teamName optionally limits them to authors of a team.
+func (s *StatsService) GetMergeTimeStats(ctx context.Context, teamName string, from, to *time.Time) (*domain.MergeTimeStats, error) {
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	timings, err := s.prRepo.ListMergeTimings(ctx, repository.PRTimingQuery{
+		TeamName: teamName,
+		Window:   repository.TimeWindow{From: from, To: to},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged PRs: %w", err)
 	}
 
+	stats := &domain.MergeTimeStats{From: from, To: to}
+	// PRs merged before merged_at was recorded have nothing to measure
+	stats.Overall, stats.Teams, stats.Authors = latencyBreakdown(timings, func(pr *domain.PRTiming) *time.Time {
+		return pr.MergedAt
+	})
+
+	return stats, nil
+}
+
+// GetFirstReviewStats returns median and p90 time from creation to the first submitted review overall, per team
+// and per author. Only PRs created in [from, to) are taken into account, teamName optionally limits them to authors
+// of a team.
+func (s *StatsService) GetFirstReviewStats(ctx context.Context, teamName string, from, to *time.Time) (*domain.FirstReviewStats, error) {
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	timings, err := s.prRepo.ListReviewTimings(ctx, repository.PRTimingQuery{
+		TeamName: teamName,
+		Window:   repository.TimeWindow{From: from, To: to},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR reviews: %w", err)
+	}
+
+	stats := &domain.FirstReviewStats{From: from, To: to, PRCount: len(timings)}
+	stats.Overall, stats.Teams, stats.Authors = latencyBreakdown(timings, func(pr *domain.PRTiming) *time.Time {
+		return pr.FirstReviewedAt
+	})
+
 	return stats, nil
 }
 
-func (s *StatsService) GetAllUserStats(ctx context.Context) ([]UserStats, error) {
-	return []UserStats{}, nil
+// latencyBreakdown measures the time from creation to the event returned by at overall, per team and per author.
+// PRs without the event are left out.
+func latencyBreakdown(
+	timings []*domain.PRTiming,
+	at func(pr *domain.PRTiming) *time.Time,
+) (domain.Latency, []*domain.TeamLatency, []*domain.AuthorLatency) {
+	var overall []time.Duration
+	byTeam := make(map[string][]time.Duration)
+	byAuthor := make(map[string][]time.Duration)
+	authorTeams := make(map[string]string)
+
+	for _, pr := range timings {
+		end := at(pr)
+		if end == nil {
+			continue
+		}
+
+		d := end.Sub(pr.CreatedAt)
+		overall = append(overall, d)
+		byAuthor[pr.AuthorID] = append(byAuthor[pr.AuthorID], d)
+		authorTeams[pr.AuthorID] = pr.TeamName
+		if pr.TeamName != "" {
+			byTeam[pr.TeamName] = append(byTeam[pr.TeamName], d)
+		}
+	}
+
+	teams := make([]*domain.TeamLatency, 0, len(byTeam))
+	for name, durations := range byTeam {
+		teams = append(teams, &domain.TeamLatency{TeamName: name, Latency: domain.NewLatency(durations)})
+	}
+	slices.SortFunc(teams, func(a, b *domain.TeamLatency) int {
+		return cmp.Compare(a.TeamName, b.TeamName)
+	})
+
+	authors := make([]*domain.AuthorLatency, 0, len(byAuthor))
+	for authorID, durations := range byAuthor {
+		authors = append(authors, &domain.AuthorLatency{
+			AuthorID: authorID,
+			TeamName: authorTeams[authorID],
+			Latency:  domain.NewLatency(durations),
+		})
+	}
+	slices.SortFunc(authors, func(a, b *domain.AuthorLatency) int {
+		return cmp.Compare(a.AuthorID, b.AuthorID)
+	})
+
+	return domain.NewLatency(overall), teams, authors
+}
+
+// GetReassignmentStats returns time from creation to the first reviewer reassignment of every reassigned PR.
+// Only PRs created in [from, to) are taken into account, teamName optionally limits them to authors of a team.
+func (s *StatsService) GetReassignmentStats(ctx context.Context, teamName string, from, to *time.Time) (*domain.ReassignmentStats, error) {
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	timings, err := s.prRepo.ListReassignmentTimings(ctx, repository.PRTimingQuery{
+		TeamName: teamName,
+		Window:   repository.TimeWindow{From: from, To: to},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR reassignments: %w", err)
+	}
+
+	stats := &domain.ReassignmentStats{
+		From:         from,
+		To:           to,
+		PRCount:      len(timings),
+		PullRequests: []*domain.PRReassignment{},
+	}
+
+	var durations []time.Duration
+	for _, pr := range timings {
+		if pr.FirstReassignedAt == nil {
+			continue
+		}
+
+		d := pr.FirstReassignedAt.Sub(pr.CreatedAt)
+		durations = append(durations, d)
+		stats.PullRequests = append(stats.PullRequests, &domain.PRReassignment{
+			PullRequestID:     pr.PullRequestID,
+			AuthorID:          pr.AuthorID,
+			TeamName:          pr.TeamName,
+			CreatedAt:         pr.CreatedAt,
+			FirstReassignedAt: *pr.FirstReassignedAt,
+			Seconds:           d.Seconds(),
+		})
+	}
+	stats.Latency = domain.NewLatency(durations)
+
+	slices.SortFunc(stats.PullRequests, func(a, b *domain.PRReassignment) int {
+		return cmp.Compare(a.PullRequestID, b.PullRequestID)
+	})
+
+	return stats, nil
 }
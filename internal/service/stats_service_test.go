@@ -7,9 +7,12 @@ import (
 	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 	"assignment-service/internal/repository/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -20,7 +23,7 @@ func TestStatsServiceGetUserStats(t *testing.T) {
 	t.Run("successful get stats", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewStatsService(mockPRRepo, mockUserRepo, logger)
+		service := NewStatsService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), logger)
 
 		user := &domain.User{
 			UserID:   "user-1",
@@ -72,10 +75,32 @@ func TestStatsServiceGetUserStats(t *testing.T) {
 		mockPRRepo.AssertExpectations(t)
 	})
 
+	t.Run("skips draft and closed PRs", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewStatsService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), logger)
+
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"user-1"}},
+			{PullRequestID: "pr-2", Status: domain.PRStatusClosed, AssignedReviewers: []string{"user-1"}},
+			{PullRequestID: "pr-3", Status: domain.PRStatusDraft, AssignedReviewers: []string{"user-1"}},
+		}
+
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(&domain.User{UserID: "user-1"}, nil)
+		mockPRRepo.On("GetByReviewer", ctx, "user-1").Return(prs, nil)
+
+		stats, err := service.GetUserStats(ctx, "user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, stats.AssignedCount)
+		assert.Equal(t, 1, stats.OpenPRCount)
+		assert.Equal(t, 0, stats.MergedPRCount)
+	})
+
 	t.Run("user not found", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewStatsService(mockPRRepo, mockUserRepo, logger)
+		service := NewStatsService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), logger)
 
 		mockUserRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
 
@@ -90,7 +115,7 @@ func TestStatsServiceGetUserStats(t *testing.T) {
 	t.Run("no PRs assigned", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewStatsService(mockPRRepo, mockUserRepo, logger)
+		service := NewStatsService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), logger)
 
 		user := &domain.User{
 			UserID:   "user-1",
@@ -119,23 +144,96 @@ func TestStatsServiceGetAllUserStats(t *testing.T) {
 	logger := zap.NewNop()
 	ctx := context.Background()
 
-	t.Run("returns empty list", func(t *testing.T) {
+	t.Run("first page with next cursor", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewStatsService(mockPRRepo, mockUserRepo, logger)
+		service := NewStatsService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), logger)
+
+		rows := []*domain.UserStats{
+			{UserID: "user-1", AssignedCount: 3},
+			{UserID: "user-2", AssignedCount: 1},
+			{UserID: "user-3", AssignedCount: 0},
+		}
+		mockPRRepo.On("ListUserStats", ctx, repository.UserStatsQuery{
+			TeamName:   "backend",
+			SortBy:     domain.UserStatsSortAssigned,
+			Descending: true,
+			Limit:      3,
+		}).Return(rows, nil)
 
-		stats, err := service.GetAllUserStats(ctx)
+		page, err := service.GetAllUserStats(ctx, UserStatsParams{TeamName: "backend", Limit: 2})
 
-		assert.NoError(t, err)
-		assert.NotNil(t, stats)
-		assert.Empty(t, stats)
+		require.NoError(t, err)
+		assert.Equal(t, rows[:2], page.Users)
+		require.NotEmpty(t, page.NextCursor)
+
+		var cursor userStatsCursor
+		require.NoError(t, decodeCursor(page.NextCursor, &cursor))
+		assert.Equal(t, userStatsCursor{SortBy: domain.UserStatsSortAssigned, Order: "desc", Value: 1, UserID: "user-2"}, cursor)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("last page continues from cursor", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewStatsService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), logger)
+
+		cursor := encodeCursor(userStatsCursor{SortBy: domain.UserStatsSortMerged, Order: "asc", Value: 2, UserID: "user-2"})
+		rows := []*domain.UserStats{{UserID: "user-5", MergedPRCount: 4}}
+		mockPRRepo.On("ListUserStats", ctx, repository.UserStatsQuery{
+			SortBy:      domain.UserStatsSortMerged,
+			AfterValue:  2,
+			AfterUserID: "user-2",
+			Limit:       DefaultPageLimit + 1,
+		}).Return(rows, nil)
+
+		page, err := service.GetAllUserStats(ctx, UserStatsParams{SortBy: domain.UserStatsSortMerged, Order: "asc", Cursor: cursor})
+
+		require.NoError(t, err)
+		assert.Equal(t, rows, page.Users)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		service := NewStatsService(new(mocks.MockPRRepository), new(mocks.MockUserRepository), new(mocks.MockTeamRepository), logger)
+		otherSort := encodeCursor(userStatsCursor{SortBy: domain.UserStatsSortOpen, Order: "desc", UserID: "user-1"})
+
+		cases := map[string]UserStatsParams{
+			"unknown sort":      {SortBy: "username"},
+			"unknown order":     {Order: "up"},
+			"negative limit":    {Limit: -1},
+			"limit too large":   {Limit: MaxPageLimit + 1},
+			"malformed cursor":  {Cursor: "not a cursor"},
+			"cursor of another": {Cursor: otherSort},
+		}
+
+		for name, params := range cases {
+			t.Run(name, func(t *testing.T) {
+				page, err := service.GetAllUserStats(ctx, params)
+
+				assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+				assert.Nil(t, page)
+			})
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewStatsService(mockPRRepo, new(mocks.MockUserRepository), new(mocks.MockTeamRepository), logger)
+
+		mockPRRepo.On("ListUserStats", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		page, err := service.GetAllUserStats(ctx, UserStatsParams{})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, page)
 	})
 
 	t.Run("error when fetching PRs by reviewer", func(t *testing.T) {
 		mockPRRepo := new(mocks.MockPRRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
 		logger := zap.NewNop()
-		service := NewStatsService(mockPRRepo, mockUserRepo, logger)
+		service := NewStatsService(mockPRRepo, mockUserRepo, new(mocks.MockTeamRepository), logger)
 
 		ctx := context.Background()
 
@@ -161,3 +259,294 @@ func TestStatsServiceGetAllUserStats(t *testing.T) {
 		mockPRRepo.AssertExpectations(t)
 	})
 }
+
+func TestStatsServiceGetTeamStats(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	window := repository.TimeWindow{From: &from, To: &to}
+
+	t.Run("counts PRs and assignments within window", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewStatsService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+
+		members := []*domain.User{
+			{UserID: "u1", TeamName: "backend"},
+			{UserID: "u2", TeamName: "backend"},
+			{UserID: "u3", TeamName: "backend"},
+		}
+		load := []*domain.UserStats{
+			{UserID: "u1", AssignedCount: 4},
+			{UserID: "u2", AssignedCount: 2},
+			{UserID: "u3", AssignedCount: 2},
+		}
+
+		mockTeamRepo.On("Exists", ctx, "backend").Return(true, nil)
+		mockUserRepo.On("GetByTeam", ctx, "backend").Return(members, nil)
+		mockPRRepo.On("CountByStatus", ctx, repository.PRCountQuery{
+			AuthorIDs: []string{"u1", "u2", "u3"},
+			Created:   window,
+		}).Return(map[domain.PRStatus]int{domain.PRStatusOpen: 2, domain.PRStatusMerged: 3, domain.PRStatusClosed: 1}, nil)
+		mockPRRepo.On("ListUserStats", ctx, repository.UserStatsQuery{
+			TeamName:   "backend",
+			SortBy:     domain.UserStatsSortAssigned,
+			Descending: true,
+			Created:    window,
+		}).Return(load, nil)
+
+		stats, err := service.GetTeamStats(ctx, "backend", &from, &to)
+
+		require.NoError(t, err)
+		assert.Equal(t, "backend", stats.TeamName)
+		assert.Equal(t, 6, stats.TotalPRs)
+		assert.Equal(t, 2, stats.OpenPRs)
+		assert.Equal(t, 3, stats.MergedPRs)
+		assert.Equal(t, 1, stats.ClosedPRs)
+		assert.Zero(t, stats.DraftPRs)
+		assert.Equal(t, load, stats.Members)
+		assert.Equal(t, 4, stats.Fairness.MaxAssigned)
+		assert.Equal(t, 2, stats.Fairness.MinAssigned)
+		require.NotNil(t, stats.Fairness.MaxMinRatio)
+		assert.InDelta(t, 2.0, *stats.Fairness.MaxMinRatio, 1e-9)
+		assert.InDelta(t, domain.Gini([]int{4, 2, 2}), stats.Fairness.Gini, 1e-9)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewStatsService(new(mocks.MockPRRepository), new(mocks.MockUserRepository), mockTeamRepo, logger)
+
+		mockTeamRepo.On("Exists", ctx, "ghosts").Return(false, nil)
+
+		stats, err := service.GetTeamStats(ctx, "ghosts", nil, nil)
+
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+		assert.Nil(t, stats)
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		service := NewStatsService(nil, nil, nil, logger)
+
+		stats, err := service.GetTeamStats(ctx, "backend", &to, &from)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+		assert.Nil(t, stats)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockTeamRepo := new(mocks.MockTeamRepository)
+		service := NewStatsService(mockPRRepo, mockUserRepo, mockTeamRepo, logger)
+
+		mockTeamRepo.On("Exists", ctx, "backend").Return(true, nil)
+		mockUserRepo.On("GetByTeam", ctx, "backend").Return([]*domain.User{{UserID: "u1"}}, nil)
+		mockPRRepo.On("CountByStatus", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		stats, err := service.GetTeamStats(ctx, "backend", nil, nil)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, stats)
+	})
+}
+
+func TestStatsServiceGetMergeTimeStats(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+
+	t.Run("groups by team and author", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewStatsService(mockPRRepo, nil, nil, logger)
+
+		timings := []*domain.PRTiming{
+			{PullRequestID: "pr-1", AuthorID: "u1", TeamName: "backend", CreatedAt: base, MergedAt: at(time.Hour)},
+			{PullRequestID: "pr-2", AuthorID: "u1", TeamName: "backend", CreatedAt: base, MergedAt: at(3 * time.Hour)},
+			{PullRequestID: "pr-3", AuthorID: "u2", TeamName: "frontend", CreatedAt: base, MergedAt: at(2 * time.Hour)},
+			{PullRequestID: "pr-4", AuthorID: "ghost", CreatedAt: base, MergedAt: at(4 * time.Hour)},
+			{PullRequestID: "pr-5", AuthorID: "u2", TeamName: "frontend", CreatedAt: base},
+		}
+		mockPRRepo.On("ListMergeTimings", ctx, repository.PRTimingQuery{
+			Window: repository.TimeWindow{From: &base},
+		}).Return(timings, nil)
+
+		stats, err := service.GetMergeTimeStats(ctx, "", &base, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, stats.Overall.Count)
+		assert.InDelta(t, 2.5*3600, stats.Overall.MedianSeconds, 1e-6)
+
+		require.Len(t, stats.Teams, 2)
+		assert.Equal(t, "backend", stats.Teams[0].TeamName)
+		assert.Equal(t, 2, stats.Teams[0].Count)
+		assert.InDelta(t, 2*3600, stats.Teams[0].MedianSeconds, 1e-6)
+		assert.Equal(t, "frontend", stats.Teams[1].TeamName)
+
+		require.Len(t, stats.Authors, 3)
+		assert.Equal(t, "ghost", stats.Authors[0].AuthorID)
+		assert.Empty(t, stats.Authors[0].TeamName)
+		assert.Equal(t, "u1", stats.Authors[1].AuthorID)
+		assert.InDelta(t, 2.8*3600, stats.Authors[1].P90Seconds, 1e-6)
+		assert.Equal(t, "u2", stats.Authors[2].AuthorID)
+		assert.Equal(t, 1, stats.Authors[2].Count)
+	})
+
+	t.Run("no merged PRs", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewStatsService(mockPRRepo, nil, nil, logger)
+
+		mockPRRepo.On("ListMergeTimings", ctx, repository.PRTimingQuery{TeamName: "backend"}).Return([]*domain.PRTiming{}, nil)
+
+		stats, err := service.GetMergeTimeStats(ctx, "backend", nil, nil)
+
+		require.NoError(t, err)
+		assert.Zero(t, stats.Overall)
+		assert.NotNil(t, stats.Teams)
+		assert.NotNil(t, stats.Authors)
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		service := NewStatsService(nil, nil, nil, logger)
+
+		stats, err := service.GetMergeTimeStats(ctx, "", &base, &base)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+		assert.Nil(t, stats)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewStatsService(mockPRRepo, nil, nil, logger)
+
+		mockPRRepo.On("ListMergeTimings", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		stats, err := service.GetMergeTimeStats(ctx, "", nil, nil)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, stats)
+	})
+}
+
+func TestStatsServiceGetFirstReviewStats(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+
+	t.Run("groups reviewed PRs by team and author", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewStatsService(mockPRRepo, nil, nil, logger)
+
+		timings := []*domain.PRTiming{
+			{PullRequestID: "pr-1", AuthorID: "u2", TeamName: "backend", CreatedAt: base, FirstReviewedAt: at(10 * time.Minute)},
+			{PullRequestID: "pr-2", AuthorID: "u1", TeamName: "backend", CreatedAt: base, FirstReviewedAt: at(30 * time.Minute)},
+			{PullRequestID: "pr-3", AuthorID: "u1", TeamName: "backend", CreatedAt: base},
+		}
+		mockPRRepo.On("ListReviewTimings", ctx, repository.PRTimingQuery{TeamName: "backend"}).Return(timings, nil)
+
+		stats, err := service.GetFirstReviewStats(ctx, "backend", nil, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.PRCount)
+		assert.Equal(t, 2, stats.Overall.Count)
+		assert.InDelta(t, 20*60, stats.Overall.MedianSeconds, 1e-6)
+		require.Len(t, stats.Teams, 1)
+		assert.Equal(t, "backend", stats.Teams[0].TeamName)
+		require.Len(t, stats.Authors, 2)
+		assert.Equal(t, "u1", stats.Authors[0].AuthorID)
+		assert.InDelta(t, 30*60, stats.Authors[0].MedianSeconds, 1e-6)
+		assert.Equal(t, 1, stats.Authors[0].Count)
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		service := NewStatsService(nil, nil, nil, logger)
+		later := base.Add(time.Hour)
+
+		stats, err := service.GetFirstReviewStats(ctx, "", &later, &base)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+		assert.Nil(t, stats)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewStatsService(mockPRRepo, nil, nil, logger)
+
+		mockPRRepo.On("ListReviewTimings", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		stats, err := service.GetFirstReviewStats(ctx, "", nil, nil)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, stats)
+	})
+}
+
+func TestStatsServiceGetReassignmentStats(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+
+	t.Run("lists reassigned PRs", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewStatsService(mockPRRepo, nil, nil, logger)
+
+		timings := []*domain.PRTiming{
+			{PullRequestID: "pr-2", AuthorID: "u1", TeamName: "backend", CreatedAt: base, FirstReassignedAt: at(30 * time.Minute)},
+			{PullRequestID: "pr-1", AuthorID: "u1", TeamName: "backend", CreatedAt: base, FirstReassignedAt: at(10 * time.Minute)},
+			{PullRequestID: "pr-3", AuthorID: "u2", TeamName: "backend", CreatedAt: base},
+		}
+		mockPRRepo.On("ListReassignmentTimings", ctx, repository.PRTimingQuery{TeamName: "backend"}).Return(timings, nil)
+
+		stats, err := service.GetReassignmentStats(ctx, "backend", nil, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.PRCount)
+		assert.Equal(t, 2, stats.Latency.Count)
+		assert.InDelta(t, 20*60, stats.Latency.MedianSeconds, 1e-6)
+		require.Len(t, stats.PullRequests, 2)
+		assert.Equal(t, "pr-1", stats.PullRequests[0].PullRequestID)
+		assert.InDelta(t, 600, stats.PullRequests[0].Seconds, 1e-6)
+		assert.Equal(t, *at(10 * time.Minute), stats.PullRequests[0].FirstReassignedAt)
+		assert.Equal(t, "pr-2", stats.PullRequests[1].PullRequestID)
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		service := NewStatsService(nil, nil, nil, logger)
+		later := base.Add(time.Hour)
+
+		stats, err := service.GetReassignmentStats(ctx, "", &later, &base)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+		assert.Nil(t, stats)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPRRepo := new(mocks.MockPRRepository)
+		service := NewStatsService(mockPRRepo, nil, nil, logger)
+
+		mockPRRepo.On("ListReassignmentTimings", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		stats, err := service.GetReassignmentStats(ctx, "", nil, nil)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, stats)
+	})
+}
@@ -13,22 +13,36 @@ import (
 type TeamService struct {
 	teamRepo repository.TeamRepository
 	userRepo repository.UserRepository
-	logger   *zap.Logger
+	// prService hands the OPEN reviews of members leaving the team over to the rest of it
+	prService *PRService
+	uow       repository.UnitOfWork
+	logger    *zap.Logger
 }
 
 func NewTeamService(
 	teamRepo repository.TeamRepository,
 	userRepo repository.UserRepository,
+	prService *PRService,
+	uow repository.UnitOfWork,
 	logger *zap.Logger,
 ) *TeamService {
 	return &TeamService{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
-		logger:   logger,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		prService: prService,
+		uow:       uow,
+		logger:    logger,
 	}
 }
 
+// CreateTeam creates the team together with its members: a failure leaves neither the team nor any of them changed
 func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		return s.createTeam(ctx, team)
+	})
+}
+
+func (s *TeamService) createTeam(ctx context.Context, team *domain.Team) error {
 	exists, err := s.teamRepo.Exists(ctx, team.TeamName)
 	if err != nil {
 		return fmt.Errorf("failed to check team existence: %w", err)
@@ -37,16 +51,33 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) error {
 		return domain.ErrTeamExists
 	}
 
+	// members of other teams have to be moved explicitly, /team/add never takes a user away from their team
+	userIDs := make([]string, 0, len(team.Members))
+	for _, member := range team.Members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get team users: %w", err)
+	}
+	for _, user := range users {
+		if user.TeamName != "" && user.TeamName != team.TeamName {
+			return domain.ErrUserInAnotherTeam
+		}
+	}
+
 	if err := s.teamRepo.Create(ctx, team); err != nil {
 		return err
 	}
 
 	for _, member := range team.Members {
 		user := &domain.User{
-			UserID:   member.UserID,
-			Username: member.Username,
-			TeamName: team.TeamName,
-			IsActive: member.IsActive,
+			UserID:         member.UserID,
+			Username:       member.Username,
+			TeamName:       team.TeamName,
+			IsActive:       member.IsActive,
+			MaxOpenReviews: member.MaxOpenReviews,
+			Email:          member.Email,
 		}
 
 		if err := s.userRepo.CreateOrUpdate(ctx, user); err != nil {
@@ -58,36 +89,281 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) error {
 	return nil
 }
 
-func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
-	team, err := s.teamRepo.GetByName(ctx, teamName)
+// DeactivateUsers sets is_active=false for the listed team members and hands their OPEN reviews over to
+// the rest of the team with PRService.ReassignOpenPRsForTeam. Both are done in one unit of work: nothing is
+// changed if any of the users is not a member of the team or any of their reviews fails to be reassigned.
+func (s *TeamService) DeactivateUsers(ctx context.Context, teamName string, userIDs []string) ([]*domain.ReviewerReassignment, error) {
+	var reassignments []*domain.ReviewerReassignment
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.deactivateUsers(ctx, teamName, userIDs); err != nil {
+			return err
+		}
+
+		var err error
+		reassignments, err = s.prService.ReassignOpenPRsForTeam(ctx, teamName, userIDs, domain.PREventReasonDeactivated)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reassignments, nil
+}
+
+func (s *TeamService) deactivateUsers(ctx context.Context, teamName string, userIDs []string) error {
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	members, err := s.userRepo.GetByTeam(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to get team users: %w", err)
+	}
+
+	memberIDs := make(map[string]bool, len(members))
+	for _, member := range members {
+		memberIDs[member.UserID] = true
+	}
+	for _, userID := range userIDs {
+		if !memberIDs[userID] {
+			return domain.ErrUserNotFound
+		}
+	}
+
+	return s.userRepo.UpdateIsActiveMany(ctx, userIDs, false)
+}
+
+// AddMember adds the user to the team, a user already in the team gets the member data updated.
+// Users of another team have to be moved explicitly, ErrUserInAnotherTeam is returned for them.
+func (s *TeamService) AddMember(ctx context.Context, teamName string, member domain.TeamMember) (*domain.Team, error) {
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	user, err := s.userRepo.GetByID(ctx, member.UserID)
+	switch {
+	case err == domain.ErrUserNotFound:
+	case err != nil:
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	case user.TeamName != "" && user.TeamName != teamName:
+		return nil, domain.ErrUserInAnotherTeam
+	}
+
+	// absences and an email missing in the request are kept, CreateOrUpdate does not overwrite empty fields
+	if err := s.userRepo.CreateOrUpdate(ctx, &domain.User{
+		UserID:         member.UserID,
+		Username:       member.Username,
+		TeamName:       teamName,
+		IsActive:       member.IsActive,
+		MaxOpenReviews: member.MaxOpenReviews,
+		Email:          member.Email,
+	}); err != nil {
+		s.logger.Error("failed to create or update user", zap.Error(err), zap.String("user_id", member.UserID))
+		return nil, fmt.Errorf("failed to create or update user %s: %w", member.UserID, err)
+	}
+
+	return s.GetTeam(ctx, teamName)
+}
+
+// RemoveMember takes the user out of the team, the user is left without a team until added to another one.
+// Their OPEN reviews are handed over to the remaining members with PRService.ReassignOpenPRsForTeam in the
+// same unit of work: if a review fails to be reassigned, the user stays in the team.
+func (s *TeamService) RemoveMember(ctx context.Context, teamName, userID string) ([]*domain.ReviewerReassignment, error) {
+	var reassignments []*domain.ReviewerReassignment
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.removeMember(ctx, teamName, userID); err != nil {
+			return err
+		}
+
+		var err error
+		reassignments, err = s.prService.ReassignOpenPRsForTeam(ctx, teamName, []string{userID}, domain.PREventReasonLeftTeam)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reassignments, nil
+}
+
+func (s *TeamService) removeMember(ctx context.Context, teamName, userID string) error {
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err == domain.ErrUserNotFound {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.TeamName != teamName {
+		return domain.ErrUserNotFound
+	}
+
+	return s.userRepo.UpdateTeam(ctx, userID, "")
+}
+
+// RenameTeam changes the team name for the team, its users and teams using it as a fallback in one unit of work
+func (s *TeamService) RenameTeam(ctx context.Context, oldName, newName string) (*domain.Team, error) {
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.teamRepo.Rename(ctx, oldName, newName); err != nil {
+			return err
+		}
+
+		if err := s.userRepo.RenameTeam(ctx, oldName, newName); err != nil {
+			return fmt.Errorf("failed to rename users team: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return s.GetTeam(ctx, newName)
+}
+
+// DeleteTeam removes a team without members, members have to be removed or moved first.
+// The check for members and the removal are one unit of work.
+func (s *TeamService) DeleteTeam(ctx context.Context, teamName string) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		return s.deleteTeam(ctx, teamName)
+	})
+}
+
+func (s *TeamService) deleteTeam(ctx context.Context, teamName string) error {
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+
 	users, err := s.userRepo.GetByTeam(ctx, teamName)
 	if err != nil {
-		s.logger.Error("failed to get team users", zap.Error(err), zap.String("team_name", teamName))
-		return nil, fmt.Errorf("failed to get team users: %w", err)
+		return fmt.Errorf("failed to get team users: %w", err)
+	}
+	if len(users) > 0 {
+		return domain.ErrTeamNotEmpty
 	}
 
-	userMap := make(map[string]*domain.User)
-	for _, user := range users {
-		userMap[user.UserID] = user
+	return s.teamRepo.Delete(ctx, teamName)
+}
+
+// MoveUser transfers the user to toTeam and hands their OPEN reviews on the old team's PRs over to the old team
+// with PRService.ReleaseMovedReviewer. The roster is read from users' team_name, so the move is a single write,
+// it is made in one unit of work with the handoff: if a review fails to be reassigned, the user is not moved.
+// Returns the moved user, the team they left and the reassigned reviews, moving a user to their current team
+// changes nothing.
+func (s *TeamService) MoveUser(ctx context.Context, userID, toTeam string) (user *domain.User, fromTeam string, reassignments []*domain.ReviewerReassignment, err error) {
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		fromTeam = user.TeamName
+		if fromTeam == toTeam {
+			reassignments = []*domain.ReviewerReassignment{}
+			return nil
+		}
+
+		exists, err := s.teamRepo.Exists(ctx, toTeam)
+		if err != nil {
+			return fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return domain.ErrTeamNotFound
+		}
+
+		if err := s.userRepo.UpdateTeam(ctx, userID, toTeam); err != nil {
+			return err
+		}
+		user.TeamName = toTeam
+
+		reassignments, err = s.prService.ReleaseMovedReviewer(ctx, userID, fromTeam, toTeam)
+		return err
+	})
+	if err != nil {
+		return nil, "", nil, err
 	}
 
-	updatedMembers := make([]domain.TeamMember, 0, len(team.Members))
-	for _, member := range team.Members {
-		if user, exists := userMap[member.UserID]; exists {
-			updatedMembers = append(updatedMembers, domain.TeamMember{
-				UserID:   user.UserID,
-				Username: user.Username,
-				IsActive: user.IsActive,
-			})
-		} else {
-			updatedMembers = append(updatedMembers, member)
+	return user, fromTeam, reassignments, nil
+}
+
+// GetTeam returns the team with its members, the users having its team_name
+func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	return s.teamRepo.GetWithMembers(ctx, teamName)
+}
+
+// TeamListParams are the order and page of the teams list
+type TeamListParams struct {
+	// "asc" or "desc" by team_name, asc by default
+	Order  string
+	Cursor string
+	Limit  int
+}
+
+type TeamsPage struct {
+	Teams      []*domain.Team `json:"teams"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// teamListCursor is the position after the last team of a page
+type teamListCursor struct {
+	Order    string `json:"o"`
+	TeamName string `json:"t"`
+}
+
+// ListTeams returns a page of teams with their members
+func (s *TeamService) ListTeams(ctx context.Context, params TeamListParams) (*TeamsPage, error) {
+	order, err := sortOrder(params.Order, "asc")
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := pageLimit(params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra team tells whether there is a next page
+	query := repository.TeamListQuery{Descending: order == "desc", Limit: limit + 1}
+	if params.Cursor != "" {
+		var after teamListCursor
+		if err := decodeCursor(params.Cursor, &after); err != nil {
+			return nil, err
+		}
+		if after.Order != order || after.TeamName == "" {
+			return nil, domain.ErrInvalidQuery
 		}
+		query.AfterTeamName = after.TeamName
+	}
+
+	teams, err := s.teamRepo.List(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	page := &TeamsPage{Teams: teams}
+	if len(teams) > limit {
+		page.Teams = teams[:limit]
+		page.NextCursor = encodeCursor(teamListCursor{Order: order, TeamName: page.Teams[limit-1].TeamName})
 	}
 
-	team.Members = updatedMembers
-	return team, nil
+	return page, nil
 }
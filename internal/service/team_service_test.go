@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
+	"assignment-service/internal/repository/memory"
 	"assignment-service/internal/repository/mocks"
 
 	"github.com/stretchr/testify/assert"
@@ -22,7 +24,7 @@ func TestTeamServiceCreateTeam(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
 		mockTeamRepo := new(mocks.MockTeamRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewTeamService(mockTeamRepo, mockUserRepo, logger)
+		service := NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
 
 		team := &domain.Team{
 			TeamName: "team-1",
@@ -33,6 +35,9 @@ func TestTeamServiceCreateTeam(t *testing.T) {
 		}
 
 		mockTeamRepo.On("Exists", ctx, "team-1").Return(false, nil)
+		mockUserRepo.On("GetByIDs", ctx, []string{"user-1", "user-2"}).Return([]*domain.User{
+			{UserID: "user-2", TeamName: "team-1"},
+		}, nil)
 		mockTeamRepo.On("Create", ctx, team).Return(nil)
 		mockUserRepo.On("CreateOrUpdate", ctx, mock.AnythingOfType("*domain.User")).Return(nil).Times(2)
 
@@ -46,7 +51,7 @@ func TestTeamServiceCreateTeam(t *testing.T) {
 	t.Run("team already exists", func(t *testing.T) {
 		mockTeamRepo := new(mocks.MockTeamRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewTeamService(mockTeamRepo, mockUserRepo, logger)
+		service := NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
 
 		team := &domain.Team{
 			TeamName: "team-1",
@@ -63,6 +68,55 @@ func TestTeamServiceCreateTeam(t *testing.T) {
 	})
 }
 
+// failingUserRepo fails CreateOrUpdate of one user
+type failingUserRepo struct {
+	repository.UserRepository
+	userID string
+}
+
+func (r *failingUserRepo) CreateOrUpdate(ctx context.Context, user *domain.User) error {
+	if user.UserID == r.userID {
+		return assert.AnError
+	}
+	return r.UserRepository.CreateOrUpdate(ctx, user)
+}
+
+func TestTeamServiceCreateTeamRollback(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	require.NoError(t, repos.Users.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", Username: "user1", IsActive: false}))
+
+	team := &domain.Team{
+		TeamName: "team-1",
+		Members: []domain.TeamMember{
+			{UserID: "user-1", Username: "renamed", IsActive: true},
+			{UserID: "user-2", Username: "user2", IsActive: true},
+			{UserID: "user-3", Username: "user3", IsActive: true},
+		},
+	}
+	svc := NewTeamService(repos.Teams, &failingUserRepo{UserRepository: repos.Users, userID: "user-3"}, nil, repos.UnitOfWork, zap.NewNop())
+
+	err := svc.CreateTeam(ctx, team)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	exists, err := repos.Teams.Exists(ctx, "team-1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	// the members written before the failure are back as they were
+	user, err := repos.Users.GetByID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.User{UserID: "user-1", Username: "user1", IsActive: false}, user)
+	_, err = repos.Users.GetByID(ctx, "user-2")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	// without the failure the same call creates everything
+	svc = NewTeamService(repos.Teams, repos.Users, nil, repos.UnitOfWork, zap.NewNop())
+	require.NoError(t, svc.CreateTeam(ctx, team))
+	created, err := repos.Teams.GetWithMembers(ctx, "team-1")
+	require.NoError(t, err)
+	assert.Len(t, created.Members, 3)
+}
+
 func TestTeamServiceGetTeam(t *testing.T) {
 	logger := zap.NewNop()
 	ctx := context.Background()
@@ -70,21 +124,16 @@ func TestTeamServiceGetTeam(t *testing.T) {
 	t.Run("successful get team", func(t *testing.T) {
 		mockTeamRepo := new(mocks.MockTeamRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewTeamService(mockTeamRepo, mockUserRepo, logger)
+		service := NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
 
 		team := &domain.Team{
 			TeamName: "team-1",
 			Members: []domain.TeamMember{
-				{UserID: "user-1", Username: "user1", IsActive: true},
+				{UserID: "user-1", Username: "user1", IsActive: false},
 			},
 		}
 
-		users := []*domain.User{
-			{UserID: "user-1", Username: "user1", TeamName: "team-1", IsActive: false}, // actual db data
-		}
-
-		mockTeamRepo.On("GetByName", ctx, "team-1").Return(team, nil)
-		mockUserRepo.On("GetByTeam", ctx, "team-1").Return(users, nil)
+		mockTeamRepo.On("GetWithMembers", ctx, "team-1").Return(team, nil)
 
 		result, err := service.GetTeam(ctx, "team-1")
 
@@ -93,18 +142,17 @@ func TestTeamServiceGetTeam(t *testing.T) {
 		assert.Equal(t, "team-1", result.TeamName)
 		require.Len(t, result.Members, 1)
 		assert.Equal(t, "user-1", result.Members[0].UserID)
-		assert.Equal(t, "user1", result.Members[0].Username)
-		assert.Equal(t, false, result.Members[0].IsActive) // must be actual status
+		assert.Equal(t, false, result.Members[0].IsActive)
 		mockTeamRepo.AssertExpectations(t)
-		mockUserRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "GetByTeam")
 	})
 
 	t.Run("team not found", func(t *testing.T) {
 		mockTeamRepo := new(mocks.MockTeamRepository)
 		mockUserRepo := new(mocks.MockUserRepository)
-		service := NewTeamService(mockTeamRepo, mockUserRepo, logger)
+		service := NewTeamService(mockTeamRepo, mockUserRepo, nil, mocks.MockUnitOfWork{}, logger)
 
-		mockTeamRepo.On("GetByName", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
+		mockTeamRepo.On("GetWithMembers", ctx, "team-1").Return(nil, domain.ErrTeamNotFound)
 
 		result, err := service.GetTeam(ctx, "team-1")
 
@@ -125,7 +173,7 @@ func TestTeamServiceCreateTeamErrorCoverage(t *testing.T) {
 
 		teamRepo.On("Exists", ctx, "team-1").Return(false, fmt.Errorf("db down"))
 
-		svc := NewTeamService(teamRepo, userRepo, logger)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
 
 		err := svc.CreateTeam(ctx, &domain.Team{TeamName: "team-1"})
 
@@ -141,9 +189,10 @@ func TestTeamServiceCreateTeamErrorCoverage(t *testing.T) {
 		userRepo := new(mocks.MockUserRepository)
 
 		teamRepo.On("Exists", ctx, "team-1").Return(false, nil)
+		userRepo.On("GetByIDs", ctx, []string{}).Return([]*domain.User{}, nil)
 		teamRepo.On("Create", ctx, mock.Anything).Return(fmt.Errorf("insert failed"))
 
-		svc := NewTeamService(teamRepo, userRepo, logger)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
 
 		err := svc.CreateTeam(ctx, &domain.Team{TeamName: "team-1"})
 
@@ -168,6 +217,7 @@ func TestTeamServiceCreateTeamErrorCoverage(t *testing.T) {
 		}
 
 		teamRepo.On("Exists", ctx, "team-1").Return(false, nil)
+		userRepo.On("GetByIDs", ctx, []string{"user-1", "user-2"}).Return([]*domain.User{}, nil)
 		teamRepo.On("Create", ctx, team).Return(nil)
 
 		userRepo.On("CreateOrUpdate", ctx, mock.MatchedBy(func(u *domain.User) bool {
@@ -178,7 +228,7 @@ func TestTeamServiceCreateTeamErrorCoverage(t *testing.T) {
 			return u.UserID == "user-2"
 		})).Return(fmt.Errorf("unique violation")).Once()
 
-		svc := NewTeamService(teamRepo, userRepo, mockLogger)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, mockLogger)
 
 		err := svc.CreateTeam(ctx, team)
 
@@ -194,9 +244,10 @@ func TestTeamServiceCreateTeamErrorCoverage(t *testing.T) {
 		team := &domain.Team{TeamName: "team-empty"}
 
 		teamRepo.On("Exists", ctx, "team-empty").Return(false, nil)
+		userRepo.On("GetByIDs", ctx, []string{}).Return([]*domain.User{}, nil)
 		teamRepo.On("Create", ctx, team).Return(nil)
 
-		svc := NewTeamService(teamRepo, userRepo, logger)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
 
 		err := svc.CreateTeam(ctx, team)
 
@@ -204,4 +255,678 @@ func TestTeamServiceCreateTeamErrorCoverage(t *testing.T) {
 		teamRepo.AssertExpectations(t)
 		userRepo.AssertNotCalled(t, "CreateOrUpdate")
 	})
+
+	t.Run("member of another team", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+
+		team := &domain.Team{
+			TeamName: "team-1",
+			Members:  []domain.TeamMember{{UserID: "user-1", Username: "u1", IsActive: true}},
+		}
+
+		teamRepo.On("Exists", ctx, "team-1").Return(false, nil)
+		userRepo.On("GetByIDs", ctx, []string{"user-1"}).Return([]*domain.User{{UserID: "user-1", TeamName: "team-2"}}, nil)
+
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		err := svc.CreateTeam(ctx, team)
+
+		assert.Equal(t, domain.ErrUserInAnotherTeam, err)
+		teamRepo.AssertNotCalled(t, "Create")
+		userRepo.AssertNotCalled(t, "CreateOrUpdate")
+	})
+}
+
+func TestTeamServiceDeactivateUsers(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	members := []*domain.User{
+		{UserID: "user-1", TeamName: "team-1", IsActive: true},
+		{UserID: "user-2", TeamName: "team-1", IsActive: true},
+		{UserID: "user-3", TeamName: "team-1", IsActive: true},
+	}
+
+	t.Run("successful deactivation", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		prRepo := new(mocks.MockPRRepository)
+		prService := NewPRService(prRepo, userRepo, teamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+		svc := NewTeamService(teamRepo, userRepo, prService, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		teamRepo.On("GetByName", ctx, "team-1").Return(&domain.Team{TeamName: "team-1"}, nil)
+		userRepo.On("GetByTeam", ctx, "team-1").Return(members, nil)
+		userRepo.On("UpdateIsActiveMany", ctx, []string{"user-1", "user-3"}, false).Return(nil)
+		prRepo.On("GetOpenByReviewers", ctx, []string{"user-1", "user-3"}).Return([]*domain.PullRequest{}, nil)
+
+		reassignments, err := svc.DeactivateUsers(ctx, "team-1", []string{"user-1", "user-3"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, reassignments)
+		teamRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(false, nil)
+
+		_, err := svc.DeactivateUsers(ctx, "team-1", []string{"user-1"})
+
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+		userRepo.AssertNotCalled(t, "UpdateIsActiveMany")
+	})
+
+	t.Run("user from another team", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByTeam", ctx, "team-1").Return(members, nil)
+
+		_, err := svc.DeactivateUsers(ctx, "team-1", []string{"user-1", "stranger"})
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+		userRepo.AssertNotCalled(t, "UpdateIsActiveMany")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByTeam", ctx, "team-1").Return(nil, assert.AnError)
+
+		_, err := svc.DeactivateUsers(ctx, "team-1", []string{"user-1"})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestTeamServiceDeactivateUsersRollback(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "team-1"}))
+	for _, userID := range []string{"user-1", "user-2", "user-3"} {
+		require.NoError(t, repos.Users.CreateOrUpdate(ctx, &domain.User{UserID: userID, TeamName: "team-1", IsActive: true}))
+	}
+	require.NoError(t, repos.PRs.Create(ctx, &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "user-1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"user-2"},
+		RequiredReviewers: 1,
+	}))
+
+	prRepo := &failingPRRepo{PRRepository: repos.PRs, prID: "pr-1"}
+	prService := NewPRService(prRepo, repos.Users, repos.Teams, repos.PREvents, repos.UnitOfWork, NewSelectorRegistry(), AssignmentPolicy{}, zap.NewNop())
+	svc := NewTeamService(repos.Teams, repos.Users, prService, repos.UnitOfWork, zap.NewNop())
+
+	reassignments, err := svc.DeactivateUsers(ctx, "team-1", []string{"user-2"})
+
+	require.Error(t, err)
+	assert.Nil(t, reassignments)
+	// the reassignment failed, so user-2 stays active and keeps the review
+	user, err := repos.Users.GetByID(ctx, "user-2")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+
+	// without the failure user-2 is deactivated and the review goes to user-3
+	prRepo.prID = ""
+	reassignments, err = svc.DeactivateUsers(ctx, "team-1", []string{"user-2"})
+
+	require.NoError(t, err)
+	require.Len(t, reassignments, 1)
+	assert.Equal(t, "user-3", reassignments[0].NewReviewerID)
+	user, err = repos.Users.GetByID(ctx, "user-2")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+}
+
+func TestTeamServiceAddMember(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	member := domain.TeamMember{UserID: "user-1", Username: "alice", IsActive: true}
+
+	t.Run("adds new user", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
+		userRepo.On("CreateOrUpdate", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.UserID == "user-1" && u.TeamName == "team-1" && u.IsActive
+		})).Return(nil)
+		teamRepo.On("GetWithMembers", ctx, "team-1").Return(&domain.Team{TeamName: "team-1", Members: []domain.TeamMember{member}}, nil)
+
+		team, err := svc.AddMember(ctx, "team-1", member)
+
+		require.NoError(t, err)
+		require.Len(t, team.Members, 1)
+		assert.Equal(t, "user-1", team.Members[0].UserID)
+		teamRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("teamless user joins", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByID", ctx, "user-1").Return(&domain.User{UserID: "user-1"}, nil)
+		userRepo.On("CreateOrUpdate", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+		teamRepo.On("GetWithMembers", ctx, "team-1").Return(&domain.Team{TeamName: "team-1", Members: []domain.TeamMember{member}}, nil)
+
+		_, err := svc.AddMember(ctx, "team-1", member)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("user of another team", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByID", ctx, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-2"}, nil)
+
+		team, err := svc.AddMember(ctx, "team-1", member)
+
+		assert.Equal(t, domain.ErrUserInAnotherTeam, err)
+		assert.Nil(t, team)
+		userRepo.AssertNotCalled(t, "CreateOrUpdate")
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(false, nil)
+
+		_, err := svc.AddMember(ctx, "team-1", member)
+
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+		userRepo.AssertNotCalled(t, "GetByID")
+	})
+
+	t.Run("user write fails", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
+		userRepo.On("CreateOrUpdate", ctx, mock.AnythingOfType("*domain.User")).Return(assert.AnError)
+
+		_, err := svc.AddMember(ctx, "team-1", member)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestTeamServiceRemoveMember(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("removes member", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		prRepo := new(mocks.MockPRRepository)
+		prService := NewPRService(prRepo, userRepo, teamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+		svc := NewTeamService(teamRepo, userRepo, prService, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		teamRepo.On("GetByName", ctx, "team-1").Return(&domain.Team{TeamName: "team-1"}, nil)
+		userRepo.On("GetByID", ctx, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-1"}, nil)
+		userRepo.On("UpdateTeam", ctx, "user-1", "").Return(nil)
+		prRepo.On("GetOpenByReviewers", ctx, []string{"user-1"}).Return([]*domain.PullRequest{}, nil)
+
+		reassignments, err := svc.RemoveMember(ctx, "team-1", "user-1")
+
+		assert.NoError(t, err)
+		assert.Empty(t, reassignments)
+		teamRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("user of another team", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByID", ctx, "user-1").Return(&domain.User{UserID: "user-1", TeamName: "team-2"}, nil)
+
+		_, err := svc.RemoveMember(ctx, "team-1", "user-1")
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+		userRepo.AssertNotCalled(t, "UpdateTeam")
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(false, nil)
+
+		_, err := svc.RemoveMember(ctx, "team-1", "user-1")
+
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("user lookup fails", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByID", ctx, "user-1").Return(nil, assert.AnError)
+
+		_, err := svc.RemoveMember(ctx, "team-1", "user-1")
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestTeamServiceRemoveMemberRollback(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "team-1"}))
+	for _, userID := range []string{"user-1", "user-2", "user-3"} {
+		require.NoError(t, repos.Users.CreateOrUpdate(ctx, &domain.User{UserID: userID, TeamName: "team-1", IsActive: true}))
+	}
+	require.NoError(t, repos.PRs.Create(ctx, &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "user-1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"user-2"},
+		RequiredReviewers: 1,
+	}))
+
+	prRepo := &failingPRRepo{PRRepository: repos.PRs, prID: "pr-1"}
+	prService := NewPRService(prRepo, repos.Users, repos.Teams, repos.PREvents, repos.UnitOfWork, NewSelectorRegistry(), AssignmentPolicy{}, zap.NewNop())
+	svc := NewTeamService(repos.Teams, repos.Users, prService, repos.UnitOfWork, zap.NewNop())
+
+	reassignments, err := svc.RemoveMember(ctx, "team-1", "user-2")
+
+	require.Error(t, err)
+	assert.Nil(t, reassignments)
+	// the reassignment failed, so user-2 stays in the team with the review
+	user, err := repos.Users.GetByID(ctx, "user-2")
+	require.NoError(t, err)
+	assert.Equal(t, "team-1", user.TeamName)
+
+	// without the failure user-2 leaves and the review goes to user-3
+	prRepo.prID = ""
+	reassignments, err = svc.RemoveMember(ctx, "team-1", "user-2")
+
+	require.NoError(t, err)
+	require.Len(t, reassignments, 1)
+	assert.Equal(t, "user-3", reassignments[0].NewReviewerID)
+	user, err = repos.Users.GetByID(ctx, "user-2")
+	require.NoError(t, err)
+	assert.Empty(t, user.TeamName)
+}
+
+func TestTeamServiceRenameTeam(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("renames team and users", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Rename", ctx, "team-1", "platform").Return(nil)
+		userRepo.On("RenameTeam", ctx, "team-1", "platform").Return(nil)
+		teamRepo.On("GetWithMembers", ctx, "platform").Return(&domain.Team{TeamName: "platform", Members: []domain.TeamMember{}}, nil)
+
+		team, err := svc.RenameTeam(ctx, "team-1", "platform")
+
+		require.NoError(t, err)
+		assert.Equal(t, "platform", team.TeamName)
+		teamRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Rename", ctx, "team-1", "team-2").Return(domain.ErrTeamExists)
+
+		team, err := svc.RenameTeam(ctx, "team-1", "team-2")
+
+		assert.Equal(t, domain.ErrTeamExists, err)
+		assert.Nil(t, team)
+		userRepo.AssertNotCalled(t, "RenameTeam")
+	})
+
+	t.Run("users rename fails", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Rename", ctx, "team-1", "platform").Return(nil).Once()
+		userRepo.On("RenameTeam", ctx, "team-1", "platform").Return(assert.AnError)
+
+		team, err := svc.RenameTeam(ctx, "team-1", "platform")
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, team)
+		teamRepo.AssertExpectations(t)
+		teamRepo.AssertNotCalled(t, "GetWithMembers")
+	})
+}
+
+// renameFailingUserRepo fails RenameTeam
+type renameFailingUserRepo struct {
+	repository.UserRepository
+}
+
+func (renameFailingUserRepo) RenameTeam(context.Context, string, string) error {
+	return assert.AnError
+}
+
+func TestTeamServiceRenameTeamRollback(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "team-1"}))
+	require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: "team-2", FallbackTeams: []string{"team-1"}}))
+	require.NoError(t, repos.Users.CreateOrUpdate(ctx, &domain.User{UserID: "user-1", TeamName: "team-1", IsActive: true}))
+
+	svc := NewTeamService(repos.Teams, renameFailingUserRepo{UserRepository: repos.Users}, nil, repos.UnitOfWork, zap.NewNop())
+
+	_, err := svc.RenameTeam(ctx, "team-1", "platform")
+
+	assert.ErrorIs(t, err, assert.AnError)
+	// the team, the fallback mention and the user all keep the old name
+	exists, err := repos.Teams.Exists(ctx, "team-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	team, err := repos.Teams.GetByName(ctx, "team-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-1"}, team.FallbackTeams)
+	user, err := repos.Users.GetByID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "team-1", user.TeamName)
+
+	// without the failure all three are renamed
+	svc = NewTeamService(repos.Teams, repos.Users, nil, repos.UnitOfWork, zap.NewNop())
+	renamed, err := svc.RenameTeam(ctx, "team-1", "platform")
+
+	require.NoError(t, err)
+	require.Len(t, renamed.Members, 1)
+	assert.Equal(t, "user-1", renamed.Members[0].UserID)
+	team, err = repos.Teams.GetByName(ctx, "team-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"platform"}, team.FallbackTeams)
+}
+
+func TestTeamServiceDeleteTeam(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("deletes empty team", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByTeam", ctx, "team-1").Return([]*domain.User{}, nil)
+		teamRepo.On("Delete", ctx, "team-1").Return(nil)
+
+		err := svc.DeleteTeam(ctx, "team-1")
+
+		assert.NoError(t, err)
+		teamRepo.AssertExpectations(t)
+	})
+
+	t.Run("team with users", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(true, nil)
+		userRepo.On("GetByTeam", ctx, "team-1").Return([]*domain.User{{UserID: "user-1", TeamName: "team-1"}}, nil)
+
+		err := svc.DeleteTeam(ctx, "team-1")
+
+		assert.Equal(t, domain.ErrTeamNotEmpty, err)
+		teamRepo.AssertNotCalled(t, "Delete")
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("Exists", ctx, "team-1").Return(false, nil)
+
+		err := svc.DeleteTeam(ctx, "team-1")
+
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+		userRepo.AssertNotCalled(t, "GetByTeam")
+	})
+}
+
+func TestTeamServiceMoveUser(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	user := func() *domain.User {
+		return &domain.User{UserID: "user-1", Username: "alice", TeamName: "team-1", IsActive: true, MaxOpenReviews: 2}
+	}
+
+	t.Run("moves user between teams", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		prRepo := new(mocks.MockPRRepository)
+		prService := NewPRService(prRepo, userRepo, teamRepo, newEventRepo(), mocks.MockUnitOfWork{}, NewSelectorRegistry(), AssignmentPolicy{}, logger)
+		svc := NewTeamService(teamRepo, userRepo, prService, mocks.MockUnitOfWork{}, logger)
+
+		userRepo.On("GetByID", ctx, "user-1").Return(user(), nil)
+		teamRepo.On("Exists", ctx, "team-2").Return(true, nil)
+		userRepo.On("UpdateTeam", ctx, "user-1", "team-2").Return(nil)
+		teamRepo.On("GetByName", ctx, "team-1").Return(&domain.Team{TeamName: "team-1"}, nil)
+		prRepo.On("GetOpenByReviewers", ctx, []string{"user-1"}).Return([]*domain.PullRequest{}, nil)
+		userRepo.On("GetByTeam", ctx, "team-2").Return([]*domain.User{}, nil)
+
+		moved, fromTeam, reassignments, err := svc.MoveUser(ctx, "user-1", "team-2")
+
+		require.NoError(t, err)
+		assert.Equal(t, "team-1", fromTeam)
+		assert.Equal(t, "team-2", moved.TeamName)
+		assert.Empty(t, reassignments)
+		teamRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+		prRepo.AssertExpectations(t)
+	})
+
+	t.Run("same team changes nothing", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		userRepo.On("GetByID", ctx, "user-1").Return(user(), nil)
+
+		moved, fromTeam, reassignments, err := svc.MoveUser(ctx, "user-1", "team-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "team-1", fromTeam)
+		assert.Equal(t, "team-1", moved.TeamName)
+		assert.Empty(t, reassignments)
+		userRepo.AssertNotCalled(t, "UpdateTeam")
+	})
+
+	t.Run("target team not found", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		userRepo.On("GetByID", ctx, "user-1").Return(user(), nil)
+		teamRepo.On("Exists", ctx, "team-2").Return(false, nil)
+
+		_, _, _, err := svc.MoveUser(ctx, "user-1", "team-2")
+
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+		userRepo.AssertNotCalled(t, "UpdateTeam")
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		userRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
+
+		_, _, _, err := svc.MoveUser(ctx, "user-1", "team-2")
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("failed write leaves the user in the old team", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		userRepo := new(mocks.MockUserRepository)
+		svc := NewTeamService(teamRepo, userRepo, nil, mocks.MockUnitOfWork{}, logger)
+
+		userRepo.On("GetByID", ctx, "user-1").Return(user(), nil)
+		teamRepo.On("Exists", ctx, "team-2").Return(true, nil)
+		userRepo.On("UpdateTeam", ctx, "user-1", "team-2").Return(assert.AnError).Once()
+
+		moved, _, _, err := svc.MoveUser(ctx, "user-1", "team-2")
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, moved)
+		userRepo.AssertExpectations(t)
+	})
+}
+
+func TestTeamServiceMoveUserRollback(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	for _, teamName := range []string{"team-1", "team-2"} {
+		require.NoError(t, repos.Teams.Create(ctx, &domain.Team{TeamName: teamName}))
+	}
+	for _, userID := range []string{"user-1", "user-2", "user-3"} {
+		require.NoError(t, repos.Users.CreateOrUpdate(ctx, &domain.User{UserID: userID, TeamName: "team-1", IsActive: true}))
+	}
+	require.NoError(t, repos.PRs.Create(ctx, &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "user-1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"user-2"},
+		RequiredReviewers: 1,
+	}))
+
+	prRepo := &failingPRRepo{PRRepository: repos.PRs, prID: "pr-1"}
+	prService := NewPRService(prRepo, repos.Users, repos.Teams, repos.PREvents, repos.UnitOfWork, NewSelectorRegistry(), AssignmentPolicy{}, zap.NewNop())
+	svc := NewTeamService(repos.Teams, repos.Users, prService, repos.UnitOfWork, zap.NewNop())
+
+	moved, _, _, err := svc.MoveUser(ctx, "user-2", "team-2")
+
+	require.Error(t, err)
+	assert.Nil(t, moved)
+	// the handoff failed, so user-2 stays in the old team with the review
+	user, err := repos.Users.GetByID(ctx, "user-2")
+	require.NoError(t, err)
+	assert.Equal(t, "team-1", user.TeamName)
+
+	// without the failure user-2 moves and the review goes to user-3
+	prRepo.prID = ""
+	moved, fromTeam, reassignments, err := svc.MoveUser(ctx, "user-2", "team-2")
+
+	require.NoError(t, err)
+	assert.Equal(t, "team-2", moved.TeamName)
+	assert.Equal(t, "team-1", fromTeam)
+	require.Len(t, reassignments, 1)
+	assert.Equal(t, "user-3", reassignments[0].NewReviewerID)
+}
+
+func TestTeamServiceListTeams(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("first page with next cursor", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		svc := NewTeamService(teamRepo, new(mocks.MockUserRepository), nil, mocks.MockUnitOfWork{}, logger)
+
+		teams := []*domain.Team{{TeamName: "backend"}, {TeamName: "frontend"}, {TeamName: "mobile"}}
+		teamRepo.On("List", ctx, repository.TeamListQuery{Limit: 3}).Return(teams, nil)
+
+		page, err := svc.ListTeams(ctx, TeamListParams{Limit: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, teams[:2], page.Teams)
+
+		var cursor teamListCursor
+		require.NoError(t, decodeCursor(page.NextCursor, &cursor))
+		assert.Equal(t, teamListCursor{Order: "asc", TeamName: "frontend"}, cursor)
+	})
+
+	t.Run("last page continues from cursor", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		svc := NewTeamService(teamRepo, new(mocks.MockUserRepository), nil, mocks.MockUnitOfWork{}, logger)
+
+		cursor := encodeCursor(teamListCursor{Order: "desc", TeamName: "mobile"})
+		teams := []*domain.Team{{TeamName: "backend"}}
+		teamRepo.On("List", ctx, repository.TeamListQuery{
+			Descending:    true,
+			AfterTeamName: "mobile",
+			Limit:         DefaultPageLimit + 1,
+		}).Return(teams, nil)
+
+		page, err := svc.ListTeams(ctx, TeamListParams{Order: "desc", Cursor: cursor})
+
+		require.NoError(t, err)
+		assert.Equal(t, teams, page.Teams)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		svc := NewTeamService(new(mocks.MockTeamRepository), new(mocks.MockUserRepository), nil, mocks.MockUnitOfWork{}, logger)
+		otherOrder := encodeCursor(teamListCursor{Order: "desc", TeamName: "mobile"})
+
+		cases := map[string]TeamListParams{
+			"unknown order":     {Order: "up"},
+			"limit too large":   {Limit: MaxPageLimit + 1},
+			"malformed cursor":  {Cursor: "not a cursor"},
+			"cursor of another": {Cursor: otherOrder},
+		}
+
+		for name, params := range cases {
+			t.Run(name, func(t *testing.T) {
+				page, err := svc.ListTeams(ctx, params)
+
+				assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+				assert.Nil(t, page)
+			})
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		teamRepo := new(mocks.MockTeamRepository)
+		svc := NewTeamService(teamRepo, new(mocks.MockUserRepository), nil, mocks.MockUnitOfWork{}, logger)
+
+		teamRepo.On("List", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		page, err := svc.ListTeams(ctx, TeamListParams{})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, page)
+	})
 }
@@ -2,6 +2,10 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
 
 	"assignment-service/internal/domain"
 	"assignment-service/internal/repository"
@@ -39,6 +43,169 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 	return user, nil
 }
 
+// UserUpdate holds the user fields to change, nil fields are left as is and an empty Email removes it
+type UserUpdate struct {
+	Username       *string
+	Email          *string
+	MaxOpenReviews *int
+}
+
+// UpdateUser writes only the given profile fields, absences changed at the same time are kept
+func (s *UserService) UpdateUser(ctx context.Context, userID string, update UserUpdate) (*domain.User, error) {
+	profile := repository.UserProfileUpdate{
+		Username:       update.Username,
+		MaxOpenReviews: update.MaxOpenReviews,
+	}
+	if update.Email != nil {
+		email := domain.NormalizeEmail(*update.Email)
+		profile.Email = &email
+	}
+
+	if err := s.userRepo.UpdateProfile(ctx, userID, profile); err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.GetByID(ctx, userID)
+}
+
 func (s *UserService) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
 	return s.userRepo.GetByID(ctx, userID)
 }
+
+// AddAbsence schedules an out-of-office period, the user gets no new reviews while it lasts
+func (s *UserService) AddAbsence(ctx context.Context, userID string, absence *domain.Absence) (*domain.Absence, error) {
+	if err := absence.Validate(); err != nil {
+		return nil, err
+	}
+
+	absenceID, err := newAbsenceID()
+	if err != nil {
+		return nil, err
+	}
+	absence.AbsenceID = absenceID
+	absence.HandedOffAt = nil
+
+	if err := s.userRepo.AddAbsence(ctx, userID, absence); err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+// ListAbsences returns the user's absences ordered by start
+func (s *UserService) ListAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	absences := slices.Clone(user.Absences)
+	slices.SortStableFunc(absences, func(a, b domain.Absence) int {
+		return a.From.Compare(b.From)
+	})
+
+	return absences, nil
+}
+
+func (s *UserService) RemoveAbsence(ctx context.Context, userID, absenceID string) error {
+	return s.userRepo.RemoveAbsence(ctx, userID, absenceID)
+}
+
+func newAbsenceID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate absence id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// UserListParams are the filters, order and page of the users list
+type UserListParams struct {
+	TeamName string
+	// nil means both active and inactive users
+	IsActive *bool
+	// domain.UserSortID (default) or domain.UserSortUsername
+	SortBy string
+	// "asc" or "desc", asc by default
+	Order  string
+	Cursor string
+	Limit  int
+}
+
+type UsersPage struct {
+	Users      []*domain.User `json:"users"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// userListCursor is the position after the last user of a page, the sort is kept to reject mixed up cursors
+type userListCursor struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	Value  string `json:"v"`
+	UserID string `json:"u"`
+}
+
+// ListUsers returns a page of users, ties of the sort field are ordered by user_id
+func (s *UserService) ListUsers(ctx context.Context, params UserListParams) (*UsersPage, error) {
+	query := repository.UserListQuery{
+		TeamName: params.TeamName,
+		IsActive: params.IsActive,
+		SortBy:   params.SortBy,
+	}
+
+	switch query.SortBy {
+	case "":
+		query.SortBy = domain.UserSortID
+	case domain.UserSortID, domain.UserSortUsername:
+	default:
+		return nil, domain.ErrInvalidQuery
+	}
+
+	order, err := sortOrder(params.Order, "asc")
+	if err != nil {
+		return nil, err
+	}
+	query.Descending = order == "desc"
+
+	limit, err := pageLimit(params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	// one extra user tells whether there is a next page
+	query.Limit = limit + 1
+
+	if params.Cursor != "" {
+		var after userListCursor
+		if err := decodeCursor(params.Cursor, &after); err != nil {
+			return nil, err
+		}
+		if after.SortBy != query.SortBy || after.Order != order || after.UserID == "" {
+			return nil, domain.ErrInvalidQuery
+		}
+		query.AfterValue = after.Value
+		query.AfterUserID = after.UserID
+	}
+
+	users, err := s.userRepo.List(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	page := &UsersPage{Users: users}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		last := page.Users[limit-1]
+		value := last.UserID
+		if query.SortBy == domain.UserSortUsername {
+			value = last.Username
+		}
+		page.NextCursor = encodeCursor(userListCursor{
+			SortBy: query.SortBy,
+			Order:  order,
+			Value:  value,
+			UserID: last.UserID,
+		})
+	}
+
+	return page, nil
+}
@@ -3,11 +3,15 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"assignment-service/internal/domain"
+	"assignment-service/internal/repository"
 	"assignment-service/internal/repository/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -100,6 +104,91 @@ func TestUserServiceGetUserByID(t *testing.T) {
 	})
 }
 
+func TestUserServiceUpdateUser(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("updates only provided fields", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		maxOpenReviews := 3
+		user := &domain.User{UserID: "user-1", Username: "testuser", TeamName: "team-1", IsActive: true, MaxOpenReviews: 3}
+
+		mockUserRepo.On("UpdateProfile", ctx, "user-1", repository.UserProfileUpdate{MaxOpenReviews: &maxOpenReviews}).Return(nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(user, nil)
+
+		result, err := service.UpdateUser(ctx, "user-1", UserUpdate{MaxOpenReviews: &maxOpenReviews})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.MaxOpenReviews)
+		assert.Equal(t, "testuser", result.Username)
+		mockUserRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("normalizes email", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		email := "  Alice@Example.COM "
+
+		mockUserRepo.On("UpdateProfile", ctx, "user-1", mock.MatchedBy(func(update repository.UserProfileUpdate) bool {
+			return update.Email != nil && *update.Email == "alice@example.com" && update.Username == nil
+		})).Return(nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(&domain.User{UserID: "user-1", Email: "alice@example.com"}, nil)
+
+		result, err := service.UpdateUser(ctx, "user-1", UserUpdate{Email: &email})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "alice@example.com", result.Email)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty email removes it", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		email := ""
+
+		mockUserRepo.On("UpdateProfile", ctx, "user-1", mock.MatchedBy(func(update repository.UserProfileUpdate) bool {
+			return update.Email != nil && *update.Email == ""
+		})).Return(nil)
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(&domain.User{UserID: "user-1"}, nil)
+
+		result, err := service.UpdateUser(ctx, "user-1", UserUpdate{Email: &email})
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Email)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("UpdateProfile", ctx, "user-1", repository.UserProfileUpdate{}).Return(domain.ErrUserNotFound)
+
+		result, err := service.UpdateUser(ctx, "user-1", UserUpdate{})
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("UpdateProfile", ctx, "user-1", repository.UserProfileUpdate{}).Return(assert.AnError)
+
+		result, err := service.UpdateUser(ctx, "user-1", UserUpdate{})
+
+		assert.Equal(t, assert.AnError, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestUserServiceSetIsActiveUpdateError(t *testing.T) {
 	ctx := context.Background()
 
@@ -128,3 +217,177 @@ func TestUserServiceSetIsActiveUpdateError(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestUserServiceAbsences(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("add absence", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("AddAbsence", ctx, "user-1", mock.MatchedBy(func(a *domain.Absence) bool {
+			return a.AbsenceID != "" && a.From.Equal(from) && a.Handoff
+		})).Return(nil)
+
+		absence, err := service.AddAbsence(ctx, "user-1", &domain.Absence{From: from, To: from.Add(24 * time.Hour), Handoff: true})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, absence.AbsenceID)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("add invalid absence", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		absence, err := service.AddAbsence(ctx, "user-1", &domain.Absence{From: from, To: from})
+
+		assert.Equal(t, domain.ErrInvalidAbsence, err)
+		assert.Nil(t, absence)
+		mockUserRepo.AssertNotCalled(t, "AddAbsence")
+	})
+
+	t.Run("add absence to unknown user", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("AddAbsence", ctx, "user-1", mock.AnythingOfType("*domain.Absence")).Return(domain.ErrUserNotFound)
+
+		absence, err := service.AddAbsence(ctx, "user-1", &domain.Absence{From: from, To: from.Add(time.Hour)})
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+		assert.Nil(t, absence)
+	})
+
+	t.Run("list absences ordered by start", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		user := &domain.User{UserID: "user-1", Absences: []domain.Absence{
+			{AbsenceID: "later", From: from.Add(72 * time.Hour), To: from.Add(96 * time.Hour)},
+			{AbsenceID: "earlier", From: from, To: from.Add(24 * time.Hour)},
+		}}
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(user, nil)
+
+		absences, err := service.ListAbsences(ctx, "user-1")
+
+		require.NoError(t, err)
+		require.Len(t, absences, 2)
+		assert.Equal(t, "earlier", absences[0].AbsenceID)
+		assert.Equal(t, "later", absences[1].AbsenceID)
+		assert.Equal(t, "later", user.Absences[0].AbsenceID)
+	})
+
+	t.Run("list absences of unknown user", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("GetByID", ctx, "user-1").Return(nil, domain.ErrUserNotFound)
+
+		absences, err := service.ListAbsences(ctx, "user-1")
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+		assert.Nil(t, absences)
+	})
+
+	t.Run("remove absence", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("RemoveAbsence", ctx, "user-1", "abs-1").Return(domain.ErrAbsenceNotFound)
+
+		err := service.RemoveAbsence(ctx, "user-1", "abs-1")
+
+		assert.Equal(t, domain.ErrAbsenceNotFound, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserServiceListUsers(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("first page by username", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		active := true
+		users := []*domain.User{
+			{UserID: "user-2", Username: "alice"},
+			{UserID: "user-1", Username: "bob"},
+			{UserID: "user-3", Username: "carol"},
+		}
+		mockUserRepo.On("List", ctx, repository.UserListQuery{
+			TeamName: "backend",
+			IsActive: &active,
+			SortBy:   domain.UserSortUsername,
+			Limit:    3,
+		}).Return(users, nil)
+
+		page, err := service.ListUsers(ctx, UserListParams{TeamName: "backend", IsActive: &active, SortBy: domain.UserSortUsername, Limit: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, users[:2], page.Users)
+
+		var cursor userListCursor
+		require.NoError(t, decodeCursor(page.NextCursor, &cursor))
+		assert.Equal(t, userListCursor{SortBy: domain.UserSortUsername, Order: "asc", Value: "bob", UserID: "user-1"}, cursor)
+	})
+
+	t.Run("last page continues from cursor", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		cursor := encodeCursor(userListCursor{SortBy: domain.UserSortID, Order: "desc", Value: "user-3", UserID: "user-3"})
+		users := []*domain.User{{UserID: "user-2"}}
+		mockUserRepo.On("List", ctx, repository.UserListQuery{
+			SortBy:      domain.UserSortID,
+			Descending:  true,
+			AfterValue:  "user-3",
+			AfterUserID: "user-3",
+			Limit:       DefaultPageLimit + 1,
+		}).Return(users, nil)
+
+		page, err := service.ListUsers(ctx, UserListParams{Order: "desc", Cursor: cursor})
+
+		require.NoError(t, err)
+		assert.Equal(t, users, page.Users)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		service := NewUserService(new(mocks.MockUserRepository), logger)
+		otherSort := encodeCursor(userListCursor{SortBy: domain.UserSortUsername, Order: "asc", UserID: "user-1"})
+
+		cases := map[string]UserListParams{
+			"unknown sort":      {SortBy: "email"},
+			"unknown order":     {Order: "up"},
+			"negative limit":    {Limit: -1},
+			"malformed cursor":  {Cursor: "not a cursor"},
+			"cursor of another": {Cursor: otherSort},
+		}
+
+		for name, params := range cases {
+			t.Run(name, func(t *testing.T) {
+				page, err := service.ListUsers(ctx, params)
+
+				assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+				assert.Nil(t, page)
+			})
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		service := NewUserService(mockUserRepo, logger)
+
+		mockUserRepo.On("List", ctx, mock.Anything).Return(nil, assert.AnError)
+
+		page, err := service.ListUsers(ctx, UserListParams{})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, page)
+	})
+}
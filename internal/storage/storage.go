@@ -0,0 +1,92 @@
+// Package storage opens the repositories of the backend chosen by STORAGE_BACKEND
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"assignment-service/internal/config"
+	"assignment-service/internal/repository"
+	"assignment-service/internal/repository/memory"
+	"assignment-service/internal/repository/mongodb"
+	"assignment-service/internal/repository/postgres"
+	"assignment-service/internal/repository/sqlite"
+
+	"go.uber.org/zap"
+)
+
+type Storage struct {
+	repository.Repositories
+
+	close func(ctx context.Context) error
+}
+
+func Open(ctx context.Context, cfg *config.Config, logger *zap.Logger) (*Storage, error) {
+	switch cfg.StorageBackend {
+	case config.StorageMongoDB:
+		client, err := mongodb.NewClient(ctx, cfg.MongoURI, cfg.MongoDB, cfg.MongoConnectTimeout, cfg.MongoAllowStandalone, logger)
+		if errors.Is(err, mongodb.ErrNoTransactions) {
+			return nil, fmt.Errorf("%w, set MONGO_ALLOW_STANDALONE=true to run with writes that are not atomic", err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &Storage{
+			Repositories: repository.Repositories{
+				Users:      mongodb.NewUserRepository(client, logger),
+				Teams:      mongodb.NewTeamRepository(client, logger),
+				PRs:        mongodb.NewPRRepository(client, logger),
+				PREvents:   mongodb.NewPREventRepository(client, logger),
+				UnitOfWork: mongodb.NewUnitOfWork(client, logger),
+			},
+			close: client.Close,
+		}, nil
+
+	case config.StoragePostgres:
+		client, err := postgres.NewClient(ctx, cfg.PostgresURI, cfg.PostgresConnectTimeout, logger)
+		if err != nil {
+			return nil, err
+		}
+		return &Storage{
+			Repositories: repository.Repositories{
+				Users:      postgres.NewUserRepository(client, logger),
+				Teams:      postgres.NewTeamRepository(client, logger),
+				PRs:        postgres.NewPRRepository(client, logger),
+				PREvents:   postgres.NewPREventRepository(client, logger),
+				UnitOfWork: postgres.NewUnitOfWork(client),
+			},
+			close: client.Close,
+		}, nil
+
+	case config.StorageSQLite:
+		client, err := sqlite.NewClient(ctx, cfg.SQLitePath, logger)
+		if err != nil {
+			return nil, err
+		}
+		return &Storage{
+			Repositories: repository.Repositories{
+				Users:      sqlite.NewUserRepository(client, logger),
+				Teams:      sqlite.NewTeamRepository(client, logger),
+				PRs:        sqlite.NewPRRepository(client, logger),
+				PREvents:   sqlite.NewPREventRepository(client, logger),
+				UnitOfWork: sqlite.NewUnitOfWork(client),
+			},
+			close: client.Close,
+		}, nil
+
+	case config.StorageMemory:
+		return &Storage{
+			Repositories: memory.NewRepositories(),
+			close:        func(context.Context) error { return nil },
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.StorageBackend)
+	}
+}
+
+// Close releases the database connection, data of the memory backend is lost
+func (s *Storage) Close(ctx context.Context) error {
+	return s.close(ctx)
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"assignment-service/internal/config"
+	"assignment-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("memory", func(t *testing.T) {
+		store, err := Open(ctx, &config.Config{StorageBackend: config.StorageMemory}, zap.NewNop())
+		require.NoError(t, err)
+
+		require.NoError(t, store.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+		exists, err := store.Teams.Exists(ctx, "backend")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		assert.NoError(t, store.Close(ctx))
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		cfg := &config.Config{StorageBackend: config.StorageSQLite, SQLitePath: filepath.Join(t.TempDir(), "service.db")}
+		store, err := Open(ctx, cfg, zap.NewNop())
+		require.NoError(t, err)
+
+		require.NoError(t, store.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+		require.NoError(t, store.Close(ctx))
+
+		// the data outlives the process
+		store, err = Open(ctx, cfg, zap.NewNop())
+		require.NoError(t, err)
+		exists, err := store.Teams.Exists(ctx, "backend")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		assert.NoError(t, store.Close(ctx))
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		store, err := Open(ctx, &config.Config{StorageBackend: "redis"}, zap.NewNop())
+
+		assert.ErrorContains(t, err, `unknown storage backend "redis"`)
+		assert.Nil(t, store)
+	})
+}